@@ -0,0 +1,98 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+// This package contains the flags shared by the commands that use the address book.
+package book
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/cartesi/rollups-node/pkg/addresses"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+// Chain ID of the devnet, the only chain where the test addresses are deployed.
+const devnetChainID = 31337
+
+// Flags that select the address book.
+type Flags struct {
+	paths       []string
+	application string
+	verify      bool
+	devnetOnly  bool
+}
+
+// AddFlags adds the address book flags to the command.
+func AddFlags(cmd *cobra.Command) *Flags {
+	return addFlags(cmd, "if set, load the address book from the given file or deployments "+
+		"directory; else, use test addresses. If repeated, the books are merged in order")
+}
+
+// AddRequiredFlags adds the address book flags to the command, requiring the address book.
+// It is used by the commands that must not fall back to the test addresses.
+func AddRequiredFlags(cmd *cobra.Command) *Flags {
+	f := addFlags(cmd, "load the address book from the given file or deployments directory. "+
+		"If repeated, the books are merged in order")
+	cobra.CheckErr(cmd.MarkFlagRequired("address-book"))
+	return f
+}
+
+// AddDevnetFlags adds the address book flags to the command, falling back to the test addresses
+// only when the chain is the devnet.
+func AddDevnetFlags(cmd *cobra.Command) *Flags {
+	f := addFlags(cmd, "if set, load the address book from the given file or deployments "+
+		"directory; else, use test addresses, which only exist in the devnet. "+
+		"If repeated, the books are merged in order")
+	f.devnetOnly = true
+	return f
+}
+
+func addFlags(cmd *cobra.Command, addressBookUsage string) *Flags {
+	var f Flags
+
+	cmd.Flags().StringArrayVar(&f.paths, "address-book", nil, addressBookUsage)
+
+	cmd.Flags().StringVar(&f.application, "application", "",
+		"name of the application in a multi-application address book")
+
+	cmd.Flags().BoolVar(&f.verify, "verify-address-book", false,
+		"check the address book against the blockchain before using it")
+
+	return &f
+}
+
+// Load the address book selected by the flags.
+// The CARTESI_CONTRACTS_*_ADDRESS environment variables override its addresses.
+func (f *Flags) Load(ctx context.Context, client *ethclient.Client) (*addresses.Book, error) {
+	var book *addresses.Book
+	var err error
+	if len(f.paths) == 0 && f.application == "" {
+		if f.devnetOnly {
+			chainID, err := client.ChainID(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("get chain id: %v", err)
+			}
+			if chainID.Uint64() != devnetChainID {
+				return nil, fmt.Errorf("the test addresses only exist in the devnet "+
+					"(chain %v), but the chain is %v: set --address-book", devnetChainID, chainID)
+			}
+		}
+		book = addresses.GetTestBook()
+		err = book.ApplyEnvironment(os.LookupEnv)
+	} else {
+		book, err = addresses.LoadBook(f.application, f.paths...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if f.verify {
+		err = book.Verify(ctx, client)
+		if err != nil {
+			return nil, fmt.Errorf("invalid address book:\n%v", err)
+		}
+	}
+	return book, nil
+}
@@ -0,0 +1,119 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+// This package contains the flags shared by the read commands that decode the payloads of
+// vouchers and notices.
+package decode
+
+import (
+	"github.com/cartesi/rollups-node/pkg/payloads"
+	"github.com/cartesi/rollups-node/pkg/readerclient"
+	"github.com/spf13/cobra"
+)
+
+// Voucher with its payload decoded as a method call.
+type Voucher struct {
+	readerclient.Voucher
+	Call        *payloads.Call `json:"call,omitempty"`
+	DecodeError string         `json:"decodeError,omitempty"`
+}
+
+// Notice with its payload decoded with the ABI types.
+type Notice struct {
+	readerclient.Notice
+	Values      []payloads.Arg `json:"values,omitempty"`
+	DecodeError string         `json:"decodeError,omitempty"`
+}
+
+// Flags that decode voucher payloads.
+type VoucherFlags struct {
+	decode   bool
+	abiFiles []string
+}
+
+// AddVoucherFlags adds the flags that decode voucher payloads to the command.
+func AddVoucherFlags(cmd *cobra.Command) *VoucherFlags {
+	var f VoucherFlags
+
+	cmd.Flags().BoolVar(&f.decode, "decode", false,
+		"decode the voucher payloads as calls to the token transfer methods, "+
+			"CartesiDApp.withdrawEther or the methods in the --abi files")
+
+	cmd.Flags().StringArrayVar(&f.abiFiles, "abi", nil,
+		"contract ABI or artifact JSON file with more methods to decode. It may be repeated")
+
+	return &f
+}
+
+// Enabled reports whether the payloads should be decoded.
+func (f *VoucherFlags) Enabled() bool {
+	return f.decode
+}
+
+// Decode the payloads of the vouchers.
+// The vouchers that can't be decoded have a decode error instead of the call.
+func (f *VoucherFlags) Decode(vouchers ...readerclient.Voucher) ([]Voucher, error) {
+	decoder, err := payloads.NewDecoder()
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range f.abiFiles {
+		err = decoder.AddABIFile(path)
+		if err != nil {
+			return nil, err
+		}
+	}
+	decoded := make([]Voucher, len(vouchers))
+	for i, voucher := range vouchers {
+		decoded[i].Voucher = voucher
+		decoded[i].Call, err = decoder.Decode(voucher.Payload)
+		if err != nil {
+			decoded[i].DecodeError = err.Error()
+		}
+	}
+	return decoded, nil
+}
+
+// Flags that decode notice payloads.
+type NoticeFlags struct {
+	decode bool
+	types  string
+}
+
+// AddNoticeFlags adds the flags that decode notice payloads to the command.
+func AddNoticeFlags(cmd *cobra.Command) *NoticeFlags {
+	var f NoticeFlags
+
+	cmd.Flags().BoolVar(&f.decode, "decode", false,
+		"decode the notice payloads with the ABI types in --types")
+
+	cmd.Flags().StringVar(&f.types, "types", "",
+		"comma-separated ABI types of the notice payloads, such as address,uint256")
+
+	cmd.MarkFlagsRequiredTogether("decode", "types")
+
+	return &f
+}
+
+// Enabled reports whether the payloads should be decoded.
+func (f *NoticeFlags) Enabled() bool {
+	return f.decode
+}
+
+// Decode the payloads of the notices.
+// The notices that can't be decoded have a decode error instead of the values.
+func (f *NoticeFlags) Decode(notices ...readerclient.Notice) ([]Notice, error) {
+	types, err := payloads.ParseTypes(f.types)
+	if err != nil {
+		return nil, err
+	}
+	decoded := make([]Notice, len(notices))
+	for i, notice := range notices {
+		decoded[i].Notice = notice
+		decoded[i].Values, err = payloads.DecodeNotice(types, notice.Payload)
+		if err != nil {
+			decoded[i].DecodeError = err.Error()
+		}
+	}
+	return decoded, nil
+}
@@ -0,0 +1,139 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+// This package contains the flags and the flow shared by the commands that send administrative
+// operations: they show the operation, run it with eth_call, ask for confirmation and send it.
+package operation
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/book"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/signer"
+	"github.com/cartesi/rollups-node/pkg/addresses"
+	"github.com/cartesi/rollups-node/pkg/ethutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+// Flags of the commands that send administrative operations.
+type Flags struct {
+	ethEndpoint string
+	signer      *signer.Flags
+	book        *book.Flags
+	yes         bool
+	dryRun      bool
+}
+
+// AddFlags adds the endpoint, signer, address book and confirmation flags to the command.
+// The address book is required, so the operations are never sent to the test addresses.
+func AddFlags(cmd *cobra.Command) *Flags {
+	var f Flags
+
+	cmd.Flags().StringVar(&f.ethEndpoint, "eth-endpoint", "http://localhost:8545",
+		"ethereum node JSON-RPC endpoint")
+
+	f.signer = signer.AddFlags(cmd)
+
+	f.book = book.AddRequiredFlags(cmd)
+
+	cmd.Flags().BoolVarP(&f.yes, "yes", "y", false,
+		"send the transaction without asking for confirmation")
+
+	cmd.Flags().BoolVar(&f.dryRun, "dry-run", false,
+		"only show the operation and run it with eth_call, without sending the transaction")
+
+	cmd.MarkFlagsMutuallyExclusive("yes", "dry-run")
+
+	return &f
+}
+
+// Function that creates the operation with the current state of the contracts.
+type Builder func(
+	ctx context.Context,
+	client *ethclient.Client,
+	book *addresses.Book,
+) (*ethutil.AdminOperation, error)
+
+// Run creates the operation, shows it with the current and new state, runs it with eth_call,
+// asks for confirmation and sends it, waiting for the receipt.
+func (f *Flags) Run(ctx context.Context, build Builder) error {
+	client, err := ethclient.DialContext(ctx, f.ethEndpoint)
+	if err != nil {
+		return err
+	}
+	slog.Info("Connected", "eth-endpoint", f.ethEndpoint)
+
+	signer, err := f.signer.NewSigner(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	txOpts, err := f.signer.TxOptions()
+	if err != nil {
+		return err
+	}
+
+	book, err := f.book.Load(ctx, client)
+	if err != nil {
+		return err
+	}
+
+	op, err := build(ctx, client, book)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Operation: %v\n", op)
+	fmt.Printf("Contract:  %v\n", op.Contract)
+	fmt.Printf("Sender:    %v\n", signer.Account())
+	for _, change := range op.Changes {
+		fmt.Printf("Change:    %v\n", change.Name)
+		fmt.Printf("  current: %v\n", change.Current)
+		fmt.Printf("  new:     %v\n", change.New)
+	}
+
+	err = op.DryRun(ctx, client, signer.Account())
+	if err != nil {
+		return fmt.Errorf("dry run failed: %w", err)
+	}
+	fmt.Println("Dry run succeeded")
+	if f.dryRun {
+		return nil
+	}
+
+	if !f.yes {
+		confirmed, err := confirm("Send the transaction?")
+		if err != nil {
+			return err
+		}
+		if !confirmed {
+			return errors.New("operation canceled")
+		}
+	}
+
+	receipt, err := op.Send(ctx, client, signer, txOpts)
+	if err != nil {
+		return err
+	}
+	slog.Info("Transaction confirmed", "tx-hash", receipt.TxHash,
+		"block-number", receipt.BlockNumber, "gas-used", receipt.GasUsed)
+	return nil
+}
+
+// Ask the question on the terminal and report whether the answer is yes.
+func confirm(question string) (bool, error) {
+	fmt.Printf("%v [y/N] ", question)
+	answer, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && answer == "" {
+		return false, fmt.Errorf("failed to read confirmation: %v", err)
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes", nil
+}
@@ -0,0 +1,63 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+// This package contains the flags shared by the read commands that get lists paginated by the
+// GraphQL API.
+package page
+
+import (
+	"context"
+	"log/slog"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/cartesi/rollups-node/pkg/readerclient"
+	"github.com/spf13/cobra"
+)
+
+// Flags that select the pages of the list.
+type Flags struct {
+	first int
+	after string
+	all   bool
+}
+
+// AddFlags adds the pagination flags to the command.
+func AddFlags(cmd *cobra.Command) *Flags {
+	var f Flags
+
+	cmd.Flags().IntVar(&f.first, "first", 0,
+		"if set, get a single page with this number of entries; with --all, the page size")
+
+	cmd.Flags().StringVar(&f.after, "after", "",
+		"if set, get the entries after this cursor, which is logged when there are more entries")
+
+	cmd.Flags().BoolVar(&f.all, "all", false,
+		"get all the entries after --after, walking the pages; this is the default "+
+			"when neither --first nor --after is set")
+
+	return &f
+}
+
+// Read gets the entries selected by the flags with the page function, such as
+// readerclient.GetVouchersPage: a single page if --first or --after is set without --all,
+// and all the pages otherwise.
+// When it gets a single page and there are more entries, it logs the cursor of the next page.
+func Read[T any](
+	ctx context.Context,
+	client graphql.Client,
+	f *Flags,
+	fetch readerclient.PageFunc[T],
+) ([]T, error) {
+	if f.all || (f.first == 0 && f.after == "") {
+		return readerclient.NewIterator(client, fetch, f.first, f.after).All(ctx)
+	}
+	entries, pageInfo, err := fetch(ctx, client, f.first, f.after)
+	if err != nil {
+		return nil, err
+	}
+	if pageInfo.HasNextPage {
+		slog.Warn("There are more entries; use --after to get the next page or --all",
+			"after", pageInfo.EndCursor)
+	}
+	return entries, nil
+}
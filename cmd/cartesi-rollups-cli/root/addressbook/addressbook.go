@@ -0,0 +1,60 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package addressbook
+
+import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/book"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "address-book",
+	Short: "Print the address book used by the other commands",
+	Long: `Print the address book used by the other commands, after merging the given books and
+the CARTESI_CONTRACTS_*_ADDRESS environment variables.
+The address book may be the output of sunodo address-book --json, the deployment.json of
+gen-devnet, a hardhat-deploy deployments directory, a foundry broadcast file or a
+multi-application book. The output is compatible with all the commands.`,
+	Example: examples,
+	Run:     run,
+}
+
+const examples = `# Check the addresses of a hardhat-deploy deployment against the blockchain:
+cartesi-rollups-cli address-book --address-book deployments/localhost --verify-address-book
+
+# Print the addresses of an application in a multi-application book:
+cartesi-rollups-cli address-book --address-book applications.json --application echo`
+
+var (
+	ethEndpoint string
+	bookFlags   *book.Flags
+)
+
+func init() {
+	Cmd.Flags().StringVar(&ethEndpoint, "eth-endpoint", "http://localhost:8545",
+		"ethereum node JSON-RPC endpoint")
+
+	bookFlags = book.AddFlags(Cmd)
+}
+
+func run(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	client, err := ethclient.DialContext(ctx, ethEndpoint)
+	cobra.CheckErr(err)
+
+	book, err := bookFlags.Load(ctx, client)
+	cobra.CheckErr(err)
+	if cmd.Flags().Changed("verify-address-book") {
+		slog.Info("The address book matches the blockchain", "eth-endpoint", ethEndpoint)
+	}
+
+	data, err := json.MarshalIndent(book, "", "  ")
+	cobra.CheckErr(err)
+	fmt.Println(string(data))
+}
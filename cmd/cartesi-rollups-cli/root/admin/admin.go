@@ -0,0 +1,30 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package admin
+
+import (
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/admin/migrateapplication"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/admin/migratehistory"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/admin/sethistory"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/admin/transferownership"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/admin/withdrawether"
+
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Administer the consensus and the ownership of the application contracts",
+	Long: `Administer the consensus and the ownership of the application contracts.
+Each command shows the operation with the current and the new state of the contracts, runs it
+with eth_call, asks for confirmation, and then sends the transaction and waits for the receipt.`,
+}
+
+func init() {
+	Cmd.AddCommand(migrateapplication.Cmd)
+	Cmd.AddCommand(sethistory.Cmd)
+	Cmd.AddCommand(migratehistory.Cmd)
+	Cmd.AddCommand(transferownership.Cmd)
+	Cmd.AddCommand(withdrawether.Cmd)
+}
@@ -0,0 +1,56 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package migrateapplication
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/operation"
+	"github.com/cartesi/rollups-node/pkg/addresses"
+	"github.com/cartesi/rollups-node/pkg/ethutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "migrate-application",
+	Short: "Move the application to a new consensus with CartesiDApp.migrateToConsensus",
+	Long: `Move the application to a new consensus with CartesiDApp.migrateToConsensus.
+Only the owner of the application may do it. The vouchers and notices are then validated
+against the claims of the new consensus, so its History must have the claims of the
+application, which is usually done by moving the History with migrate-history first.`,
+	Example: examples,
+	Run:     run,
+}
+
+const examples = `# Move the application in the address book to a new Authority:
+cartesi-rollups-cli admin migrate-application --address-book deployment.json --consensus $AUTHORITY`
+
+var (
+	operationFlags *operation.Flags
+	consensus      string
+)
+
+func init() {
+	operationFlags = operation.AddFlags(Cmd)
+
+	Cmd.Flags().StringVar(&consensus, "consensus", "",
+		"address of the new consensus")
+
+	cobra.CheckErr(Cmd.MarkFlagRequired("consensus"))
+}
+
+func run(cmd *cobra.Command, args []string) {
+	if !common.IsHexAddress(consensus) {
+		cobra.CheckErr(fmt.Errorf("invalid --consensus: %v", consensus))
+	}
+	cobra.CheckErr(operationFlags.Run(cmd.Context(),
+		func(ctx context.Context, client *ethclient.Client, book *addresses.Book) (
+			*ethutil.AdminOperation, error) {
+			return ethutil.MigrateApplicationToConsensus(
+				ctx, client, book, common.HexToAddress(consensus))
+		}))
+}
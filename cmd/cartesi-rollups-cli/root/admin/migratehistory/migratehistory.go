@@ -0,0 +1,57 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package migratehistory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/operation"
+	"github.com/cartesi/rollups-node/pkg/addresses"
+	"github.com/cartesi/rollups-node/pkg/ethutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "migrate-history",
+	Short: "Move the History to a new consensus with History.migrateToConsensus",
+	Long: `Move the History to a new consensus with History.migrateToConsensus.
+The new consensus becomes the owner of the History, so only it may submit claims, and the claims
+already in the History remain valid. If the History is owned by the Authority in the address
+book, the operation goes through Authority.migrateHistoryToConsensus, which only the owner of
+the Authority may call.`,
+	Example: examples,
+	Run:     run,
+}
+
+const examples = `# Move the History in the address book to a new Authority:
+cartesi-rollups-cli admin migrate-history --address-book deployment.json --consensus $AUTHORITY`
+
+var (
+	operationFlags *operation.Flags
+	consensus      string
+)
+
+func init() {
+	operationFlags = operation.AddFlags(Cmd)
+
+	Cmd.Flags().StringVar(&consensus, "consensus", "",
+		"address of the new consensus")
+
+	cobra.CheckErr(Cmd.MarkFlagRequired("consensus"))
+}
+
+func run(cmd *cobra.Command, args []string) {
+	if !common.IsHexAddress(consensus) {
+		cobra.CheckErr(fmt.Errorf("invalid --consensus: %v", consensus))
+	}
+	cobra.CheckErr(operationFlags.Run(cmd.Context(),
+		func(ctx context.Context, client *ethclient.Client, book *addresses.Book) (
+			*ethutil.AdminOperation, error) {
+			return ethutil.MigrateHistoryToConsensus(
+				ctx, client, book, common.HexToAddress(consensus))
+		}))
+}
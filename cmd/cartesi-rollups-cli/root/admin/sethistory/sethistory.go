@@ -0,0 +1,54 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package sethistory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/operation"
+	"github.com/cartesi/rollups-node/pkg/addresses"
+	"github.com/cartesi/rollups-node/pkg/ethutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "set-history",
+	Short: "Set the History of the Authority with Authority.setHistory",
+	Long: `Set the History of the Authority with Authority.setHistory.
+Only the owner of the Authority may do it. The Authority then submits its claims to the new
+History and reads the claims from it, so the node must use the new History as well.`,
+	Example: examples,
+	Run:     run,
+}
+
+const examples = `# Point the Authority in the address book to a new History:
+cartesi-rollups-cli admin set-history --address-book deployment.json --history $HISTORY`
+
+var (
+	operationFlags *operation.Flags
+	history        string
+)
+
+func init() {
+	operationFlags = operation.AddFlags(Cmd)
+
+	Cmd.Flags().StringVar(&history, "history", "",
+		"address of the new History")
+
+	cobra.CheckErr(Cmd.MarkFlagRequired("history"))
+}
+
+func run(cmd *cobra.Command, args []string) {
+	if !common.IsHexAddress(history) {
+		cobra.CheckErr(fmt.Errorf("invalid --history: %v", history))
+	}
+	cobra.CheckErr(operationFlags.Run(cmd.Context(),
+		func(ctx context.Context, client *ethclient.Client, book *addresses.Book) (
+			*ethutil.AdminOperation, error) {
+			return ethutil.SetAuthorityHistory(ctx, client, book, common.HexToAddress(history))
+		}))
+}
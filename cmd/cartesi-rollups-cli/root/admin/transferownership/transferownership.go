@@ -0,0 +1,79 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package transferownership
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/operation"
+	"github.com/cartesi/rollups-node/pkg/addresses"
+	"github.com/cartesi/rollups-node/pkg/ethutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "transfer-ownership",
+	Short: "Transfer the ownership of the application, the Authority or the History",
+	Long: `Transfer the ownership of the application, the Authority or the History with
+transferOwnership. Only the current owner may do it, and it can't be undone by the
+current owner afterwards.`,
+	Example: examples,
+	Run:     run,
+}
+
+const examples = `# Transfer the ownership of the application to a multisig:
+cartesi-rollups-cli admin transfer-ownership --address-book deployment.json \
+    --contract application --new-owner $MULTISIG`
+
+// Contracts accepted by --contract and their names in the bindings.
+var contractNames = map[string]string{
+	"application": "CartesiDApp",
+	"authority":   "Authority",
+	"history":     "History",
+}
+
+var (
+	operationFlags *operation.Flags
+	contract       string
+	newOwner       string
+)
+
+func init() {
+	operationFlags = operation.AddFlags(Cmd)
+
+	Cmd.Flags().StringVar(&contract, "contract", "",
+		"contract whose ownership is transferred: application, authority or history")
+
+	cobra.CheckErr(Cmd.MarkFlagRequired("contract"))
+
+	Cmd.Flags().StringVar(&newOwner, "new-owner", "",
+		"address of the new owner")
+
+	cobra.CheckErr(Cmd.MarkFlagRequired("new-owner"))
+}
+
+func run(cmd *cobra.Command, args []string) {
+	name, ok := contractNames[contract]
+	if !ok {
+		cobra.CheckErr(fmt.Errorf(
+			"invalid --contract %q: expected application, authority or history", contract))
+	}
+	if !common.IsHexAddress(newOwner) {
+		cobra.CheckErr(fmt.Errorf("invalid --new-owner: %v", newOwner))
+	}
+	cobra.CheckErr(operationFlags.Run(cmd.Context(),
+		func(ctx context.Context, client *ethclient.Client, book *addresses.Book) (
+			*ethutil.AdminOperation, error) {
+			address := map[string]common.Address{
+				"CartesiDApp": book.CartesiDApp,
+				"Authority":   book.AuthorityAddress,
+				"History":     book.HistoryAddress,
+			}[name]
+			return ethutil.TransferOwnership(
+				ctx, client, name, address, common.HexToAddress(newOwner))
+		}))
+}
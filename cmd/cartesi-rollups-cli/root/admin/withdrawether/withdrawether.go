@@ -0,0 +1,66 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package withdrawether
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/operation"
+	"github.com/cartesi/rollups-node/pkg/addresses"
+	"github.com/cartesi/rollups-node/pkg/ethutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "withdraw-ether",
+	Short: "Withdraw ether from the application with CartesiDApp.withdrawEther",
+	Long: `Withdraw ether from the application with CartesiDApp.withdrawEther.
+The application only accepts this call from itself, so withdrawals usually come from vouchers
+executed with the execute command. The dry run shows whether the sender may call it directly.`,
+	Example: examples,
+	Run:     run,
+}
+
+const examples = `# Check whether 1 ether may be withdrawn, without sending the transaction:
+cartesi-rollups-cli admin withdraw-ether --address-book deployment.json \
+    --receiver $ACCOUNT --amount 1000000000000000000 --dry-run`
+
+var (
+	operationFlags *operation.Flags
+	receiver       string
+	amount         string
+)
+
+func init() {
+	operationFlags = operation.AddFlags(Cmd)
+
+	Cmd.Flags().StringVar(&receiver, "receiver", "",
+		"address that receives the ether")
+
+	cobra.CheckErr(Cmd.MarkFlagRequired("receiver"))
+
+	Cmd.Flags().StringVar(&amount, "amount", "",
+		"amount of ether in wei")
+
+	cobra.CheckErr(Cmd.MarkFlagRequired("amount"))
+}
+
+func run(cmd *cobra.Command, args []string) {
+	if !common.IsHexAddress(receiver) {
+		cobra.CheckErr(fmt.Errorf("invalid --receiver: %v", receiver))
+	}
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok || value.Sign() < 0 {
+		cobra.CheckErr(fmt.Errorf("invalid --amount: expected an amount in wei"))
+	}
+	cobra.CheckErr(operationFlags.Run(cmd.Context(),
+		func(ctx context.Context, client *ethclient.Client, book *addresses.Book) (
+			*ethutil.AdminOperation, error) {
+			return ethutil.WithdrawEther(ctx, client, book, common.HexToAddress(receiver), value)
+		}))
+}
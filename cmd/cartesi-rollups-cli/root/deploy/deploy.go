@@ -0,0 +1,197 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package deploy
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/book"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/signer"
+	"github.com/cartesi/rollups-node/pkg/ethutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "deploy",
+	Short: "Deploy an application with an Authority/History pair through the factories",
+	Long: `Deploy an Authority/History pair and a CartesiDApp through the
+AuthorityHistoryPairFactory and the CartesiDAppFactory in the address book.
+The template hash comes from the hash file of the machine snapshot. The contracts are deployed
+deterministically, so their addresses are predicted first, and the contracts that are already
+deployed with the same owners, template hash and salt are kept.
+The resulting address book is compatible with the --address-book flag of the other commands.`,
+	Example: examples,
+	Run:     run,
+}
+
+const examples = `# Deploy the application of a snapshot in the devnet and write its address book:
+cartesi-rollups-cli deploy --snapshot machine-snapshot --output deployment.json
+
+# Deploy it in another chain, with the factories of its address book:
+cartesi-rollups-cli deploy --snapshot machine-snapshot --eth-endpoint $RPC_URL \
+  --address-book deployments/sepolia --output deployment.json
+
+# Predict the addresses of a deployment with a custom owner and salt, without deploying it:
+cartesi-rollups-cli deploy --snapshot machine-snapshot --owner $OWNER --salt 0x01 --dry-run
+
+# Deploy an application that uses an existing Authority:
+cartesi-rollups-cli deploy --template-hash $HASH --consensus $AUTHORITY`
+
+var (
+	ethEndpoint    string
+	signerFlags    *signer.Flags
+	bookFlags      *book.Flags
+	snapshot       string
+	templateHash   string
+	owner          string
+	authorityOwner string
+	salt           string
+	consensus      string
+	output         string
+	dryRun         bool
+)
+
+func init() {
+	Cmd.Flags().StringVar(&ethEndpoint, "eth-endpoint", "http://localhost:8545",
+		"ethereum node JSON-RPC endpoint")
+
+	signerFlags = signer.AddFlags(Cmd)
+
+	bookFlags = book.AddDevnetFlags(Cmd)
+
+	Cmd.Flags().StringVar(&snapshot, "snapshot", "",
+		"machine snapshot directory, or its hash file, with the template hash")
+
+	Cmd.Flags().StringVar(&templateHash, "template-hash", "",
+		"template hash of the application hex-encoded starting with 0x")
+
+	Cmd.Flags().StringVar(&owner, "owner", "",
+		"owner of the application and of the Authority (default: the signer account)")
+
+	Cmd.Flags().StringVar(&authorityOwner, "authority-owner", "",
+		"if set, owner of the Authority instead of --owner")
+
+	Cmd.Flags().StringVar(&salt, "salt", "0x0",
+		"salt of the deterministic deployments hex-encoded starting with 0x")
+
+	Cmd.Flags().StringVar(&consensus, "consensus", "",
+		"if set, use this Authority instead of deploying a new Authority/History pair")
+
+	Cmd.Flags().StringVar(&output, "output", "",
+		"if set, write the address book to this file; else, print it")
+
+	Cmd.Flags().BoolVar(&dryRun, "dry-run", false,
+		"only predict the addresses, without deploying the contracts")
+
+	Cmd.MarkFlagsOneRequired("snapshot", "template-hash")
+	Cmd.MarkFlagsMutuallyExclusive("snapshot", "template-hash")
+	Cmd.MarkFlagsMutuallyExclusive("consensus", "authority-owner")
+}
+
+func run(cmd *cobra.Command, args []string) {
+	opts := &ethutil.DeployOptions{}
+	var err error
+	if snapshot != "" {
+		opts.TemplateHash, err = ethutil.ReadTemplateHash(snapshot)
+		cobra.CheckErr(err)
+	} else {
+		opts.TemplateHash, err = parseHash("template-hash", templateHash)
+		cobra.CheckErr(err)
+	}
+	opts.Salt, err = parseHash("salt", salt)
+	cobra.CheckErr(err)
+	if consensus != "" {
+		address, err := parseAddress("consensus", consensus)
+		cobra.CheckErr(err)
+		opts.Consensus = &address
+	}
+
+	ctx := cmd.Context()
+	client, err := ethclient.DialContext(ctx, ethEndpoint)
+	cobra.CheckErr(err)
+	slog.Info("Connected", "eth-endpoint", ethEndpoint)
+
+	signer, err := signerFlags.NewSigner(ctx, client)
+	cobra.CheckErr(err)
+
+	txOpts, err := signerFlags.TxOptions()
+	cobra.CheckErr(err)
+
+	book, err := bookFlags.Load(ctx, client)
+	cobra.CheckErr(err)
+
+	opts.ApplicationOwner = signer.Account()
+	if owner != "" {
+		opts.ApplicationOwner, err = parseAddress("owner", owner)
+		cobra.CheckErr(err)
+	}
+	opts.AuthorityOwner = opts.ApplicationOwner
+	if authorityOwner != "" {
+		opts.AuthorityOwner, err = parseAddress("authority-owner", authorityOwner)
+		cobra.CheckErr(err)
+	}
+
+	deployment, err := ethutil.PredictDeployment(ctx, client, book, opts)
+	cobra.CheckErr(err)
+	slog.Info("Predicted deployment",
+		"authority-address", deployment.Authority,
+		"history-address", deployment.History,
+		"application-address", deployment.Application,
+		"template-hash", opts.TemplateHash)
+
+	if !dryRun {
+		deployment, err = ethutil.DeployApplication(ctx, client, book, signer, opts, txOpts)
+		cobra.CheckErr(err)
+		if !deployment.NewAuthority && opts.Consensus == nil {
+			slog.Info("Authority/History pair was already deployed")
+		}
+		if deployment.NewApplication {
+			slog.Info("Application deployed", "application-address", deployment.Application,
+				"block-number", deployment.BlockNumber)
+		} else {
+			slog.Info("Application was already deployed",
+				"application-address", deployment.Application)
+		}
+	}
+
+	data, err := json.MarshalIndent(deployment.Book(book), "", "  ")
+	cobra.CheckErr(err)
+	if output == "" {
+		fmt.Println(string(data))
+		return
+	}
+	cobra.CheckErr(os.WriteFile(output, append(data, '\n'), 0644))
+	slog.Info("Address book written", "output", output)
+}
+
+func parseAddress(flag string, value string) (common.Address, error) {
+	if !common.IsHexAddress(value) {
+		return common.Address{}, fmt.Errorf("invalid --%v: %v", flag, value)
+	}
+	return common.HexToAddress(value), nil
+}
+
+// Parse a hex value of up to 32 bytes, such as 0x1, padding it on the left.
+func parseHash(flag string, value string) (common.Hash, error) {
+	digits, ok := strings.CutPrefix(value, "0x")
+	if !ok || len(digits) > 2*common.HashLength {
+		return common.Hash{}, fmt.Errorf("invalid --%v: expected up to 32 bytes "+
+			"hex-encoded starting with 0x", flag)
+	}
+	if len(digits)%2 == 1 {
+		digits = "0" + digits
+	}
+	bytes, err := hex.DecodeString(digits)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("invalid --%v: %v", flag, err)
+	}
+	return common.BytesToHash(bytes), nil
+}
@@ -0,0 +1,25 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package deposit
+
+import (
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/deposit/erc1155"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/deposit/erc20"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/deposit/erc721"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/deposit/ether"
+
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "deposit",
+	Short: "Deposit assets in the application through the portals",
+}
+
+func init() {
+	Cmd.AddCommand(ether.Cmd)
+	Cmd.AddCommand(erc20.Cmd)
+	Cmd.AddCommand(erc721.Cmd)
+	Cmd.AddCommand(erc1155.Cmd)
+}
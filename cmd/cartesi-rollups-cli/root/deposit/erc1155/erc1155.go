@@ -0,0 +1,140 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package erc1155
+
+import (
+	"fmt"
+	"log/slog"
+	"math/big"
+
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/book"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/signer"
+	"github.com/cartesi/rollups-node/pkg/ethutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "erc1155",
+	Short: "Deposit ERC-1155 tokens in the application through the ERC-1155 portals",
+	Long: `Deposit ERC-1155 tokens in the application through the ERC-1155 portals.
+A single token id goes through the ERC1155SinglePortal; multiple ones, or --batch, go through the
+ERC1155BatchPortal.`,
+	Example: examples,
+	Run:     run,
+}
+
+const examples = `# Deposit 10 units of token 1, approving the portal first if needed:
+cartesi-rollups-cli deposit erc1155 --token $TOKEN --token-id 1 --value 10
+
+# Deposit 10 units of token 1 and 20 units of token 2 in a single input:
+cartesi-rollups-cli deposit erc1155 --token $TOKEN --token-id 1,2 --value 10,20`
+
+var (
+	ethEndpoint      string
+	signerFlags      *signer.Flags
+	token            string
+	tokenIds         []string
+	values           []string
+	batch            bool
+	hexBaseLayerData string
+	hexExecLayerData string
+	bookFlags        *book.Flags
+)
+
+func init() {
+	Cmd.Flags().StringVar(&ethEndpoint, "eth-endpoint", "http://localhost:8545",
+		"ethereum node JSON-RPC endpoint")
+
+	signerFlags = signer.AddFlags(Cmd)
+
+	Cmd.Flags().StringVar(&token, "token", "",
+		"address of the ERC-1155 token contract")
+
+	cobra.CheckErr(Cmd.MarkFlagRequired("token"))
+
+	Cmd.Flags().StringSliceVar(&tokenIds, "token-id", nil,
+		"ids of the tokens")
+
+	cobra.CheckErr(Cmd.MarkFlagRequired("token-id"))
+
+	Cmd.Flags().StringSliceVar(&values, "value", nil,
+		"amount of each token, in the order of the ids")
+
+	cobra.CheckErr(Cmd.MarkFlagRequired("value"))
+
+	Cmd.Flags().BoolVar(&batch, "batch", false,
+		"deposit through the ERC1155BatchPortal even with a single token id")
+
+	Cmd.Flags().StringVar(&hexBaseLayerData, "base-layer-data", "0x",
+		"data for the token contract hex-encoded starting with 0x")
+
+	Cmd.Flags().StringVar(&hexExecLayerData, "exec-layer-data", "0x",
+		"data for the application hex-encoded starting with 0x")
+
+	bookFlags = book.AddFlags(Cmd)
+}
+
+func run(cmd *cobra.Command, args []string) {
+	if !common.IsHexAddress(token) {
+		cobra.CheckErr(fmt.Errorf("invalid --token: %v", token))
+	}
+	if len(tokenIds) != len(values) {
+		cobra.CheckErr(fmt.Errorf("got %v token ids and %v values", len(tokenIds), len(values)))
+	}
+	ids, err := parseAmounts("token-id", tokenIds)
+	cobra.CheckErr(err)
+	amounts, err := parseAmounts("value", values)
+	cobra.CheckErr(err)
+	baseLayerData, err := hexutil.Decode(hexBaseLayerData)
+	cobra.CheckErr(err)
+	execLayerData, err := hexutil.Decode(hexExecLayerData)
+	cobra.CheckErr(err)
+
+	ctx := cmd.Context()
+	client, err := ethclient.DialContext(ctx, ethEndpoint)
+	cobra.CheckErr(err)
+	slog.Info("Connected", "eth-endpoint", ethEndpoint)
+
+	signer, err := signerFlags.NewSigner(ctx, client)
+	cobra.CheckErr(err)
+
+	txOpts, err := signerFlags.TxOptions()
+	cobra.CheckErr(err)
+
+	book, err := bookFlags.Load(ctx, client)
+	cobra.CheckErr(err)
+
+	slog.Info("Depositing ERC-1155 tokens",
+		"application-address", book.CartesiDApp,
+		"token", token,
+		"token-ids", ids,
+		"values", amounts,
+	)
+	var inputIndex int
+	if len(ids) == 1 && !batch {
+		inputIndex, err = ethutil.DepositSingleERC1155Token(ctx, client, book, signer,
+			common.HexToAddress(token), ids[0], amounts[0], baseLayerData, execLayerData, txOpts)
+	} else {
+		inputIndex, err = ethutil.DepositBatchERC1155Token(ctx, client, book, signer,
+			common.HexToAddress(token), ids, amounts, baseLayerData, execLayerData, txOpts)
+	}
+	cobra.CheckErr(err)
+
+	slog.Info("Input added", "input-index", inputIndex)
+}
+
+func parseAmounts(flag string, values []string) ([]*big.Int, error) {
+	amounts := make([]*big.Int, len(values))
+	for i, value := range values {
+		amount, ok := new(big.Int).SetString(value, 10)
+		if !ok || amount.Sign() < 0 {
+			return nil, fmt.Errorf("invalid --%v: %v", flag, value)
+		}
+		amounts[i] = amount
+	}
+	return amounts, nil
+}
@@ -0,0 +1,96 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package erc20
+
+import (
+	"fmt"
+	"log/slog"
+	"math/big"
+
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/book"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/signer"
+	"github.com/cartesi/rollups-node/pkg/ethutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "erc20",
+	Short:   "Deposit ERC-20 tokens in the application through the ERC20Portal",
+	Example: examples,
+	Run:     run,
+}
+
+const examples = `# Deposit 100 base units of a token, approving the portal first if needed:
+cartesi-rollups-cli deposit erc20 --token $TOKEN --amount 100`
+
+var (
+	ethEndpoint      string
+	signerFlags      *signer.Flags
+	token            string
+	amount           string
+	hexExecLayerData string
+	bookFlags        *book.Flags
+)
+
+func init() {
+	Cmd.Flags().StringVar(&ethEndpoint, "eth-endpoint", "http://localhost:8545",
+		"ethereum node JSON-RPC endpoint")
+
+	signerFlags = signer.AddFlags(Cmd)
+
+	Cmd.Flags().StringVar(&token, "token", "",
+		"address of the ERC-20 token contract")
+
+	cobra.CheckErr(Cmd.MarkFlagRequired("token"))
+
+	Cmd.Flags().StringVar(&amount, "amount", "",
+		"amount of tokens in base units")
+
+	cobra.CheckErr(Cmd.MarkFlagRequired("amount"))
+
+	Cmd.Flags().StringVar(&hexExecLayerData, "exec-layer-data", "0x",
+		"data for the application hex-encoded starting with 0x")
+
+	bookFlags = book.AddFlags(Cmd)
+}
+
+func run(cmd *cobra.Command, args []string) {
+	if !common.IsHexAddress(token) {
+		cobra.CheckErr(fmt.Errorf("invalid --token: %v", token))
+	}
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok || value.Sign() < 0 {
+		cobra.CheckErr(fmt.Errorf("invalid --amount: expected an amount in base units"))
+	}
+	execLayerData, err := hexutil.Decode(hexExecLayerData)
+	cobra.CheckErr(err)
+
+	ctx := cmd.Context()
+	client, err := ethclient.DialContext(ctx, ethEndpoint)
+	cobra.CheckErr(err)
+	slog.Info("Connected", "eth-endpoint", ethEndpoint)
+
+	signer, err := signerFlags.NewSigner(ctx, client)
+	cobra.CheckErr(err)
+
+	txOpts, err := signerFlags.TxOptions()
+	cobra.CheckErr(err)
+
+	book, err := bookFlags.Load(ctx, client)
+	cobra.CheckErr(err)
+
+	slog.Info("Depositing ERC-20 tokens",
+		"application-address", book.CartesiDApp,
+		"token", token,
+		"amount", value,
+	)
+	inputIndex, err := ethutil.DepositERC20Tokens(
+		ctx, client, book, signer, common.HexToAddress(token), value, execLayerData, txOpts)
+	cobra.CheckErr(err)
+
+	slog.Info("Input added", "input-index", inputIndex)
+}
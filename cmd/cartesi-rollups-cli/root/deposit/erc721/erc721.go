@@ -0,0 +1,102 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package erc721
+
+import (
+	"fmt"
+	"log/slog"
+	"math/big"
+
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/book"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/signer"
+	"github.com/cartesi/rollups-node/pkg/ethutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "erc721",
+	Short:   "Deposit an ERC-721 token in the application through the ERC721Portal",
+	Example: examples,
+	Run:     run,
+}
+
+const examples = `# Deposit token 1, approving the portal first if needed:
+cartesi-rollups-cli deposit erc721 --token $TOKEN --token-id 1`
+
+var (
+	ethEndpoint      string
+	signerFlags      *signer.Flags
+	token            string
+	tokenId          string
+	hexBaseLayerData string
+	hexExecLayerData string
+	bookFlags        *book.Flags
+)
+
+func init() {
+	Cmd.Flags().StringVar(&ethEndpoint, "eth-endpoint", "http://localhost:8545",
+		"ethereum node JSON-RPC endpoint")
+
+	signerFlags = signer.AddFlags(Cmd)
+
+	Cmd.Flags().StringVar(&token, "token", "",
+		"address of the ERC-721 token contract")
+
+	cobra.CheckErr(Cmd.MarkFlagRequired("token"))
+
+	Cmd.Flags().StringVar(&tokenId, "token-id", "",
+		"id of the token")
+
+	cobra.CheckErr(Cmd.MarkFlagRequired("token-id"))
+
+	Cmd.Flags().StringVar(&hexBaseLayerData, "base-layer-data", "0x",
+		"data for the token contract hex-encoded starting with 0x")
+
+	Cmd.Flags().StringVar(&hexExecLayerData, "exec-layer-data", "0x",
+		"data for the application hex-encoded starting with 0x")
+
+	bookFlags = book.AddFlags(Cmd)
+}
+
+func run(cmd *cobra.Command, args []string) {
+	if !common.IsHexAddress(token) {
+		cobra.CheckErr(fmt.Errorf("invalid --token: %v", token))
+	}
+	id, ok := new(big.Int).SetString(tokenId, 10)
+	if !ok || id.Sign() < 0 {
+		cobra.CheckErr(fmt.Errorf("invalid --token-id: %v", tokenId))
+	}
+	baseLayerData, err := hexutil.Decode(hexBaseLayerData)
+	cobra.CheckErr(err)
+	execLayerData, err := hexutil.Decode(hexExecLayerData)
+	cobra.CheckErr(err)
+
+	ctx := cmd.Context()
+	client, err := ethclient.DialContext(ctx, ethEndpoint)
+	cobra.CheckErr(err)
+	slog.Info("Connected", "eth-endpoint", ethEndpoint)
+
+	signer, err := signerFlags.NewSigner(ctx, client)
+	cobra.CheckErr(err)
+
+	txOpts, err := signerFlags.TxOptions()
+	cobra.CheckErr(err)
+
+	book, err := bookFlags.Load(ctx, client)
+	cobra.CheckErr(err)
+
+	slog.Info("Depositing ERC-721 token",
+		"application-address", book.CartesiDApp,
+		"token", token,
+		"token-id", id,
+	)
+	inputIndex, err := ethutil.DepositERC721Token(ctx, client, book, signer,
+		common.HexToAddress(token), id, baseLayerData, execLayerData, txOpts)
+	cobra.CheckErr(err)
+
+	slog.Info("Input added", "input-index", inputIndex)
+}
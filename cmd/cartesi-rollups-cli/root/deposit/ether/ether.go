@@ -0,0 +1,85 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package ether
+
+import (
+	"fmt"
+	"log/slog"
+	"math/big"
+
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/book"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/signer"
+	"github.com/cartesi/rollups-node/pkg/ethutil"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:     "ether",
+	Short:   "Deposit ether in the application through the EtherPortal",
+	Example: examples,
+	Run:     run,
+}
+
+const examples = `# Deposit 1 ether:
+cartesi-rollups-cli deposit ether --amount 1000000000000000000
+
+# Deposit 1 wei with the string "hi" as the execution layer data:
+cartesi-rollups-cli deposit ether --amount 1 --exec-layer-data 0x6869`
+
+var (
+	ethEndpoint      string
+	signerFlags      *signer.Flags
+	amount           string
+	hexExecLayerData string
+	bookFlags        *book.Flags
+)
+
+func init() {
+	Cmd.Flags().StringVar(&ethEndpoint, "eth-endpoint", "http://localhost:8545",
+		"ethereum node JSON-RPC endpoint")
+
+	signerFlags = signer.AddFlags(Cmd)
+
+	Cmd.Flags().StringVar(&amount, "amount", "",
+		"amount of ether in wei")
+
+	cobra.CheckErr(Cmd.MarkFlagRequired("amount"))
+
+	Cmd.Flags().StringVar(&hexExecLayerData, "exec-layer-data", "0x",
+		"data for the application hex-encoded starting with 0x")
+
+	bookFlags = book.AddFlags(Cmd)
+}
+
+func run(cmd *cobra.Command, args []string) {
+	value, ok := new(big.Int).SetString(amount, 10)
+	if !ok || value.Sign() < 0 {
+		cobra.CheckErr(fmt.Errorf("invalid --amount: expected an amount in wei"))
+	}
+	execLayerData, err := hexutil.Decode(hexExecLayerData)
+	cobra.CheckErr(err)
+
+	ctx := cmd.Context()
+	client, err := ethclient.DialContext(ctx, ethEndpoint)
+	cobra.CheckErr(err)
+	slog.Info("Connected", "eth-endpoint", ethEndpoint)
+
+	signer, err := signerFlags.NewSigner(ctx, client)
+	cobra.CheckErr(err)
+
+	txOpts, err := signerFlags.TxOptions()
+	cobra.CheckErr(err)
+
+	book, err := bookFlags.Load(ctx, client)
+	cobra.CheckErr(err)
+
+	slog.Info("Depositing ether", "application-address", book.CartesiDApp, "amount", value)
+	inputIndex, err := ethutil.DepositEther(
+		ctx, client, book, signer, value, execLayerData, txOpts)
+	cobra.CheckErr(err)
+
+	slog.Info("Input added", "input-index", inputIndex)
+}
@@ -0,0 +1,306 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package execute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/cartesi/rollups-node/pkg/addresses"
+	"github.com/cartesi/rollups-node/pkg/ethutil"
+	"github.com/cartesi/rollups-node/pkg/readerclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+const (
+	statusExecuted = "executed"
+	statusSkipped  = "skipped"
+	statusFailed   = "failed"
+)
+
+// Outcome of a voucher in a bulk execution.
+type result struct {
+	InputIndex   int          `json:"inputIndex"`
+	VoucherIndex int          `json:"voucherIndex"`
+	Status       string       `json:"status"`
+	Reason       string       `json:"reason,omitempty"`
+	TxHash       *common.Hash `json:"txHash,omitempty"`
+	GasUsed      uint64       `json:"gasUsed,omitempty"`
+}
+
+// Report of a bulk execution.
+type report struct {
+	Executed int      `json:"executed"`
+	Skipped  int      `json:"skipped"`
+	Failed   int      `json:"failed"`
+	GasUsed  uint64   `json:"gasUsed"`
+	Vouchers []result `json:"vouchers"`
+}
+
+// Range of inputs whose vouchers are executed.
+// A negative last means there is no upper bound.
+type inputRange struct {
+	first int
+	last  int
+}
+
+// Parse a range in the FIRST-LAST format, with inclusive bounds. LAST may be omitted.
+func parseInputRange(value string) (inputRange, error) {
+	invalid := fmt.Errorf("invalid --input-range %q: expected FIRST-LAST or FIRST-", value)
+	firstValue, lastValue, found := strings.Cut(value, "-")
+	if !found {
+		return inputRange{}, invalid
+	}
+	first, err := strconv.Atoi(firstValue)
+	if err != nil || first < 0 {
+		return inputRange{}, invalid
+	}
+	if lastValue == "" {
+		return inputRange{first, -1}, nil
+	}
+	last, err := strconv.Atoi(lastValue)
+	if err != nil || last < first {
+		return inputRange{}, invalid
+	}
+	return inputRange{first, last}, nil
+}
+
+func (r inputRange) contains(inputIndex int) bool {
+	return inputIndex >= r.first && (r.last < 0 || inputIndex <= r.last)
+}
+
+// Gas that may still be spent by the bulk execution.
+// Each execution reserves its gas limit before sending the transaction and returns what was not
+// used, so the budget is never exceeded.
+type gasBudget struct {
+	mutex    sync.Mutex
+	limit    uint64
+	reserved uint64
+}
+
+// Reserve the gas; return false if the budget is exhausted. A zero limit means no budget.
+func (b *gasBudget) reserve(gas uint64) bool {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	if b.limit > 0 && b.reserved+gas > b.limit {
+		return false
+	}
+	b.reserved += gas
+	return true
+}
+
+// Return the part of the reserved gas that was not used.
+func (b *gasBudget) settle(reserved uint64, used uint64) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+	b.reserved -= reserved - min(used, reserved)
+}
+
+// Blockchain operations of a bulk execution.
+type voucherExecutor interface {
+	wasExecuted(ctx context.Context, voucher readerclient.Voucher) (bool, error)
+	estimate(ctx context.Context, voucher readerclient.Voucher) (uint64, error)
+	execute(ctx context.Context, voucher readerclient.Voucher) (*common.Hash, error)
+	gasUsed(ctx context.Context, txHash common.Hash) (uint64, error)
+}
+
+// Executor that sends the transactions to the blockchain.
+type chainExecutor struct {
+	client *ethclient.Client
+	book   *addresses.Book
+	signer ethutil.Signer
+	txOpts *ethutil.TxOptions
+}
+
+func (e *chainExecutor) wasExecuted(
+	ctx context.Context,
+	voucher readerclient.Voucher,
+) (bool, error) {
+	return ethutil.WasVoucherExecuted(ctx, e.client, e.book, voucher.InputIndex, voucher.Index)
+}
+
+func (e *chainExecutor) estimate(
+	ctx context.Context,
+	voucher readerclient.Voucher,
+) (uint64, error) {
+	return ethutil.EstimateVoucherExecution(ctx, e.client, e.book, e.signer, voucher.Payload,
+		&voucher.Destination, readerclient.ConvertToContractProof(voucher.Proof), e.txOpts)
+}
+
+func (e *chainExecutor) execute(
+	ctx context.Context,
+	voucher readerclient.Voucher,
+) (*common.Hash, error) {
+	return ethutil.ExecuteVoucherWithOptions(ctx, e.client, e.book, e.signer, voucher.Payload,
+		&voucher.Destination, readerclient.ConvertToContractProof(voucher.Proof), e.txOpts)
+}
+
+func (e *chainExecutor) gasUsed(ctx context.Context, txHash common.Hash) (uint64, error) {
+	receipt, err := e.client.TransactionReceipt(ctx, txHash)
+	if err != nil {
+		return 0, err
+	}
+	return receipt.GasUsed, nil
+}
+
+// Execute the vouchers of the inputs in the range that have a proof and were not executed yet.
+func executeVouchers(
+	ctx context.Context,
+	graphqlClient graphql.Client,
+	client *ethclient.Client,
+	book *addresses.Book,
+	signer ethutil.Signer,
+	txOpts *ethutil.TxOptions,
+	inputs inputRange,
+) (*report, error) {
+	if concurrency > 1 {
+		// the transactions of the signer are sent without waiting for the previous ones
+		txOpts.Nonces = ethutil.NewNonceManager(client)
+	}
+	executor := &chainExecutor{client: client, book: book, signer: signer, txOpts: txOpts}
+	return bulkExecute(ctx, graphqlClient, readerclient.GetVouchersPage, executor, inputs,
+		&gasBudget{limit: maxGas})
+}
+
+// Execute the vouchers of the pages fetched from the reader with the executor.
+func bulkExecute(
+	ctx context.Context,
+	graphqlClient graphql.Client,
+	fetch readerclient.PageFunc[readerclient.Voucher],
+	executor voucherExecutor,
+	inputs inputRange,
+	budget *gasBudget,
+) (*report, error) {
+	semaphore := make(chan struct{}, max(concurrency, 1))
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var results []result
+	record := func(r result) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		results = append(results, r)
+	}
+
+	after := ""
+	for done := false; !done; {
+		vouchers, pageInfo, err := fetch(ctx, graphqlClient, pageSize, after)
+		if err != nil {
+			wg.Wait()
+			return nil, fmt.Errorf("failed to get vouchers: %v", err)
+		}
+		for _, voucher := range vouchers {
+			if inputs.last >= 0 && voucher.InputIndex > inputs.last {
+				// the vouchers are ordered by input
+				done = true
+				break
+			}
+			if !inputs.contains(voucher.InputIndex) {
+				continue
+			}
+			r := result{InputIndex: voucher.InputIndex, VoucherIndex: voucher.Index}
+			if voucher.Proof == nil {
+				r.Status, r.Reason = statusSkipped, "no proof yet"
+				record(r)
+				continue
+			}
+			executed, err := executor.wasExecuted(ctx, voucher)
+			if err != nil {
+				r.Status, r.Reason = statusFailed, err.Error()
+				record(r)
+				continue
+			}
+			if executed {
+				r.Status, r.Reason = statusSkipped, "already executed"
+				record(r)
+				continue
+			}
+			semaphore <- struct{}{}
+			wg.Add(1)
+			go func(voucher readerclient.Voucher) {
+				defer wg.Done()
+				defer func() { <-semaphore }()
+				record(executeOne(ctx, executor, budget, voucher))
+			}(voucher)
+		}
+		if !pageInfo.HasNextPage || pageInfo.EndCursor == "" {
+			done = true
+		}
+		after = pageInfo.EndCursor
+	}
+	wg.Wait()
+
+	slices.SortFunc(results, func(a, b result) int {
+		if a.InputIndex != b.InputIndex {
+			return a.InputIndex - b.InputIndex
+		}
+		return a.VoucherIndex - b.VoucherIndex
+	})
+	rep := &report{Vouchers: results}
+	for _, r := range results {
+		switch r.Status {
+		case statusExecuted:
+			rep.Executed++
+		case statusSkipped:
+			rep.Skipped++
+		case statusFailed:
+			rep.Failed++
+		}
+		rep.GasUsed += r.GasUsed
+	}
+	return rep, nil
+}
+
+// Execute the voucher if its gas fits in the budget.
+func executeOne(
+	ctx context.Context,
+	executor voucherExecutor,
+	budget *gasBudget,
+	voucher readerclient.Voucher,
+) result {
+	r := result{InputIndex: voucher.InputIndex, VoucherIndex: voucher.Index}
+	fail := func(err error) result {
+		var revertErr *ethutil.RevertError
+		if errors.As(err, &revertErr) && revertErr.Name == "VoucherReexecutionNotAllowed" {
+			r.Status, r.Reason = statusSkipped, "already executed"
+		} else {
+			r.Status, r.Reason = statusFailed, err.Error()
+		}
+		return r
+	}
+
+	gas, err := executor.estimate(ctx, voucher)
+	if err != nil {
+		return fail(err)
+	}
+	if !budget.reserve(gas) {
+		r.Status, r.Reason = statusSkipped, "gas budget exhausted"
+		return r
+	}
+	slog.Info("Executing voucher",
+		"voucher-index", voucher.Index,
+		"input-index", voucher.InputIndex,
+	)
+	txHash, err := executor.execute(ctx, voucher)
+	if err != nil {
+		// a failed transaction keeps its reservation, as it may have used the gas
+		return fail(err)
+	}
+	r.Status, r.TxHash = statusExecuted, txHash
+	gasUsed, err := executor.gasUsed(ctx, *txHash)
+	if err != nil {
+		slog.Warn("Failed to get receipt", "tx-hash", txHash, "error", err)
+		return r
+	}
+	budget.settle(gas, gasUsed)
+	r.GasUsed = gasUsed
+	return r
+}
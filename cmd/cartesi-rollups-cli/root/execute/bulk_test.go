@@ -0,0 +1,363 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package execute
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"testing"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/cartesi/rollups-node/pkg/ethutil"
+	"github.com/cartesi/rollups-node/pkg/readerclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseInputRange(t *testing.T) {
+	tests := []struct {
+		value string
+		want  inputRange
+		valid bool
+	}{
+		{value: "0-0", want: inputRange{0, 0}, valid: true},
+		{value: "3-10", want: inputRange{3, 10}, valid: true},
+		{value: "5-", want: inputRange{5, -1}, valid: true},
+		{value: "", valid: false},
+		{value: "5", valid: false},
+		{value: "-5", valid: false},
+		{value: "a-5", valid: false},
+		{value: "1-b", valid: false},
+		{value: "10-3", valid: false},
+		{value: "1-2-3", valid: false},
+	}
+	for _, test := range tests {
+		t.Run(test.value, func(t *testing.T) {
+			got, err := parseInputRange(test.value)
+			if !test.valid {
+				require.Error(t, err)
+				return
+			}
+			require.Nil(t, err)
+			require.Equal(t, test.want, got)
+		})
+	}
+}
+
+func TestInputRangeContains(t *testing.T) {
+	tests := []struct {
+		inputs     inputRange
+		inputIndex int
+		want       bool
+	}{
+		{inputs: inputRange{2, 4}, inputIndex: 1, want: false},
+		{inputs: inputRange{2, 4}, inputIndex: 2, want: true},
+		{inputs: inputRange{2, 4}, inputIndex: 4, want: true},
+		{inputs: inputRange{2, 4}, inputIndex: 5, want: false},
+		{inputs: inputRange{2, -1}, inputIndex: 1, want: false},
+		{inputs: inputRange{2, -1}, inputIndex: 1000, want: true},
+	}
+	for _, test := range tests {
+		name := fmt.Sprintf("%v/%v", test.inputs, test.inputIndex)
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.want, test.inputs.contains(test.inputIndex))
+		})
+	}
+}
+
+func TestGasBudget(t *testing.T) {
+	type step struct {
+		reserve uint64
+		used    uint64
+		settle  bool
+		want    bool
+	}
+	tests := []struct {
+		name         string
+		limit        uint64
+		steps        []step
+		wantReserved uint64
+	}{
+		{
+			name:         "NoLimit",
+			limit:        0,
+			steps:        []step{{reserve: 1 << 40, want: true}, {reserve: 1 << 40, want: true}},
+			wantReserved: 1 << 41,
+		},
+		{
+			name:         "FitsExactly",
+			limit:        100,
+			steps:        []step{{reserve: 60, want: true}, {reserve: 40, want: true}},
+			wantReserved: 100,
+		},
+		{
+			name:         "Exhausted",
+			limit:        100,
+			steps:        []step{{reserve: 60, want: true}, {reserve: 41, want: false}},
+			wantReserved: 60,
+		},
+		{
+			name:  "SettleReturnsTheUnusedGas",
+			limit: 100,
+			steps: []step{
+				{reserve: 60, used: 20, settle: true, want: true},
+				{reserve: 80, want: true},
+			},
+			wantReserved: 100,
+		},
+		{
+			name:         "SettleNeverReturnsMoreThanReserved",
+			limit:        100,
+			steps:        []step{{reserve: 60, used: 90, settle: true, want: true}},
+			wantReserved: 60,
+		},
+		{
+			name:  "FailedReservationIsNotSettled",
+			limit: 100,
+			steps: []step{
+				{reserve: 60, want: true},
+				{reserve: 50, want: false},
+				{reserve: 40, want: true},
+			},
+			wantReserved: 100,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			budget := &gasBudget{limit: test.limit}
+			for _, step := range test.steps {
+				require.Equal(t, step.want, budget.reserve(step.reserve))
+				if step.settle {
+					budget.settle(step.reserve, step.used)
+				}
+			}
+			require.Equal(t, test.wantReserved, budget.reserved)
+		})
+	}
+}
+
+// Executor that fakes the transactions of the vouchers.
+type fakeExecutor struct {
+	executed    map[[2]int]bool
+	reverted    map[[2]int]bool
+	failed      map[[2]int]bool
+	gas         uint64
+	used        uint64
+	checkErrors map[[2]int]error
+}
+
+func key(voucher readerclient.Voucher) [2]int {
+	return [2]int{voucher.InputIndex, voucher.Index}
+}
+
+func (e *fakeExecutor) wasExecuted(_ context.Context, voucher readerclient.Voucher) (bool, error) {
+	if err := e.checkErrors[key(voucher)]; err != nil {
+		return false, err
+	}
+	return e.executed[key(voucher)], nil
+}
+
+func (e *fakeExecutor) estimate(_ context.Context, voucher readerclient.Voucher) (uint64, error) {
+	if e.reverted[key(voucher)] {
+		return 0, &ethutil.RevertError{
+			Contract: "CartesiDApp",
+			Name:     "VoucherReexecutionNotAllowed",
+		}
+	}
+	return e.gas, nil
+}
+
+func (e *fakeExecutor) execute(
+	_ context.Context,
+	voucher readerclient.Voucher,
+) (*common.Hash, error) {
+	if e.failed[key(voucher)] {
+		return nil, errors.New("transaction failed")
+	}
+	txHash := common.BigToHash(common.Big1)
+	return &txHash, nil
+}
+
+func (e *fakeExecutor) gasUsed(_ context.Context, _ common.Hash) (uint64, error) {
+	return e.used, nil
+}
+
+// Return a PageFunc that serves the pages in order and counts the fetched pages.
+func fakePages(
+	pages [][]readerclient.Voucher,
+	fetched *int,
+) readerclient.PageFunc[readerclient.Voucher] {
+	return func(
+		_ context.Context,
+		_ graphql.Client,
+		_ int,
+		after string,
+	) ([]readerclient.Voucher, *readerclient.PageInfo, error) {
+		page := 0
+		if after != "" {
+			page, _ = strconv.Atoi(after)
+		}
+		*fetched++
+		pageInfo := &readerclient.PageInfo{
+			EndCursor:   fmt.Sprint(page + 1),
+			HasNextPage: page+1 < len(pages),
+		}
+		return pages[page], pageInfo, nil
+	}
+}
+
+func voucher(inputIndex int, index int, proved bool) readerclient.Voucher {
+	v := readerclient.Voucher{InputIndex: inputIndex, Index: index}
+	if proved {
+		v.Proof = &readerclient.Proof{}
+	}
+	return v
+}
+
+func TestBulkExecute(t *testing.T) {
+	type want struct {
+		inputIndex int
+		index      int
+		status     string
+		reason     string
+	}
+	tests := []struct {
+		name        string
+		inputs      inputRange
+		limit       uint64
+		pages       [][]readerclient.Voucher
+		executor    *fakeExecutor
+		want        []want
+		wantFetched int
+		wantGasUsed uint64
+	}{
+		{
+			name:   "ExecutesTheProvedVouchersInTheRange",
+			inputs: inputRange{1, -1},
+			pages: [][]readerclient.Voucher{
+				{voucher(0, 0, true), voucher(1, 0, true)},
+				{voucher(1, 1, false), voucher(2, 0, true)},
+			},
+			executor: &fakeExecutor{gas: 100, used: 70},
+			want: []want{
+				{1, 0, statusExecuted, ""},
+				{1, 1, statusSkipped, "no proof yet"},
+				{2, 0, statusExecuted, ""},
+			},
+			wantFetched: 2,
+			wantGasUsed: 140,
+		},
+		{
+			name:   "SkipsTheExecutedVouchers",
+			inputs: inputRange{0, -1},
+			pages: [][]readerclient.Voucher{
+				{voucher(0, 0, true), voucher(0, 1, true), voucher(1, 0, true)},
+			},
+			executor: &fakeExecutor{
+				gas:      100,
+				used:     100,
+				executed: map[[2]int]bool{{0, 0}: true},
+				reverted: map[[2]int]bool{{1, 0}: true},
+			},
+			want: []want{
+				{0, 0, statusSkipped, "already executed"},
+				{0, 1, statusExecuted, ""},
+				{1, 0, statusSkipped, "already executed"},
+			},
+			wantFetched: 1,
+			wantGasUsed: 100,
+		},
+		{
+			name:   "ReportsTheFailures",
+			inputs: inputRange{0, -1},
+			pages: [][]readerclient.Voucher{
+				{voucher(0, 0, true), voucher(0, 1, true)},
+			},
+			executor: &fakeExecutor{
+				gas:         100,
+				used:        100,
+				failed:      map[[2]int]bool{{0, 1}: true},
+				checkErrors: map[[2]int]error{{0, 0}: errors.New("reader down")},
+			},
+			want: []want{
+				{0, 0, statusFailed, "reader down"},
+				{0, 1, statusFailed, "transaction failed"},
+			},
+			wantFetched: 1,
+		},
+		{
+			name:   "StopsWhenTheBudgetIsExhausted",
+			inputs: inputRange{0, -1},
+			limit:  250,
+			pages: [][]readerclient.Voucher{
+				{voucher(0, 0, true), voucher(0, 1, true), voucher(0, 2, true)},
+			},
+			executor: &fakeExecutor{gas: 100, used: 100},
+			want: []want{
+				{0, 0, statusExecuted, ""},
+				{0, 1, statusExecuted, ""},
+				{0, 2, statusSkipped, "gas budget exhausted"},
+			},
+			wantFetched: 1,
+			wantGasUsed: 200,
+		},
+		{
+			name:   "UnusedGasReturnsToTheBudget",
+			inputs: inputRange{0, -1},
+			limit:  250,
+			pages: [][]readerclient.Voucher{
+				{voucher(0, 0, true), voucher(0, 1, true), voucher(0, 2, true)},
+			},
+			executor: &fakeExecutor{gas: 100, used: 50},
+			want: []want{
+				{0, 0, statusExecuted, ""},
+				{0, 1, statusExecuted, ""},
+				{0, 2, statusExecuted, ""},
+			},
+			wantFetched: 1,
+			wantGasUsed: 150,
+		},
+		{
+			name:   "StopsAfterTheLastInputOfTheRange",
+			inputs: inputRange{0, 1},
+			pages: [][]readerclient.Voucher{
+				{voucher(0, 0, true), voucher(1, 0, true)},
+				{voucher(2, 0, true)},
+				{voucher(3, 0, true)},
+			},
+			executor: &fakeExecutor{gas: 100, used: 100},
+			want: []want{
+				{0, 0, statusExecuted, ""},
+				{1, 0, statusExecuted, ""},
+			},
+			wantFetched: 2,
+			wantGasUsed: 200,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			fetched := 0
+			rep, err := bulkExecute(context.Background(), nil, fakePages(test.pages, &fetched),
+				test.executor, test.inputs, &gasBudget{limit: test.limit})
+			require.Nil(t, err)
+			require.Equal(t, test.wantFetched, fetched)
+			require.Len(t, rep.Vouchers, len(test.want))
+			counts := map[string]int{}
+			for i, w := range test.want {
+				r := rep.Vouchers[i]
+				require.Equal(t, w.inputIndex, r.InputIndex)
+				require.Equal(t, w.index, r.VoucherIndex)
+				require.Equal(t, w.status, r.Status)
+				require.Equal(t, w.reason, r.Reason)
+				counts[w.status]++
+			}
+			require.Equal(t, counts[statusExecuted], rep.Executed)
+			require.Equal(t, counts[statusSkipped], rep.Skipped)
+			require.Equal(t, counts[statusFailed], rep.Failed)
+			require.Equal(t, test.wantGasUsed, rep.GasUsed)
+		})
+	}
+}
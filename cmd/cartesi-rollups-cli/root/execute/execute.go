@@ -4,11 +4,15 @@
 package execute
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
 	"log/slog"
 	"os"
 
 	"github.com/Khan/genqlient/graphql"
-	"github.com/cartesi/rollups-node/pkg/addresses"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/book"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/signer"
 	"github.com/cartesi/rollups-node/pkg/ethutil"
 	"github.com/cartesi/rollups-node/pkg/readerclient"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -23,28 +27,59 @@ var Cmd = &cobra.Command{
 }
 
 const examples = `# Executes voucher 5 from input 6:
-cartesi-rollups-cli execute --voucher-index 5 --input-index 6`
+cartesi-rollups-cli execute --voucher-index 5 --input-index 6
+
+# Executes every voucher with a proof that was not executed yet:
+cartesi-rollups-cli execute --all
+
+# Executes the vouchers of inputs 10 to 20, spending at most 5 million gas:
+cartesi-rollups-cli execute --input-range 10-20 --gas-budget 5000000 --report report.json`
 
 var (
 	voucherIndex    int
 	inputIndex      int
+	all             bool
+	inputRangeFlag  string
+	pageSize        int
+	maxGas          uint64
+	concurrency     int
+	reportFile      string
 	graphqlEndpoint string
 	ethEndpoint     string
-	mnemonic        string
-	account         uint32
-	addressBookFile string
+	signerFlags     *signer.Flags
+	bookFlags       *book.Flags
 )
 
 func init() {
 	Cmd.Flags().IntVar(&voucherIndex, "voucher-index", 0,
 		"index of the voucher")
 
-	cobra.CheckErr(Cmd.MarkFlagRequired("voucher-index"))
-
 	Cmd.Flags().IntVar(&inputIndex, "input-index", 0,
 		"index of the input")
 
-	cobra.CheckErr(Cmd.MarkFlagRequired("input-index"))
+	Cmd.Flags().BoolVar(&all, "all", false,
+		"execute every voucher with a proof that was not executed yet")
+
+	Cmd.Flags().StringVar(&inputRangeFlag, "input-range", "",
+		"execute the vouchers with a proof that were not executed yet of the inputs in the "+
+			"range FIRST-LAST, inclusive; LAST may be omitted")
+
+	Cmd.Flags().IntVar(&pageSize, "page-size", 100,
+		"number of vouchers read from graphql in each request")
+
+	Cmd.Flags().Uint64Var(&maxGas, "gas-budget", 0,
+		"if set, stop executing vouchers when their gas would exceed this amount")
+
+	Cmd.Flags().IntVar(&concurrency, "concurrency", 1,
+		"number of vouchers executed at the same time")
+
+	Cmd.Flags().StringVar(&reportFile, "report", "",
+		"if set, write the report of the executed, skipped and failed vouchers to this file; "+
+			"else, print it")
+
+	Cmd.MarkFlagsRequiredTogether("voucher-index", "input-index")
+	Cmd.MarkFlagsOneRequired("input-index", "all", "input-range")
+	Cmd.MarkFlagsMutuallyExclusive("input-index", "all", "input-range")
 
 	Cmd.Flags().StringVar(&graphqlEndpoint, "graphql-endpoint", "http://localhost:10000/graphql",
 		"address used to connect to graphql")
@@ -52,41 +87,62 @@ func init() {
 	Cmd.Flags().StringVar(&ethEndpoint, "eth-endpoint", "http://localhost:8545",
 		"ethereum node JSON-RPC endpoint")
 
-	Cmd.Flags().StringVar(&mnemonic, "mnemonic", ethutil.FoundryMnemonic,
-		"mnemonic used to sign the transaction")
+	signerFlags = signer.AddFlags(Cmd)
 
-	Cmd.Flags().Uint32Var(&account, "account", 0,
-		"account index used to sign the transaction (default: 0)")
-
-	Cmd.Flags().StringVar(&addressBookFile, "address-book", "",
-		"if set, load the address book from the given file; else, use test addresses")
+	bookFlags = book.AddFlags(Cmd)
 }
 
 func run(cmd *cobra.Command, args []string) {
 	ctx := cmd.Context()
 	graphqlClient := graphql.NewClient(graphqlEndpoint, nil)
 
-	resp, err := readerclient.GetVoucher(ctx, graphqlClient, voucherIndex, inputIndex)
-	cobra.CheckErr(err)
+	inputs := inputRange{first: 0, last: -1}
+	if inputRangeFlag != "" {
+		var err error
+		inputs, err = parseInputRange(inputRangeFlag)
+		cobra.CheckErr(err)
+	}
+
+	var resp *readerclient.Voucher
+	if !all && inputRangeFlag == "" {
+		var err error
+		resp, err = readerclient.GetVoucher(ctx, graphqlClient, voucherIndex, inputIndex)
+		cobra.CheckErr(err)
 
-	if resp.Proof == nil {
-		slog.Warn("The voucher has no associated proof yet")
-		os.Exit(0)
+		if resp.Proof == nil {
+			slog.Warn("The voucher has no associated proof yet")
+			os.Exit(0)
+		}
 	}
 
 	client, err := ethclient.DialContext(ctx, ethEndpoint)
 	cobra.CheckErr(err)
 	slog.Info("Connected", "eth-endpoint", ethEndpoint)
 
-	signer, err := ethutil.NewMnemonicSigner(ctx, client, mnemonic, account)
+	signer, err := signerFlags.NewSigner(ctx, client)
+	cobra.CheckErr(err)
+
+	txOpts, err := signerFlags.TxOptions()
 	cobra.CheckErr(err)
 
-	var book *addresses.Book
-	if addressBookFile != "" {
-		book, err = addresses.GetBookFromFile(addressBookFile)
+	book, err := bookFlags.Load(ctx, client)
+	cobra.CheckErr(err)
+
+	if resp == nil {
+		rep, err := executeVouchers(ctx, graphqlClient, client, book, signer, txOpts, inputs)
 		cobra.CheckErr(err)
-	} else {
-		book = addresses.GetTestBook()
+		writeReport(rep)
+		if rep.Failed > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	executed, err := ethutil.WasVoucherExecuted(ctx, client, book, inputIndex, voucherIndex)
+	cobra.CheckErr(err)
+	if executed {
+		slog.Error("The voucher was already executed")
+		os.Exit(1)
 	}
 
 	proof := readerclient.ConvertToContractProof(resp.Proof)
@@ -96,7 +152,7 @@ func run(cmd *cobra.Command, args []string) {
 		"input-index", inputIndex,
 		"application-address", book.CartesiDApp,
 	)
-	txHash, err := ethutil.ExecuteVoucher(
+	txHash, err := ethutil.ExecuteVoucherWithOptions(
 		ctx,
 		client,
 		book,
@@ -104,8 +160,32 @@ func run(cmd *cobra.Command, args []string) {
 		resp.Payload,
 		&resp.Destination,
 		proof,
+		txOpts,
 	)
+	var revertErr *ethutil.RevertError
+	if errors.As(err, &revertErr) && revertErr.Name == "VoucherReexecutionNotAllowed" {
+		slog.Error("The voucher was already executed")
+		os.Exit(1)
+	}
 	cobra.CheckErr(err)
 
 	slog.Info("Voucher executed", "tx-hash", txHash)
 }
+
+func writeReport(rep *report) {
+	slog.Info("Vouchers processed",
+		"executed", rep.Executed,
+		"skipped", rep.Skipped,
+		"failed", rep.Failed,
+		"gas-used", rep.GasUsed,
+	)
+	val, err := json.MarshalIndent(rep, "", "    ")
+	cobra.CheckErr(err)
+	if reportFile == "" {
+		fmt.Println(string(val))
+		return
+	}
+	const fileMode = 0644
+	cobra.CheckErr(os.WriteFile(reportFile, val, fileMode))
+	slog.Info("Report written", "file", reportFile)
+}
@@ -0,0 +1,127 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package claims
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/book"
+	"github.com/cartesi/rollups-node/pkg/claims"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "claims",
+	Short: "Reads the claims of the application from the History contract",
+	Long: `Reads the claims of the application from the History contract, ordered by index.
+Each claim has the epoch hash, the range of inputs it covers and the transaction that
+submitted it. With --claim-index, it reads a single claim with History.getClaim.`,
+	Example: examples,
+	Run:     run,
+}
+
+const examples = `# Read the claims of the test application:
+cartesi-rollups-cli read claims
+
+# Read the claims as a table, searching from the deployment block of the History:
+cartesi-rollups-cli read claims --address-book deployment.json --from-block 5000000 --format table
+
+# Read the claim with index 3:
+cartesi-rollups-cli read claims --claim-index 3`
+
+const (
+	formatJson  = "json"
+	formatTable = "table"
+)
+
+var (
+	ethEndpoint string
+	bookFlags   *book.Flags
+	fromBlock   uint64
+	chunkSize   uint64
+	claimIndex  uint64
+	format      string
+)
+
+func init() {
+	Cmd.Flags().StringVar(&ethEndpoint, "eth-endpoint", "http://localhost:8545",
+		"ethereum node JSON-RPC endpoint")
+
+	bookFlags = book.AddFlags(Cmd)
+
+	Cmd.Flags().Uint64Var(&fromBlock, "from-block", 0,
+		"block where the search for claims starts; it must not be after the first claim")
+
+	Cmd.Flags().Uint64Var(&chunkSize, "chunk-size", claims.DefaultChunkSize,
+		"max number of blocks in each request for claims")
+
+	Cmd.Flags().Uint64Var(&claimIndex, "claim-index", 0,
+		"if set, read only the claim with this index")
+
+	Cmd.Flags().StringVar(&format, "format", formatJson,
+		"output format: json or table")
+
+	Cmd.MarkFlagsMutuallyExclusive("claim-index", "from-block")
+}
+
+func run(cmd *cobra.Command, args []string) {
+	if format != formatJson && format != formatTable {
+		cobra.CheckErr(fmt.Errorf("invalid --format %q: expected json or table", format))
+	}
+
+	ctx := cmd.Context()
+	client, err := ethclient.DialContext(ctx, ethEndpoint)
+	cobra.CheckErr(err)
+
+	book, err := bookFlags.Load(ctx, client)
+	cobra.CheckErr(err)
+
+	reader, err := claims.NewReader(ctx, client, book)
+	cobra.CheckErr(err)
+
+	if cmd.Flags().Changed("claim-index") {
+		claim, err := reader.GetClaim(ctx, claims.ProofContext(claimIndex))
+		cobra.CheckErr(err)
+		if format == formatTable {
+			w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "INDEX\tINPUTS\tEPOCH HASH")
+			fmt.Fprintf(w, "%v\t%v-%v\t%v\n",
+				claimIndex, claim.FirstIndex, claim.LastIndex, claim.EpochHash)
+			cobra.CheckErr(w.Flush())
+			return
+		}
+		printJson(claim)
+		return
+	}
+
+	list, err := reader.ListClaims(ctx, &claims.ListOptions{
+		FromBlock: fromBlock,
+		ChunkSize: chunkSize,
+	})
+	cobra.CheckErr(err)
+	if format == formatTable {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "INDEX\tINPUTS\tEPOCH HASH\tBLOCK\tTIME\tSUBMITTER\tTRANSACTION")
+		for _, claim := range list {
+			fmt.Fprintf(w, "%v\t%v-%v\t%v\t%v\t%v\t%v\t%v\n",
+				claim.Index, claim.FirstIndex, claim.LastIndex, claim.EpochHash,
+				claim.BlockNumber, claim.BlockTime.Format(time.RFC3339), claim.Submitter,
+				claim.TxHash)
+		}
+		cobra.CheckErr(w.Flush())
+		return
+	}
+	printJson(list)
+}
+
+func printJson(value any) {
+	val, err := json.MarshalIndent(value, "", "    ")
+	cobra.CheckErr(err)
+	fmt.Println(string(val))
+}
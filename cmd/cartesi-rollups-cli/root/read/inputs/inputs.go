@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/Khan/genqlient/graphql"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/page"
 	"github.com/cartesi/rollups-node/pkg/readerclient"
 	"github.com/spf13/cobra"
 )
@@ -20,22 +21,28 @@ var Cmd = &cobra.Command{
 }
 
 const examples = `# Read inputs from GraphQL:
-cartesi-rollups-cli read inputs`
+cartesi-rollups-cli read inputs
+
+# Read the inputs after a cursor, 100 at a time:
+cartesi-rollups-cli read inputs --all --first 100 --after $CURSOR`
 
 var (
 	graphqlEndpoint string
+	pageFlags       *page.Flags
 )
 
 func init() {
 	Cmd.Flags().StringVar(&graphqlEndpoint, "graphql-endpoint", "http://localhost:10000/graphql",
 		"address used to connect to graphql")
+
+	pageFlags = page.AddFlags(Cmd)
 }
 
 func run(cmd *cobra.Command, args []string) {
 	ctx := cmd.Context()
 	client := graphql.NewClient(graphqlEndpoint, nil)
 
-	resp, err := readerclient.GetInputs(ctx, client)
+	resp, err := page.Read(ctx, client, pageFlags, readerclient.GetInputsPage)
 	cobra.CheckErr(err)
 
 	val, err := json.MarshalIndent(resp, "", "    ")
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/Khan/genqlient/graphql"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/decode"
 	"github.com/cartesi/rollups-node/pkg/readerclient"
 	"github.com/spf13/cobra"
 )
@@ -20,12 +21,16 @@ var Cmd = &cobra.Command{
 }
 
 const examples = `# Read notice 5 from input 6:
-cartesi-rollups-cli read notice --notice-index 5 --input-index 6`
+cartesi-rollups-cli read notice --notice-index 5 --input-index 6
+
+# Read notice 5 from input 6 with its payload decoded as an address and an amount:
+cartesi-rollups-cli read notice --notice-index 5 --input-index 6 --decode --types address,uint256`
 
 var (
 	noticeIndex     int
 	inputIndex      int
 	graphqlEndpoint string
+	decodeFlags     *decode.NoticeFlags
 )
 
 func init() {
@@ -41,6 +46,8 @@ func init() {
 
 	Cmd.Flags().StringVar(&graphqlEndpoint, "graphql-endpoint", "http://localhost:10000/graphql",
 		"address used to connect to graphql")
+
+	decodeFlags = decode.AddNoticeFlags(Cmd)
 }
 
 func run(cmd *cobra.Command, args []string) {
@@ -50,7 +57,14 @@ func run(cmd *cobra.Command, args []string) {
 	resp, err := readerclient.GetNotice(ctx, client, noticeIndex, inputIndex)
 	cobra.CheckErr(err)
 
-	val, err := json.MarshalIndent(resp, "", "    ")
+	var output any = resp
+	if decodeFlags.Enabled() {
+		decoded, err := decodeFlags.Decode(*resp)
+		cobra.CheckErr(err)
+		output = decoded[0]
+	}
+
+	val, err := json.MarshalIndent(output, "", "    ")
 	cobra.CheckErr(err)
 
 	fmt.Print(string(val))
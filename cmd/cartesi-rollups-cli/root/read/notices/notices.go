@@ -8,6 +8,8 @@ import (
 	"fmt"
 
 	"github.com/Khan/genqlient/graphql"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/decode"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/page"
 	"github.com/cartesi/rollups-node/pkg/readerclient"
 	"github.com/spf13/cobra"
 )
@@ -20,11 +22,23 @@ var Cmd = &cobra.Command{
 }
 
 const examples = `# Read all notices:
-cartesi-rollups-cli read notices`
+cartesi-rollups-cli read notices
+
+# Read all notices with their payloads decoded as strings:
+cartesi-rollups-cli read notices --decode --types string
+
+# Read the first 10 notices, and then the next 10 with the cursor that is logged:
+cartesi-rollups-cli read notices --first 10
+cartesi-rollups-cli read notices --first 10 --after $CURSOR
+
+# Read the first 10 notices of input 3:
+cartesi-rollups-cli read notices --input-index 3 --first 10`
 
 var (
 	inputIndex      int
 	graphqlEndpoint string
+	pageFlags       *page.Flags
+	decodeFlags     *decode.NoticeFlags
 )
 
 func init() {
@@ -33,6 +47,10 @@ func init() {
 
 	Cmd.Flags().StringVar(&graphqlEndpoint, "graphql-endpoint", "http://localhost:10000/graphql",
 		"address used to connect to graphql")
+
+	pageFlags = page.AddFlags(Cmd)
+
+	decodeFlags = decode.AddNoticeFlags(Cmd)
 }
 
 func run(cmd *cobra.Command, args []string) {
@@ -43,14 +61,21 @@ func run(cmd *cobra.Command, args []string) {
 	var err error
 
 	if cmd.Flags().Changed("input-index") {
-		resp, err = readerclient.GetInputNotices(ctx, client, inputIndex)
+		resp, err = page.Read(ctx, client, pageFlags,
+			readerclient.ForInput(readerclient.GetInputNoticesPage, inputIndex))
 		cobra.CheckErr(err)
 	} else {
-		resp, err = readerclient.GetNotices(ctx, client)
+		resp, err = page.Read(ctx, client, pageFlags, readerclient.GetNoticesPage)
+		cobra.CheckErr(err)
+	}
+
+	var output any = resp
+	if decodeFlags.Enabled() {
+		output, err = decodeFlags.Decode(resp...)
 		cobra.CheckErr(err)
 	}
 
-	val, err := json.MarshalIndent(resp, "", "    ")
+	val, err := json.MarshalIndent(output, "", "    ")
 	cobra.CheckErr(err)
 
 	fmt.Print(string(val))
@@ -4,6 +4,7 @@
 package read
 
 import (
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/read/claims"
 	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/read/input"
 	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/read/inputs"
 	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/read/notice"
@@ -18,7 +19,7 @@ import (
 
 var Cmd = &cobra.Command{
 	Use:   "read",
-	Short: "Read the node state from the GraphQL API",
+	Short: "Read the node state from the GraphQL API and the claims from the blockchain",
 }
 
 func init() {
@@ -30,4 +31,5 @@ func init() {
 	Cmd.AddCommand(vouchers.Cmd)
 	Cmd.AddCommand(report.Cmd)
 	Cmd.AddCommand(reports.Cmd)
+	Cmd.AddCommand(claims.Cmd)
 }
@@ -8,6 +8,7 @@ import (
 	"fmt"
 
 	"github.com/Khan/genqlient/graphql"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/page"
 	"github.com/cartesi/rollups-node/pkg/readerclient"
 	"github.com/spf13/cobra"
 )
@@ -20,11 +21,19 @@ var Cmd = &cobra.Command{
 }
 
 const examples = `# Read all reports:
-cartesi-rollups-cli read reports`
+cartesi-rollups-cli read reports
+
+# Read the first 10 reports, and then the next 10 with the cursor that is logged:
+cartesi-rollups-cli read reports --first 10
+cartesi-rollups-cli read reports --first 10 --after $CURSOR
+
+# Read the first 10 reports of input 3:
+cartesi-rollups-cli read reports --input-index 3 --first 10`
 
 var (
 	inputIndex      int
 	graphqlEndpoint string
+	pageFlags       *page.Flags
 )
 
 func init() {
@@ -33,6 +42,8 @@ func init() {
 
 	Cmd.Flags().StringVar(&graphqlEndpoint, "graphql-endpoint", "http://localhost:10000/graphql",
 		"address used to connect to graphql")
+
+	pageFlags = page.AddFlags(Cmd)
 }
 
 func run(cmd *cobra.Command, args []string) {
@@ -43,10 +54,11 @@ func run(cmd *cobra.Command, args []string) {
 	var err error
 
 	if cmd.Flags().Changed("input-index") {
-		resp, err = readerclient.GetInputReports(ctx, client, inputIndex)
+		resp, err = page.Read(ctx, client, pageFlags,
+			readerclient.ForInput(readerclient.GetInputReportsPage, inputIndex))
 		cobra.CheckErr(err)
 	} else {
-		resp, err = readerclient.GetReports(ctx, client)
+		resp, err = page.Read(ctx, client, pageFlags, readerclient.GetReportsPage)
 		cobra.CheckErr(err)
 	}
 
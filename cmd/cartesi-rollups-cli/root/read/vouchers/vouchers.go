@@ -8,6 +8,8 @@ import (
 	"fmt"
 
 	"github.com/Khan/genqlient/graphql"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/decode"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/page"
 	"github.com/cartesi/rollups-node/pkg/readerclient"
 	"github.com/spf13/cobra"
 )
@@ -20,11 +22,23 @@ var Cmd = &cobra.Command{
 }
 
 const examples = `# Read all vouchers:
-cartesi-rollups-cli read vouchers`
+cartesi-rollups-cli read vouchers
+
+# Read all vouchers decoding their payloads with the methods of a contract:
+cartesi-rollups-cli read vouchers --decode --abi MyToken.json
+
+# Read the first 10 vouchers, and then the next 10 with the cursor that is logged:
+cartesi-rollups-cli read vouchers --first 10
+cartesi-rollups-cli read vouchers --first 10 --after $CURSOR
+
+# Read the first 10 vouchers of input 3:
+cartesi-rollups-cli read vouchers --input-index 3 --first 10`
 
 var (
 	inputIndex      int
 	graphqlEndpoint string
+	pageFlags       *page.Flags
+	decodeFlags     *decode.VoucherFlags
 )
 
 func init() {
@@ -33,6 +47,10 @@ func init() {
 
 	Cmd.Flags().StringVar(&graphqlEndpoint, "graphql-endpoint", "http://localhost:10000/graphql",
 		"address used to connect to graphql")
+
+	pageFlags = page.AddFlags(Cmd)
+
+	decodeFlags = decode.AddVoucherFlags(Cmd)
 }
 
 func run(cmd *cobra.Command, args []string) {
@@ -43,14 +61,21 @@ func run(cmd *cobra.Command, args []string) {
 	var err error
 
 	if cmd.Flags().Changed("input-index") {
-		resp, err = readerclient.GetInputVouchers(ctx, client, inputIndex)
+		resp, err = page.Read(ctx, client, pageFlags,
+			readerclient.ForInput(readerclient.GetInputVouchersPage, inputIndex))
 		cobra.CheckErr(err)
 	} else {
-		resp, err = readerclient.GetVouchers(ctx, client)
+		resp, err = page.Read(ctx, client, pageFlags, readerclient.GetVouchersPage)
+		cobra.CheckErr(err)
+	}
+
+	var output any = resp
+	if decodeFlags.Enabled() {
+		output, err = decodeFlags.Decode(resp...)
 		cobra.CheckErr(err)
 	}
 
-	val, err := json.MarshalIndent(resp, "", "    ")
+	val, err := json.MarshalIndent(output, "", "    ")
 	cobra.CheckErr(err)
 
 	fmt.Print(string(val))
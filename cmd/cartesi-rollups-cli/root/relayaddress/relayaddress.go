@@ -0,0 +1,67 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package relayaddress
+
+import (
+	"log/slog"
+
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/book"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/signer"
+	"github.com/cartesi/rollups-node/pkg/ethutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "relay-address",
+	Short: "Relay the application address to the application through the DAppAddressRelay",
+	Long: `Relay the application address to the application through the DAppAddressRelay.
+The application backend receives its own address as an input, which it needs to execute vouchers
+that target itself, such as ether withdrawals.`,
+	Example: examples,
+	Run:     run,
+}
+
+const examples = `# Relay the address of the test application:
+cartesi-rollups-cli relay-address
+
+# Relay the address of the application in the address book:
+cartesi-rollups-cli relay-address --address-book deployment.json`
+
+var (
+	ethEndpoint string
+	signerFlags *signer.Flags
+	bookFlags   *book.Flags
+)
+
+func init() {
+	Cmd.Flags().StringVar(&ethEndpoint, "eth-endpoint", "http://localhost:8545",
+		"ethereum node JSON-RPC endpoint")
+
+	signerFlags = signer.AddFlags(Cmd)
+
+	bookFlags = book.AddFlags(Cmd)
+}
+
+func run(cmd *cobra.Command, args []string) {
+	ctx := cmd.Context()
+	client, err := ethclient.DialContext(ctx, ethEndpoint)
+	cobra.CheckErr(err)
+	slog.Info("Connected", "eth-endpoint", ethEndpoint)
+
+	signer, err := signerFlags.NewSigner(ctx, client)
+	cobra.CheckErr(err)
+
+	txOpts, err := signerFlags.TxOptions()
+	cobra.CheckErr(err)
+
+	book, err := bookFlags.Load(ctx, client)
+	cobra.CheckErr(err)
+
+	slog.Info("Relaying application address", "application-address", book.CartesiDApp)
+	inputIndex, err := ethutil.RelayDAppAddress(ctx, client, book, signer, txOpts)
+	cobra.CheckErr(err)
+
+	slog.Info("Input added", "input-index", inputIndex)
+}
@@ -4,15 +4,21 @@
 package root
 
 import (
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/addressbook"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/admin"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/deploy"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/deposit"
 	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/deps"
 	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/execute"
 	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/increasetime"
 	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/inspect"
 	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/mine"
 	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/read"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/relayaddress"
 	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/savesnapshot"
 	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/send"
 	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/validate"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/root/verifyclaims"
 	"github.com/spf13/cobra"
 )
 
@@ -33,5 +39,11 @@ func init() {
 	Cmd.AddCommand(deps.Cmd)
 	Cmd.AddCommand(execute.Cmd)
 	Cmd.AddCommand(mine.Cmd)
+	Cmd.AddCommand(deposit.Cmd)
+	Cmd.AddCommand(relayaddress.Cmd)
+	Cmd.AddCommand(addressbook.Cmd)
+	Cmd.AddCommand(verifyclaims.Cmd)
+	Cmd.AddCommand(admin.Cmd)
+	Cmd.AddCommand(deploy.Cmd)
 	Cmd.DisableAutoGenTag = true
 }
@@ -6,7 +6,8 @@ package send
 import (
 	"log/slog"
 
-	"github.com/cartesi/rollups-node/pkg/addresses"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/book"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/signer"
 	"github.com/cartesi/rollups-node/pkg/ethutil"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/ethclient"
@@ -21,33 +22,33 @@ var Cmd = &cobra.Command{
 }
 
 const examples = `# Send the string "hi" encoded as hex:
-cartesi-rollups-cli send --payload 0x$(printf "hi" | xxd -p)`
+cartesi-rollups-cli send --payload 0x$(printf "hi" | xxd -p)
+
+# Send the input signing it with an encrypted keystore:
+cartesi-rollups-cli send --payload 0x6869 --keystore key.json --keystore-password-file password
+
+# Send the input signing it with an external signer, such as Clef:
+cartesi-rollups-cli send --payload 0x6869 --signer-url ~/.clef/clef.ipc`
 
 var (
-	ethEndpoint     string
-	mnemonic        string
-	account         uint32
-	hexPayload      string
-	addressBookFile string
+	ethEndpoint string
+	signerFlags *signer.Flags
+	hexPayload  string
+	bookFlags   *book.Flags
 )
 
 func init() {
 	Cmd.Flags().StringVar(&ethEndpoint, "eth-endpoint", "http://localhost:8545",
 		"ethereum node JSON-RPC endpoint")
 
-	Cmd.Flags().StringVar(&mnemonic, "mnemonic", ethutil.FoundryMnemonic,
-		"mnemonic used to sign the transaction")
-
-	Cmd.Flags().Uint32Var(&account, "account", 0,
-		"account index used to sign the transaction (default: 0)")
+	signerFlags = signer.AddFlags(Cmd)
 
 	Cmd.Flags().StringVar(&hexPayload, "payload", "",
 		"input payload hex-encoded starting with 0x")
 
 	cobra.CheckErr(Cmd.MarkFlagRequired("payload"))
 
-	Cmd.Flags().StringVar(&addressBookFile, "address-book", "",
-		"if set, load the address book from the given file; else, use test addresses")
+	bookFlags = book.AddFlags(Cmd)
 }
 
 func run(cmd *cobra.Command, args []string) {
@@ -59,19 +60,17 @@ func run(cmd *cobra.Command, args []string) {
 	cobra.CheckErr(err)
 	slog.Info("Connected", "eth-endpoint", ethEndpoint)
 
-	signer, err := ethutil.NewMnemonicSigner(ctx, client, mnemonic, account)
+	signer, err := signerFlags.NewSigner(ctx, client)
 	cobra.CheckErr(err)
 
-	var book *addresses.Book
-	if addressBookFile != "" {
-		book, err = addresses.GetBookFromFile(addressBookFile)
-		cobra.CheckErr(err)
-	} else {
-		book = addresses.GetTestBook()
-	}
+	txOpts, err := signerFlags.TxOptions()
+	cobra.CheckErr(err)
+
+	book, err := bookFlags.Load(ctx, client)
+	cobra.CheckErr(err)
 
 	slog.Info("Sending input", "application-address", book.CartesiDApp)
-	inputIndex, err := ethutil.AddInput(ctx, client, book, signer, payload)
+	inputIndex, err := ethutil.AddInputWithOptions(ctx, client, book, signer, payload, txOpts)
 	cobra.CheckErr(err)
 
 	slog.Info("Input added", "input-index", inputIndex)
@@ -4,13 +4,18 @@
 package validate
 
 import (
+	"errors"
+	"fmt"
 	"log/slog"
 	"os"
 
 	"github.com/Khan/genqlient/graphql"
-	"github.com/cartesi/rollups-node/pkg/addresses"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/book"
 	"github.com/cartesi/rollups-node/pkg/ethutil"
+	"github.com/cartesi/rollups-node/pkg/proofs"
 	"github.com/cartesi/rollups-node/pkg/readerclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/spf13/cobra"
 )
@@ -23,14 +28,22 @@ var Cmd = &cobra.Command{
 }
 
 const examples = `# Validates notice 5 from input 6:
-cartesi-rollups-cli validate --notice-index 5 --input-index 6`
+cartesi-rollups-cli validate --notice-index 5 --input-index 6
+
+# Verifies the proof of notice 5 from input 6 without calling the blockchain node:
+cartesi-rollups-cli validate --notice-index 5 --input-index 6 --offline
+
+# Also checks the proof against the epoch hash of a claim obtained separately:
+cartesi-rollups-cli validate --notice-index 5 --input-index 6 --offline --epoch-hash $HASH`
 
 var (
 	noticeIndex     int
 	inputIndex      int
 	graphqlEndpoint string
 	ethEndpoint     string
-	addressBookFile string
+	bookFlags       *book.Flags
+	offline         bool
+	epochHash       string
 )
 
 func init() {
@@ -50,11 +63,20 @@ func init() {
 	Cmd.Flags().StringVar(&ethEndpoint, "eth-endpoint", "http://localhost:8545",
 		"ethereum node JSON-RPC endpoint")
 
-	Cmd.Flags().StringVar(&addressBookFile, "address-book", "",
-		"if set, load the address book from the given file; else, use test addresses")
+	bookFlags = book.AddFlags(Cmd)
+
+	Cmd.Flags().BoolVar(&offline, "offline", false,
+		"verify the Merkle proof locally instead of calling CartesiDApp.validateNotice")
+
+	Cmd.Flags().StringVar(&epochHash, "epoch-hash", "",
+		"if set, epoch hash of the claim the proof must match; requires --offline")
 }
 
 func run(cmd *cobra.Command, args []string) {
+	if epochHash != "" && !offline {
+		cobra.CheckErr(errors.New("--epoch-hash requires --offline"))
+	}
+
 	ctx := cmd.Context()
 	graphqlClient := graphql.NewClient(graphqlEndpoint, nil)
 
@@ -66,17 +88,17 @@ func run(cmd *cobra.Command, args []string) {
 		os.Exit(0)
 	}
 
+	if offline {
+		validateOffline(resp)
+		return
+	}
+
 	client, err := ethclient.DialContext(ctx, ethEndpoint)
 	cobra.CheckErr(err)
 	slog.Info("Connected", "eth-endpoint", ethEndpoint)
 
-	var book *addresses.Book
-	if addressBookFile != "" {
-		book, err = addresses.GetBookFromFile(addressBookFile)
-		cobra.CheckErr(err)
-	} else {
-		book = addresses.GetTestBook()
-	}
+	book, err := bookFlags.Load(ctx, client)
+	cobra.CheckErr(err)
 
 	proof := readerclient.ConvertToContractProof(resp.Proof)
 
@@ -90,3 +112,30 @@ func run(cmd *cobra.Command, args []string) {
 
 	slog.Info("Notice validated")
 }
+
+func validateOffline(notice *readerclient.Notice) {
+	slog.Info("Verifying notice proof offline",
+		"notice-index", noticeIndex,
+		"input-index", inputIndex,
+	)
+	proofEpochHash, err := proofs.VerifyNotice(notice.Payload, notice.Proof)
+	cobra.CheckErr(err)
+	slog.Info("Notice proof verified", "epoch-hash", proofEpochHash)
+
+	if epochHash == "" {
+		return
+	}
+	if !isHash(epochHash) {
+		cobra.CheckErr(fmt.Errorf("invalid --epoch-hash: %v", epochHash))
+	}
+	if proofEpochHash != common.HexToHash(epochHash) {
+		cobra.CheckErr(fmt.Errorf("%w: the proof commits to %v, but the claim is %v",
+			proofs.ErrIncorrectEpochHash, proofEpochHash, epochHash))
+	}
+	slog.Info("Notice proof matches the claim")
+}
+
+func isHash(value string) bool {
+	bytes, err := hexutil.Decode(value)
+	return err == nil && len(bytes) == common.HashLength
+}
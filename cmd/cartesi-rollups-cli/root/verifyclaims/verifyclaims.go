@@ -0,0 +1,156 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package verifyclaims
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/cartesi/rollups-node/cmd/cartesi-rollups-cli/book"
+	"github.com/cartesi/rollups-node/pkg/claims"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+var Cmd = &cobra.Command{
+	Use:   "verify-claims",
+	Short: "Verifies the claims of the application against the outputs of the node",
+	Long: `Verifies the claims of the application in the History contract against the outputs
+reported by the GraphQL API of the node.
+The epoch hash of each claim is recomputed from the output proofs, and the claims must cover
+the inputs of their epochs and be submitted up to an epoch length after the end of the epoch,
+plus the grace period. Mismatching, missing and late claims are reported, and the command
+fails if it finds any.`,
+	Example: examples,
+	Run:     run,
+}
+
+const examples = `# Verify the claims of the test application in the devnet:
+cartesi-rollups-cli verify-claims
+
+# Verify the claims of an application in Sepolia, with the same settings as its node:
+cartesi-rollups-cli verify-claims --eth-endpoint "$RPC_URL" --address-book deployment.json \
+    --epoch-length 7200 --input-box-block 3963384 --grace-period 600 --format table`
+
+const (
+	formatJson  = "json"
+	formatTable = "table"
+)
+
+var (
+	ethEndpoint     string
+	graphqlEndpoint string
+	bookFlags       *book.Flags
+	epochLength     uint64
+	inputBoxBlock   uint64
+	gracePeriod     uint64
+	chunkSize       uint64
+	format          string
+)
+
+func init() {
+	Cmd.Flags().StringVar(&ethEndpoint, "eth-endpoint", "http://localhost:8545",
+		"ethereum node JSON-RPC endpoint")
+
+	Cmd.Flags().StringVar(&graphqlEndpoint, "graphql-endpoint", "http://localhost:10000/graphql",
+		"address used to connect to graphql")
+
+	bookFlags = book.AddFlags(Cmd)
+
+	Cmd.Flags().Uint64Var(&epochLength, "epoch-length", 120,
+		"length of the epochs in blocks, as in CARTESI_EPOCH_LENGTH")
+
+	Cmd.Flags().Uint64Var(&inputBoxBlock, "input-box-block", 20,
+		"deployment block of the InputBox, where the first epoch starts")
+
+	Cmd.Flags().Uint64Var(&gracePeriod, "grace-period", 0,
+		"blocks tolerated after the deadline of a claim before it is late or missing")
+
+	Cmd.Flags().Uint64Var(&chunkSize, "chunk-size", claims.DefaultChunkSize,
+		"max number of blocks in each request for claims")
+
+	Cmd.Flags().StringVar(&format, "format", formatJson,
+		"output format: json or table")
+}
+
+func run(cmd *cobra.Command, args []string) {
+	if format != formatJson && format != formatTable {
+		cobra.CheckErr(fmt.Errorf("invalid --format %q: expected json or table", format))
+	}
+
+	ctx := cmd.Context()
+	client, err := ethclient.DialContext(ctx, ethEndpoint)
+	cobra.CheckErr(err)
+
+	book, err := bookFlags.Load(ctx, client)
+	cobra.CheckErr(err)
+
+	reader, err := claims.NewReader(ctx, client, book)
+	cobra.CheckErr(err)
+
+	verifier, err := claims.NewVerifier(reader, graphql.NewClient(graphqlEndpoint, nil),
+		claims.EpochConfig{
+			Length:       epochLength,
+			GenesisBlock: inputBoxBlock,
+			GracePeriod:  gracePeriod,
+		})
+	cobra.CheckErr(err)
+	verifier.ChunkSize = chunkSize
+
+	report, err := verifier.Verify(ctx)
+	cobra.CheckErr(err)
+
+	if format == formatTable {
+		printTable(report)
+	} else {
+		val, err := json.MarshalIndent(report, "", "    ")
+		cobra.CheckErr(err)
+		fmt.Println(string(val))
+	}
+
+	if len(report.Findings) > 0 {
+		cobra.CheckErr(fmt.Errorf("found %v problems in the claims", len(report.Findings)))
+	}
+}
+
+func printTable(report *claims.Report) {
+	kinds := make(map[uint64][]string)
+	for _, finding := range report.Findings {
+		kinds[finding.Epoch] = append(kinds[finding.Epoch], string(finding.Kind))
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "EPOCH\tINPUTS\tDEADLINE\tCLAIM\tSTATUS")
+	for _, epoch := range report.Epochs {
+		claim := "-"
+		if epoch.Claim != nil {
+			claim = fmt.Sprint(epoch.Claim.Index)
+		}
+		fmt.Fprintf(w, "%v\t%v-%v\t%v\t%v\t%v\n", epoch.Epoch, epoch.FirstInput,
+			epoch.LastInput, epoch.Deadline, claim, status(epoch, kinds[epoch.Epoch]))
+	}
+	cobra.CheckErr(w.Flush())
+	for _, finding := range report.Findings {
+		fmt.Println(finding)
+	}
+}
+
+// Summarize the verification of the epoch.
+func status(epoch claims.EpochReport, kinds []string) string {
+	switch {
+	case len(kinds) > 0:
+		return strings.Join(kinds, ",")
+	case epoch.Verified:
+		return "verified"
+	case epoch.Claim != nil:
+		return "unverified"
+	case epoch.Closed:
+		return "pending"
+	default:
+		return "open"
+	}
+}
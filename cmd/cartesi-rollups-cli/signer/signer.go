@@ -0,0 +1,154 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+// This package contains the flags shared by the commands that sign and send transactions.
+package signer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/cartesi/rollups-node/pkg/ethutil"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/spf13/cobra"
+)
+
+// Flags that select how the transactions are signed.
+type Flags struct {
+	mnemonic             string
+	account              uint32
+	keystore             string
+	keystorePasswordFile string
+	signerURL            string
+	signerAccount        string
+	legacy               bool
+	maxFeePerGas         string
+	maxPriorityFeePerGas string
+	gasMargin            uint64
+	replaceAfter         time.Duration
+	maxReplacements      int
+	confirmations        uint64
+	timeout              time.Duration
+}
+
+// AddFlags adds the signer flags to the command.
+func AddFlags(cmd *cobra.Command) *Flags {
+	var f Flags
+
+	cmd.Flags().StringVar(&f.mnemonic, "mnemonic", ethutil.FoundryMnemonic,
+		"mnemonic used to sign the transaction")
+
+	cmd.Flags().Uint32Var(&f.account, "account", 0,
+		"account index used to sign the transaction (default: 0)")
+
+	cmd.Flags().StringVar(&f.keystore, "keystore", "",
+		"if set, sign the transaction with the key in this encrypted keystore file")
+
+	cmd.Flags().StringVar(&f.keystorePasswordFile, "keystore-password-file", "",
+		"file with the password of the keystore")
+
+	cmd.Flags().StringVar(&f.signerURL, "signer-url", "",
+		"if set, sign the transaction with the external JSON-RPC signer at this HTTP URL "+
+			"or Unix socket path")
+
+	cmd.Flags().StringVar(&f.signerAccount, "signer-account", "",
+		"address of the account of the external signer (default: its first account)")
+
+	cmd.Flags().BoolVar(&f.legacy, "legacy", false,
+		"send a legacy transaction with a gas price, for chains without EIP-1559")
+
+	cmd.Flags().StringVar(&f.maxFeePerGas, "max-fee-per-gas", "",
+		"if set, the max fee per gas in wei, or the gas price of legacy transactions, "+
+			"never exceeds this value")
+
+	cmd.Flags().StringVar(&f.maxPriorityFeePerGas, "max-priority-fee-per-gas", "",
+		"if set, the priority fee per gas in wei never exceeds this value")
+
+	cmd.Flags().Uint64Var(&f.gasMargin, "gas-margin", ethutil.DefaultGasMargin,
+		"percentage added to the estimated gas limit")
+
+	cmd.Flags().DurationVar(&f.replaceAfter, "replace-after", 0,
+		"if set, replace the transaction with one with higher fees when it stays pending "+
+			"for this long, up to --max-replacements times. It requires --max-fee-per-gas")
+
+	cmd.Flags().IntVar(&f.maxReplacements, "max-replacements", ethutil.DefaultMaxReplacements,
+		"number of times the transaction is replaced with --replace-after")
+
+	cmd.Flags().Uint64Var(&f.confirmations, "confirmations", 1,
+		"number of blocks, including the one with the transaction, to wait for")
+
+	cmd.Flags().DurationVar(&f.timeout, "timeout", 0,
+		"if set, stop waiting for the transaction after this long")
+
+	cmd.MarkFlagsRequiredTogether("keystore", "keystore-password-file")
+	cmd.MarkFlagsMutuallyExclusive("keystore", "mnemonic")
+	cmd.MarkFlagsMutuallyExclusive("keystore", "account")
+	cmd.MarkFlagsMutuallyExclusive("signer-url", "keystore")
+	cmd.MarkFlagsMutuallyExclusive("signer-url", "mnemonic")
+	cmd.MarkFlagsMutuallyExclusive("signer-url", "account")
+
+	return &f
+}
+
+// NewSigner creates the signer selected by the flags.
+func (f *Flags) NewSigner(ctx context.Context, client *ethclient.Client) (ethutil.Signer, error) {
+	if f.signerURL != "" {
+		var account *common.Address
+		if f.signerAccount != "" {
+			if !common.IsHexAddress(f.signerAccount) {
+				return nil, fmt.Errorf("invalid signer account: %v", f.signerAccount)
+			}
+			address := common.HexToAddress(f.signerAccount)
+			account = &address
+		}
+		return ethutil.NewRemoteSigner(ctx, client, f.signerURL, account)
+	}
+	if f.keystore != "" {
+		return ethutil.NewKeystoreSigner(ctx, client, f.keystore, f.keystorePasswordFile)
+	}
+	return ethutil.NewMnemonicSigner(ctx, client, f.mnemonic, f.account)
+}
+
+// TxOptions returns the transaction options selected by the flags.
+func (f *Flags) TxOptions() (*ethutil.TxOptions, error) {
+	opts := &ethutil.TxOptions{
+		Legacy:          f.legacy,
+		GasMargin:       f.gasMargin,
+		ReplaceAfter:    f.replaceAfter,
+		MaxReplacements: f.maxReplacements,
+		Confirmations:   f.confirmations,
+		Timeout:         f.timeout,
+	}
+	var err error
+	opts.MaxFeePerGas, err = parseWei("max-fee-per-gas", f.maxFeePerGas)
+	if err != nil {
+		return nil, err
+	}
+	// the fees would be raised on every replacement without a ceiling
+	if f.replaceAfter > 0 && opts.MaxFeePerGas == nil {
+		return nil, errors.New("--replace-after requires --max-fee-per-gas")
+	}
+	if f.maxReplacements <= 0 {
+		return nil, errors.New("invalid --max-replacements: expected a positive number")
+	}
+	opts.MaxPriorityFeePerGas, err = parseWei("max-priority-fee-per-gas", f.maxPriorityFeePerGas)
+	if err != nil {
+		return nil, err
+	}
+	return opts, nil
+}
+
+func parseWei(flag string, value string) (*big.Int, error) {
+	if value == "" {
+		return nil, nil
+	}
+	wei, ok := new(big.Int).SetString(value, 10)
+	if !ok || wei.Sign() < 0 {
+		return nil, fmt.Errorf("invalid --%v: expected an amount in wei", flag)
+	}
+	return wei, nil
+}
@@ -5,6 +5,8 @@ package main
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"os"
 	"os/signal"
@@ -15,6 +17,7 @@ import (
 	"github.com/cartesi/rollups-node/internal/node/config"
 	"github.com/lmittmann/tint"
 	"github.com/mattn/go-isatty"
+	"github.com/spf13/pflag"
 )
 
 var (
@@ -26,30 +29,51 @@ var (
 func main() {
 	startTime := time.Now()
 
+	flags := pflag.NewFlagSet(os.Args[0], pflag.ContinueOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(os.Stderr, "Usage: %v [flags]\n\n", os.Args[0])
+		fmt.Fprintln(os.Stderr, "Runs the Cartesi Rollups Node.")
+		fmt.Fprintln(os.Stderr, "Each flag sets the corresponding CARTESI_* environment variable,")
+		fmt.Fprintln(os.Stderr, "taking precedence over it. See docs/config.md for details.")
+		fmt.Fprintf(os.Stderr, "\nFlags:\n%v", flags.FlagUsages())
+	}
+	config.AddFlags(flags)
+	if err := flags.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, pflag.ErrHelp) {
+			os.Exit(0)
+		}
+		os.Exit(1)
+	}
+
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
-	config := config.FromEnv()
+	nodeConfig := config.FromEnv()
 
-	// setup log
-	opts := &tint.Options{
-		Level:      config.LogLevel,
-		AddSource:  config.LogLevel == slog.LevelDebug,
-		NoColor:    !config.LogPretty || !isatty.IsTerminal(os.Stdout.Fd()),
-		TimeFormat: "2006-01-02T15:04:05.000", // RFC3339 with milliseconds and without timezone
-	}
-	handler := tint.NewHandler(os.Stdout, opts)
-	logger := slog.New(handler)
-	slog.SetDefault(logger)
-	slog.Info("Starting the Cartesi Rollups Node", "version", buildVersion, "config", config)
+	setupLog(nodeConfig)
+	slog.Info("Starting the Cartesi Rollups Node", "version", buildVersion, "config", nodeConfig)
 
 	// create the node supervisor
-	supervisor, err := node.Setup(ctx, config, "")
+	supervisor, err := node.Setup(ctx, nodeConfig, "")
 	if err != nil {
 		slog.Error("Node exited with an error", "error", err)
 		os.Exit(1)
 	}
 
+	// reload the config on SIGHUP
+	hangup := make(chan os.Signal, 1)
+	signal.Notify(hangup, syscall.SIGHUP)
+	go func() {
+		for {
+			select {
+			case <-hangup:
+				reloadConfig(ctx, supervisor)
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
 	// logs startup time
 	ready := make(chan struct{}, 1)
 	go func() {
@@ -67,3 +91,33 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// Setup the default logger using the config.
+func setupLog(c config.NodeConfig) {
+	opts := &tint.Options{
+		Level:      c.LogLevel,
+		AddSource:  c.LogLevel == slog.LevelDebug,
+		NoColor:    !c.LogPretty || !isatty.IsTerminal(os.Stdout.Fd()),
+		TimeFormat: "2006-01-02T15:04:05.000", // RFC3339 with milliseconds and without timezone
+	}
+	handler := tint.NewHandler(os.Stdout, opts)
+	logger := slog.New(handler)
+	slog.SetDefault(logger)
+}
+
+// Re-read the config and apply it to the running node.
+// The node keeps running with the previous config if the new one can't be applied.
+func reloadConfig(ctx context.Context, n *node.Node) {
+	slog.Info("Received SIGHUP; reloading the configuration")
+	c, err := config.TryFromEnv()
+	if err != nil {
+		slog.Error("Failed to reload the configuration", "error", err)
+		return
+	}
+	if err := n.Reload(ctx, c); err != nil {
+		slog.Error("Failed to reload the configuration", "error", err)
+		return
+	}
+	setupLog(c)
+	slog.Info("Configuration reloaded", "config", c)
+}
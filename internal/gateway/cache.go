@@ -0,0 +1,115 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package gateway
+
+import (
+	"bytes"
+	"container/list"
+	"encoding/json"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Least recently used cache of the results of immutable requests.
+type cache struct {
+	size    int
+	mutex   sync.Mutex
+	entries map[string]*list.Element
+	order   *list.List // most recently used first
+}
+
+type cacheEntry struct {
+	key    string
+	result json.RawMessage
+}
+
+func newCache(size int) *cache {
+	return &cache{
+		size:    size,
+		entries: make(map[string]*list.Element),
+		order:   list.New(),
+	}
+}
+
+func (c *cache) get(key string) (json.RawMessage, bool) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	element, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(element)
+	return element.Value.(cacheEntry).result, true
+}
+
+func (c *cache) add(key string, result json.RawMessage) {
+	if c.size <= 0 {
+		return
+	}
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if element, ok := c.entries[key]; ok {
+		c.order.MoveToFront(element)
+		return
+	}
+	c.entries[key] = c.order.PushFront(cacheEntry{key, result})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(cacheEntry).key)
+	}
+}
+
+// Returns the cache key of the request, which doesn't depend on the request ID.
+func cacheKey(req request) string {
+	var params bytes.Buffer
+	if err := json.Compact(&params, req.Params); err != nil {
+		return req.Method + string(req.Params)
+	}
+	return req.Method + params.String()
+}
+
+// Reports whether the result of the request can't change anymore.
+// Blocks are considered final when they are at least finalityOffset blocks before the head.
+func isImmutable(req request, result json.RawMessage, head uint64, finalityOffset uint64) bool {
+	if len(result) == 0 || string(result) == "null" {
+		return false
+	}
+	isFinal := func(block string) bool {
+		number, err := parseBlockNumber(block)
+		return err == nil && head > 0 && number+finalityOffset <= head
+	}
+	switch req.Method {
+	case "eth_chainId", "net_version", "eth_getBlockByHash":
+		return true
+	case "eth_getBlockByNumber":
+		var params []json.RawMessage
+		var block string
+		return json.Unmarshal(req.Params, &params) == nil && len(params) > 0 &&
+			json.Unmarshal(params[0], &block) == nil && isFinal(block)
+	case "eth_getLogs":
+		var params []struct {
+			BlockHash *string `json:"blockHash"`
+			FromBlock *string `json:"fromBlock"`
+			ToBlock   *string `json:"toBlock"`
+		}
+		if json.Unmarshal(req.Params, &params) != nil || len(params) != 1 {
+			return false
+		}
+		filter := params[0]
+		if filter.BlockHash != nil {
+			return true
+		}
+		return filter.FromBlock != nil && filter.ToBlock != nil &&
+			isFinal(*filter.FromBlock) && isFinal(*filter.ToBlock)
+	default:
+		return false
+	}
+}
+
+// Parses a block number in hex format. Tags such as "latest" are rejected.
+func parseBlockNumber(block string) (uint64, error) {
+	return hexutil.DecodeUint64(block)
+}
@@ -0,0 +1,394 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+// This package implements a JSON-RPC gateway that sits between the node services and the
+// blockchain RPC providers.
+//
+// The gateway forwards each request to the first healthy upstream endpoint and fails over to the
+// next one when an upstream can't be reached. It caches the responses that can't change, such as
+// old blocks and logs, rate-limits requests per method, and exports per-method metrics.
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sync/atomic"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"golang.org/x/time/rate"
+)
+
+const (
+	// Maximum size of a request body.
+	maxRequestSize = 5 * 1024 * 1024
+
+	// Rate limit that applies to the methods without a limit of their own.
+	AnyMethod = "*"
+
+	// JSON-RPC error codes returned by the gateway itself.
+	codeParseError     = -32700
+	codeInvalidRequest = -32600
+	codeInternalError  = -32603
+)
+
+// Config for the gateway.
+type Config struct {
+	// Upstream HTTP endpoints, in order of preference.
+	Upstreams []string
+
+	// Interval between health checks of the upstream endpoints.
+	HealthcheckInterval time.Duration
+
+	// Maximum duration of a request to an upstream endpoint.
+	// A request that takes longer fails over to the next upstream.
+	RequestTimeout time.Duration
+
+	// Requests per second allowed for each method.
+	// The limit for AnyMethod applies to the methods that are not listed.
+	// Requests over the limit wait for their turn instead of failing.
+	RateLimits map[string]float64
+
+	// Maximum number of cached responses. Zero disables the cache.
+	CacheSize int
+
+	// Blocks at least this deep from the latest block are considered final, so the responses
+	// about them are cached.
+	FinalityOffset uint64
+}
+
+// Gateway is an http.Handler that serves JSON-RPC requests on / and metrics on /metrics.
+type Gateway struct {
+	config    Config
+	upstreams []*upstream
+	client    *http.Client
+	cache     *cache
+	limiters  map[string]*rate.Limiter
+	metrics   *metrics
+	handler   http.Handler
+}
+
+// An upstream endpoint. The URL is never logged, as it usually contains an API key.
+type upstream struct {
+	name    string
+	url     string
+	healthy atomic.Bool
+	head    atomic.Uint64
+}
+
+// JSON-RPC 2.0 request.
+type request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id,omitempty"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// JSON-RPC 2.0 response.
+type response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  json.RawMessage `json:"result,omitempty"`
+	Error   *rpcError       `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int             `json:"code"`
+	Message string          `json:"message"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+// New creates the gateway.
+func New(config Config) (*Gateway, error) {
+	if len(config.Upstreams) == 0 {
+		return nil, errors.New("no upstream endpoints")
+	}
+	if config.HealthcheckInterval <= 0 {
+		return nil, errors.New("the health check interval must be positive")
+	}
+	if config.RequestTimeout <= 0 {
+		return nil, errors.New("the request timeout must be positive")
+	}
+	g := &Gateway{
+		config:   config,
+		client:   &http.Client{},
+		cache:    newCache(config.CacheSize),
+		limiters: make(map[string]*rate.Limiter),
+		metrics:  newMetrics(),
+	}
+	for i, endpoint := range config.Upstreams {
+		u := &upstream{name: fmt.Sprintf("upstream-%v", i), url: endpoint}
+		u.healthy.Store(true)
+		g.metrics.upstreamHealthy.WithLabelValues(u.name).Set(1)
+		g.upstreams = append(g.upstreams, u)
+	}
+	for method, limit := range config.RateLimits {
+		if limit <= 0 {
+			return nil, fmt.Errorf("invalid rate limit for %v: %v", method, limit)
+		}
+		burst := max(1, int(limit))
+		g.limiters[method] = rate.NewLimiter(rate.Limit(limit), burst)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/", http.HandlerFunc(g.serveRPC))
+	mux.Handle("/metrics", promhttp.HandlerFor(g.metrics.registry, promhttp.HandlerOpts{}))
+	g.handler = mux
+	return g, nil
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	g.handler.ServeHTTP(w, r)
+}
+
+// RunHealthchecks checks the upstream endpoints periodically until the context is done.
+func (g *Gateway) RunHealthchecks(ctx context.Context) {
+	ticker := time.NewTicker(g.config.HealthcheckInterval)
+	defer ticker.Stop()
+	for {
+		g.checkUpstreams(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Checks whether each upstream responds to eth_blockNumber and records its latest block.
+func (g *Gateway) checkUpstreams(ctx context.Context) {
+	for _, u := range g.upstreams {
+		ctx, cancel := context.WithTimeout(ctx, g.config.HealthcheckInterval)
+		head, err := g.blockNumber(ctx, u)
+		cancel()
+		if err != nil {
+			g.setHealthy(u, false, "error", err)
+			continue
+		}
+		u.head.Store(head)
+		g.setHealthy(u, true)
+	}
+}
+
+func (g *Gateway) blockNumber(ctx context.Context, u *upstream) (uint64, error) {
+	req := request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "eth_blockNumber"}
+	resp, err := g.send(ctx, u, req)
+	if err != nil {
+		return 0, err
+	}
+	if resp.Error != nil {
+		return 0, fmt.Errorf("eth_blockNumber: %v", resp.Error.Message)
+	}
+	var number string
+	if err := json.Unmarshal(resp.Result, &number); err != nil {
+		return 0, fmt.Errorf("eth_blockNumber: %v", err)
+	}
+	return parseBlockNumber(number)
+}
+
+func (g *Gateway) setHealthy(u *upstream, healthy bool, args ...any) {
+	if u.healthy.Swap(healthy) != healthy {
+		if healthy {
+			slog.Info("RPC upstream is healthy", "upstream", u.name)
+		} else {
+			slog.Warn("RPC upstream is unhealthy", append([]any{"upstream", u.name}, args...)...)
+		}
+	}
+	value := 0.0
+	if healthy {
+		value = 1
+	}
+	g.metrics.upstreamHealthy.WithLabelValues(u.name).Set(value)
+}
+
+// Returns the latest block known by the healthy upstreams, or zero if it is not known.
+func (g *Gateway) head() uint64 {
+	var head uint64
+	for _, u := range g.upstreams {
+		if u.healthy.Load() {
+			head = max(head, u.head.Load())
+		}
+	}
+	return head
+}
+
+func (g *Gateway) serveRPC(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "expected a POST request", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	var result any
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var batch []request
+		if err := json.Unmarshal(body, &batch); err != nil || len(batch) == 0 {
+			result = errorResponse(nil, codeParseError, "invalid batch")
+		} else {
+			responses := make([]response, len(batch))
+			for i, req := range batch {
+				responses[i] = g.handle(r.Context(), req)
+			}
+			result = responses
+		}
+	} else {
+		var req request
+		if err := json.Unmarshal(body, &req); err != nil {
+			result = errorResponse(nil, codeParseError, "invalid request")
+		} else {
+			result = g.handle(r.Context(), req)
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(result); err != nil {
+		slog.Warn("Failed to write RPC response", "error", err)
+	}
+}
+
+// Handles a single request, from the cache or from an upstream.
+func (g *Gateway) handle(ctx context.Context, req request) response {
+	if req.Method == "" {
+		return errorResponse(req.ID, codeInvalidRequest, "missing method")
+	}
+	start := time.Now()
+	defer func() {
+		g.metrics.duration.WithLabelValues(req.Method).Observe(time.Since(start).Seconds())
+	}()
+	g.metrics.requests.WithLabelValues(req.Method).Inc()
+
+	if err := g.wait(ctx, req.Method); err != nil {
+		g.metrics.errors.WithLabelValues(req.Method).Inc()
+		return errorResponse(req.ID, codeInternalError, err.Error())
+	}
+
+	key := cacheKey(req)
+	if result, ok := g.cache.get(key); ok {
+		g.metrics.cacheHits.WithLabelValues(req.Method).Inc()
+		return response{JSONRPC: "2.0", ID: req.ID, Result: result}
+	}
+
+	resp, err := g.forward(ctx, req)
+	if err != nil {
+		g.metrics.errors.WithLabelValues(req.Method).Inc()
+		return errorResponse(req.ID, codeInternalError, err.Error())
+	}
+	if resp.Error != nil {
+		g.metrics.errors.WithLabelValues(req.Method).Inc()
+	} else if isImmutable(req, resp.Result, g.head(), g.config.FinalityOffset) {
+		g.cache.add(key, resp.Result)
+	}
+	return resp
+}
+
+// Waits until the rate limit of the method allows the request.
+func (g *Gateway) wait(ctx context.Context, method string) error {
+	limiter, ok := g.limiters[method]
+	if !ok {
+		limiter, ok = g.limiters[AnyMethod]
+	}
+	if !ok || limiter.Allow() {
+		return nil
+	}
+	g.metrics.rateLimited.WithLabelValues(method).Inc()
+	if err := limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("rate limit: %v", err)
+	}
+	return nil
+}
+
+// Forwards the request to the first healthy upstream, failing over to the next ones when an
+// upstream fails or times out. It stops when the context is done.
+// If every upstream is unhealthy, it tries all of them anyway.
+func (g *Gateway) forward(ctx context.Context, req request) (response, error) {
+	candidates := make([]*upstream, 0, len(g.upstreams))
+	for _, u := range g.upstreams {
+		if u.healthy.Load() {
+			candidates = append(candidates, u)
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = g.upstreams
+	}
+
+	var err error
+	for _, u := range candidates {
+		var resp response
+		resp, err = g.sendWithTimeout(ctx, u, req)
+		if err == nil {
+			return resp, nil
+		}
+		if ctx.Err() != nil {
+			break
+		}
+		g.metrics.failovers.WithLabelValues(u.name).Inc()
+		g.setHealthy(u, false, "method", req.Method, "error", err)
+	}
+	return response{}, fmt.Errorf("no upstream available: %v", err)
+}
+
+// Sends the request to the upstream, giving up after the request timeout.
+func (g *Gateway) sendWithTimeout(
+	ctx context.Context,
+	u *upstream,
+	req request,
+) (response, error) {
+	attemptCtx, cancel := context.WithTimeout(ctx, g.config.RequestTimeout)
+	defer cancel()
+	resp, err := g.send(attemptCtx, u, req)
+	if err != nil && ctx.Err() == nil && attemptCtx.Err() == context.DeadlineExceeded {
+		return response{}, fmt.Errorf("timed out after %v", g.config.RequestTimeout)
+	}
+	return resp, err
+}
+
+// Sends the request to the upstream.
+// It returns an error if the upstream could not be reached or did not return a valid response.
+// JSON-RPC errors are returned in the response.
+func (g *Gateway) send(ctx context.Context, u *upstream, req request) (response, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return response{}, err
+	}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, u.url, bytes.NewReader(body))
+	if err != nil {
+		// don't wrap the error, which contains the URL
+		return response{}, errors.New("failed to create request")
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpResp, err := g.client.Do(httpReq)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err // drop the URL
+		}
+		return response{}, err
+	}
+	defer httpResp.Body.Close()
+	if httpResp.StatusCode != http.StatusOK {
+		return response{}, fmt.Errorf("unexpected status %v", httpResp.Status)
+	}
+	var resp response
+	if err := json.NewDecoder(httpResp.Body).Decode(&resp); err != nil {
+		return response{}, fmt.Errorf("decode response: %v", err)
+	}
+	return resp, nil
+}
+
+func errorResponse(id json.RawMessage, code int, message string) response {
+	if id == nil {
+		id = json.RawMessage("null")
+	}
+	return response{JSONRPC: "2.0", ID: id, Error: &rpcError{Code: code, Message: message}}
+}
@@ -0,0 +1,277 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package gateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+)
+
+type GatewaySuite struct {
+	suite.Suite
+	primary   *fakeUpstream
+	secondary *fakeUpstream
+	gateway   *Gateway
+	server    *httptest.Server
+}
+
+func TestGateway(t *testing.T) {
+	suite.Run(t, new(GatewaySuite))
+}
+
+func (s *GatewaySuite) SetupTest() {
+	s.primary = newFakeUpstream(100)
+	s.secondary = newFakeUpstream(100)
+	s.gateway = s.newGateway(Config{})
+}
+
+func (s *GatewaySuite) TearDownTest() {
+	s.server.Close()
+	s.primary.Close()
+	s.secondary.Close()
+}
+
+func (s *GatewaySuite) TestItForwardsRequests() {
+	resp := s.call(`{"jsonrpc":"2.0","id":7,"method":"eth_blockNumber"}`)
+	s.Equal(`{"jsonrpc":"2.0","id":7,"result":"0x64"}`, resp)
+	s.Equal(1, s.primary.calls("eth_blockNumber"))
+	s.Equal(0, s.secondary.calls("eth_blockNumber"))
+}
+
+func (s *GatewaySuite) TestItPassesErrorsThrough() {
+	resp := s.call(`{"jsonrpc":"2.0","id":1,"method":"eth_unknown"}`)
+	s.Equal(`{"jsonrpc":"2.0","id":1,"error":{"code":-32601,"message":"not found"}}`, resp)
+	s.Equal(0, s.secondary.calls("eth_unknown"))
+	s.Equal(1.0, testutil.ToFloat64(s.gateway.metrics.errors.WithLabelValues("eth_unknown")))
+}
+
+func (s *GatewaySuite) TestItFailsOverToTheNextUpstream() {
+	s.primary.failing.Store(true)
+	resp := s.call(`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"}`)
+	s.Equal(`{"jsonrpc":"2.0","id":1,"result":"0x64"}`, resp)
+	s.Equal(1, s.secondary.calls("eth_blockNumber"))
+	s.False(s.gateway.upstreams[0].healthy.Load())
+	s.Equal(1.0, testutil.ToFloat64(s.gateway.metrics.failovers.WithLabelValues("upstream-0")))
+
+	// the unhealthy upstream is skipped
+	s.call(`{"jsonrpc":"2.0","id":2,"method":"eth_blockNumber"}`)
+	s.Equal(1, s.primary.calls("eth_blockNumber"))
+	s.Equal(2, s.secondary.calls("eth_blockNumber"))
+}
+
+func (s *GatewaySuite) TestItFailsOverWhenAnUpstreamHangs() {
+	s.gateway = s.newGateway(Config{RequestTimeout: 100 * time.Millisecond})
+	s.primary.hanging.Store(true)
+	resp := s.call(`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"}`)
+	s.Equal(`{"jsonrpc":"2.0","id":1,"result":"0x64"}`, resp)
+	s.Equal(1, s.secondary.calls("eth_blockNumber"))
+	s.False(s.gateway.upstreams[0].healthy.Load())
+	s.Equal(1.0, testutil.ToFloat64(s.gateway.metrics.failovers.WithLabelValues("upstream-0")))
+}
+
+func (s *GatewaySuite) TestItDoesNotFailOverWhenTheRequestIsCanceled() {
+	s.primary.hanging.Store(true)
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	req := request{JSONRPC: "2.0", ID: json.RawMessage("1"), Method: "eth_blockNumber"}
+	_, err := s.gateway.forward(ctx, req)
+	s.ErrorContains(err, "no upstream available")
+	s.Equal(0, s.secondary.calls("eth_blockNumber"))
+	s.True(s.gateway.upstreams[0].healthy.Load())
+	s.Equal(0.0, testutil.ToFloat64(s.gateway.metrics.failovers.WithLabelValues("upstream-0")))
+}
+
+func (s *GatewaySuite) TestItReturnsAnErrorWhenEveryUpstreamFails() {
+	s.primary.failing.Store(true)
+	s.secondary.failing.Store(true)
+	resp := s.call(`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"}`)
+	s.Contains(resp, `"code":-32603`)
+	s.Contains(resp, "no upstream available")
+	s.NotContains(resp, s.primary.URL)
+}
+
+func (s *GatewaySuite) TestHealthchecksTrackTheUpstreams() {
+	s.primary.failing.Store(true)
+	s.gateway.checkUpstreams(context.Background())
+	s.False(s.gateway.upstreams[0].healthy.Load())
+	s.True(s.gateway.upstreams[1].healthy.Load())
+	s.Equal(uint64(100), s.gateway.head())
+
+	s.primary.failing.Store(false)
+	s.gateway.checkUpstreams(context.Background())
+	s.True(s.gateway.upstreams[0].healthy.Load())
+}
+
+func (s *GatewaySuite) TestItCachesFinalBlocks() {
+	s.gateway = s.newGateway(Config{CacheSize: 10, FinalityOffset: 10})
+	s.gateway.checkUpstreams(context.Background())
+
+	final := `{"jsonrpc":"2.0","id":%v,"method":"eth_getBlockByNumber","params":["0x5a",false]}`
+	s.Equal(`{"jsonrpc":"2.0","id":1,"result":{"number":"0x5a"}}`, s.call(fmt.Sprintf(final, 1)))
+	s.Equal(`{"jsonrpc":"2.0","id":2,"result":{"number":"0x5a"}}`, s.call(fmt.Sprintf(final, 2)))
+	s.Equal(1, s.primary.calls("eth_getBlockByNumber"))
+
+	recent := `{"jsonrpc":"2.0","id":1,"method":"eth_getBlockByNumber","params":["0x5b",false]}`
+	s.call(recent)
+	s.call(recent)
+	latest := `{"jsonrpc":"2.0","id":1,"method":"eth_getBlockByNumber","params":["latest",false]}`
+	s.call(latest)
+	s.call(latest)
+	s.Equal(5, s.primary.calls("eth_getBlockByNumber"))
+	s.Equal(1.0,
+		testutil.ToFloat64(s.gateway.metrics.cacheHits.WithLabelValues("eth_getBlockByNumber")))
+}
+
+func (s *GatewaySuite) TestItCachesLogsOfFinalBlocks() {
+	s.gateway = s.newGateway(Config{CacheSize: 10, FinalityOffset: 10})
+	s.gateway.checkUpstreams(context.Background())
+
+	logs := `{"jsonrpc":"2.0","id":1,"method":"eth_getLogs",` +
+		`"params":[{"fromBlock":"0x1","toBlock":"%v"}]}`
+	s.call(fmt.Sprintf(logs, "0x5a"))
+	s.call(fmt.Sprintf(logs, "0x5a"))
+	s.call(fmt.Sprintf(logs, "latest"))
+	s.call(fmt.Sprintf(logs, "latest"))
+	s.Equal(3, s.primary.calls("eth_getLogs"))
+}
+
+func (s *GatewaySuite) TestCacheEvictsTheLeastRecentlyUsedEntry() {
+	c := newCache(2)
+	c.add("a", json.RawMessage("1"))
+	c.add("b", json.RawMessage("2"))
+	c.get("a")
+	c.add("c", json.RawMessage("3"))
+	_, ok := c.get("b")
+	s.False(ok)
+	_, ok = c.get("a")
+	s.True(ok)
+}
+
+func (s *GatewaySuite) TestItHandlesBatches() {
+	resp := s.call(`[{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"},` +
+		`{"jsonrpc":"2.0","id":2,"method":"eth_chainId"}]`)
+	s.Equal(`[{"jsonrpc":"2.0","id":1,"result":"0x64"},{"jsonrpc":"2.0","id":2,"result":"0x7a69"}]`,
+		resp)
+}
+
+func (s *GatewaySuite) TestItRateLimitsPerMethod() {
+	s.gateway = s.newGateway(Config{RateLimits: map[string]float64{"eth_blockNumber": 2}})
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		s.call(`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"}`)
+		s.call(`{"jsonrpc":"2.0","id":1,"method":"eth_chainId"}`)
+	}
+	s.GreaterOrEqual(time.Since(start), 400*time.Millisecond)
+	s.Equal(1.0,
+		testutil.ToFloat64(s.gateway.metrics.rateLimited.WithLabelValues("eth_blockNumber")))
+	s.Equal(0.0, testutil.ToFloat64(s.gateway.metrics.rateLimited.WithLabelValues("eth_chainId")))
+}
+
+func (s *GatewaySuite) TestItExportsMetrics() {
+	s.call(`{"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"}`)
+	resp, err := http.Get(s.server.URL + "/metrics")
+	s.Require().Nil(err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	s.Require().Nil(err)
+	s.Contains(string(body), `cartesi_rpc_gateway_requests_total{method="eth_blockNumber"} 1`)
+}
+
+func (s *GatewaySuite) newGateway(config Config) *Gateway {
+	if s.server != nil {
+		s.server.Close()
+	}
+	config.Upstreams = []string{s.primary.URL, s.secondary.URL}
+	config.HealthcheckInterval = time.Second
+	if config.RequestTimeout == 0 {
+		config.RequestTimeout = time.Second
+	}
+	gateway, err := New(config)
+	s.Require().Nil(err)
+	s.server = httptest.NewServer(gateway)
+	return gateway
+}
+
+// Sends the request to the gateway and returns the response body.
+func (s *GatewaySuite) call(body string) string {
+	resp, err := http.Post(s.server.URL, "application/json", strings.NewReader(body))
+	s.Require().Nil(err)
+	defer resp.Body.Close()
+	s.Require().Equal(http.StatusOK, resp.StatusCode)
+	data, err := io.ReadAll(resp.Body)
+	s.Require().Nil(err)
+	return string(bytes.TrimSpace(data))
+}
+
+// Stand-in for a blockchain RPC provider.
+type fakeUpstream struct {
+	*httptest.Server
+	head    uint64
+	failing atomic.Bool
+	hanging atomic.Bool
+	mutex   sync.Mutex
+	counts  map[string]int
+}
+
+func newFakeUpstream(head uint64) *fakeUpstream {
+	u := &fakeUpstream{head: head, counts: make(map[string]int)}
+	u.Server = httptest.NewServer(http.HandlerFunc(u.serve))
+	return u
+}
+
+func (u *fakeUpstream) calls(method string) int {
+	u.mutex.Lock()
+	defer u.mutex.Unlock()
+	return u.counts[method]
+}
+
+func (u *fakeUpstream) serve(w http.ResponseWriter, r *http.Request) {
+	var req request
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	u.mutex.Lock()
+	u.counts[req.Method]++
+	u.mutex.Unlock()
+	if u.failing.Load() {
+		w.WriteHeader(http.StatusBadGateway)
+		return
+	}
+	if u.hanging.Load() {
+		<-r.Context().Done()
+		return
+	}
+
+	resp := response{JSONRPC: "2.0", ID: req.ID}
+	switch req.Method {
+	case "eth_blockNumber":
+		resp.Result = json.RawMessage(fmt.Sprintf(`"0x%x"`, u.head))
+	case "eth_chainId":
+		resp.Result = json.RawMessage(`"0x7a69"`)
+	case "eth_getBlockByNumber":
+		var params []json.RawMessage
+		_ = json.Unmarshal(req.Params, &params)
+		resp.Result = json.RawMessage(fmt.Sprintf(`{"number":%s}`, params[0]))
+	case "eth_getLogs":
+		resp.Result = json.RawMessage(`[]`)
+	default:
+		resp.Error = &rpcError{Code: -32601, Message: "not found"}
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(resp)
+}
@@ -0,0 +1,68 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package gateway
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "cartesi_rpc_gateway"
+
+// Metrics of the gateway.
+// Each gateway has its own registry, so it may be restarted without registering them twice.
+type metrics struct {
+	registry        *prometheus.Registry
+	requests        *prometheus.CounterVec
+	errors          *prometheus.CounterVec
+	cacheHits       *prometheus.CounterVec
+	rateLimited     *prometheus.CounterVec
+	duration        *prometheus.HistogramVec
+	failovers       *prometheus.CounterVec
+	upstreamHealthy *prometheus.GaugeVec
+}
+
+func newMetrics() *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "requests_total",
+			Help:      "Number of requests by method.",
+		}, []string{"method"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "errors_total",
+			Help:      "Number of requests that returned an error by method.",
+		}, []string{"method"}),
+		cacheHits: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "cache_hits_total",
+			Help:      "Number of requests served from the cache by method.",
+		}, []string{"method"}),
+		rateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "rate_limited_total",
+			Help:      "Number of requests delayed by the rate limit by method.",
+		}, []string{"method"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: metricsNamespace,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of the requests by method.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method"}),
+		failovers: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "failovers_total",
+			Help:      "Number of requests that failed over to the next upstream by upstream.",
+		}, []string{"upstream"}),
+		upstreamHealthy: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "upstream_healthy",
+			Help:      "Whether the upstream is healthy (1) or not (0).",
+		}, []string{"upstream"}),
+	}
+	m.registry.MustRegister(m.requests, m.errors, m.cacheHits, m.rateLimited, m.duration,
+		m.failovers, m.upstreamHealthy)
+	return m
+}
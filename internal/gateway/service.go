@@ -0,0 +1,38 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package gateway
+
+import (
+	"context"
+
+	"github.com/cartesi/rollups-node/internal/services"
+)
+
+// Service runs the gateway as an HTTP server along with the upstream health checks.
+type Service struct {
+	Name    string
+	Address string
+	Config  Config
+}
+
+func (s Service) String() string {
+	return s.Name
+}
+
+func (s Service) Start(ctx context.Context, ready chan<- struct{}) error {
+	gateway, err := New(s.Config)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go gateway.RunHealthchecks(ctx)
+
+	server := services.HttpService{
+		Name:    s.Name,
+		Address: s.Address,
+		Handler: gateway,
+	}
+	return server.Start(ctx, ready)
+}
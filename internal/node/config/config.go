@@ -3,11 +3,18 @@
 
 // The config package manages the node configuration, which comes from environment variables.
 // The sub-package generate specifies these environment variables.
+// Secret variables may also be loaded from files through their _FILE variants, and any variable
+// may be set in the file given by CARTESI_ENV_FILE.
+// The chain presets fill in the blockchain and contracts variables that are not set.
 package config
 
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
 )
 
 // NodeConfig contains all the Node variables.
@@ -22,6 +29,15 @@ type NodeConfig struct {
 	BlockchainIsLegacy                       bool
 	BlockchainFinalityOffset                 int
 	BlockchainBlockTimeout                   int
+	RpcGatewayEnabled                        bool
+	RpcGatewayFallbackHttpEndpoints          Redacted[[]string]
+	RpcGatewayHealthcheckInterval            Duration
+	RpcGatewayRequestTimeout                 Duration
+	RpcGatewayRateLimits                     map[string]float64
+	RpcGatewayCacheSize                      int
+	WatchtowerEnabled                        bool
+	WatchtowerGracePeriod                    uint64
+	WatchtowerPollingInterval                Duration
 	ContractsApplicationAddress              string
 	ContractsHistoryAddress                  string
 	ContractsAuthorityAddress                string
@@ -61,6 +77,21 @@ type AuthAWS struct {
 	Region Redacted[string]
 }
 
+// AuthKeystore allows signing through an encrypted keystore file, which is decrypted by the
+// authority claimer so the private key never leaves it.
+// The password is either in the password file or given directly.
+type AuthKeystore struct {
+	Path         string
+	PasswordFile string
+	Password     Redacted[string]
+}
+
+// AuthRemote allows signing through an external JSON-RPC signer.
+type AuthRemote struct {
+	URL     Redacted[string]
+	Account string
+}
+
 // Redacted is a wrapper that redacts a given field from the logs.
 type Redacted[T any] struct {
 	Value T
@@ -71,26 +102,37 @@ func (r Redacted[T]) String() string {
 }
 
 // FromEnv loads the config from environment variables.
+// It panics if a variable is missing or invalid.
 func FromEnv() NodeConfig {
+	loadEnvFile()
 	var config NodeConfig
 	config.LogLevel = getLogLevel()
 	config.LogPretty = getLogPretty()
 	config.RollupsEpochLength = getEpochLength()
-	config.BlockchainID = getBlockchainId()
-	config.BlockchainHttpEndpoint = Redacted[string]{getBlockchainHttpEndpoint()}
-	config.BlockchainWsEndpoint = Redacted[string]{getBlockchainWsEndpoint()}
-	config.BlockchainIsLegacy = getBlockchainIsLegacy()
-	config.BlockchainFinalityOffset = getBlockchainFinalityOffset()
+	config.BlockchainHttpEndpoint = getBlockchainHttpEndpoint()
+	config.BlockchainWsEndpoint = getBlockchainWsEndpoint()
 	config.BlockchainBlockTimeout = getBlockchainBlockTimeout()
+	config.RpcGatewayEnabled = getRpcGatewayEnabled()
+	if config.RpcGatewayEnabled {
+		config.RpcGatewayFallbackHttpEndpoints = fallbackEndpointsFromEnv()
+		config.RpcGatewayHealthcheckInterval = getRpcGatewayHealthcheckInterval()
+		config.RpcGatewayRequestTimeout = getRpcGatewayRequestTimeout()
+		config.RpcGatewayRateLimits = rateLimitsFromEnv()
+		config.RpcGatewayCacheSize = getRpcGatewayCacheSize()
+	}
+	config.WatchtowerEnabled = getWatchtowerEnabled()
+	if config.WatchtowerEnabled {
+		config.WatchtowerGracePeriod = getWatchtowerGracePeriod()
+		config.WatchtowerPollingInterval = getWatchtowerPollingInterval()
+	}
 	config.ContractsApplicationAddress = getContractsApplicationAddress()
 	config.ContractsHistoryAddress = getContractsHistoryAddress()
 	config.ContractsAuthorityAddress = getContractsAuthorityAddress()
-	config.ContractsInputBoxAddress = getContractsInputBoxAddress()
-	config.ContractsInputBoxDeploymentBlockNumber = getContractsInputBoxDeploymentBlockNumber()
+	presetVarsFromEnv(&config)
 	if !getFeatureHostMode() {
 		config.SnapshotDir = getSnapshotDir()
 	}
-	config.PostgresEndpoint = Redacted[string]{getPostgresEndpoint()}
+	config.PostgresEndpoint = getPostgresEndpoint()
 	config.HttpAddress = getHttpAddress()
 	config.HttpPort = getHttpPort()
 	config.FeatureHostMode = getFeatureHostMode()
@@ -101,7 +143,7 @@ func FromEnv() NodeConfig {
 	config.ExperimentalSunodoValidatorEnabled = getExperimentalSunodoValidatorEnabled()
 	if config.ExperimentalSunodoValidatorEnabled {
 		config.ExperimentalSunodoValidatorRedisEndpoint =
-			Redacted[string]{getExperimentalSunodoValidatorRedisEndpoint()}
+			getExperimentalSunodoValidatorRedisEndpoint()
 	}
 	// Authentication is only available when the claimer is enabled
 	if !config.FeatureDisableClaimer {
@@ -110,42 +152,116 @@ func FromEnv() NodeConfig {
 	return config
 }
 
-func authFromEnv() Auth {
-	switch getAuthKind() {
-	case AuthKindPrivateKeyVar:
-		return AuthPrivateKey{
-			PrivateKey: Redacted[string]{getAuthPrivateKey()},
+// TryFromEnv loads the config from environment variables.
+// Unlike FromEnv, it returns an error if a variable is missing or invalid.
+func TryFromEnv() (config NodeConfig, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
 		}
-	case AuthKindPrivateKeyFile:
-		path := getAuthPrivateKeyFile()
-		privateKey, err := os.ReadFile(path)
-		if err != nil {
-			panic(fmt.Sprintf("failed to read private-key file: %v", err))
+	}()
+	return FromEnv(), nil
+}
+
+// Diff returns the names of the NodeConfig fields that differ between the two configs.
+func Diff(old NodeConfig, new NodeConfig) []string {
+	var fields []string
+	oldValue := reflect.ValueOf(old)
+	newValue := reflect.ValueOf(new)
+	for i := 0; i < oldValue.NumField(); i++ {
+		if !reflect.DeepEqual(oldValue.Field(i).Interface(), newValue.Field(i).Interface()) {
+			fields = append(fields, oldValue.Type().Field(i).Name)
 		}
-		return AuthPrivateKey{
-			PrivateKey: Redacted[string]{string(privateKey)},
+	}
+	return fields
+}
+
+// Splits the comma-separated list of fallback endpoints and checks each one is an HTTP URL.
+func fallbackEndpointsFromEnv() Redacted[[]string] {
+	var endpoints []string
+	for _, endpoint := range strings.Split(getRpcGatewayFallbackHttpEndpoints().Value, ",") {
+		endpoint = strings.TrimSpace(endpoint)
+		if endpoint == "" {
+			continue
 		}
-	case AuthKindMnemonicVar:
-		return AuthMnemonic{
-			Mnemonic:     Redacted[string]{getAuthMnemonic()},
-			AccountIndex: Redacted[int]{getAuthMnemonicAccountIndex()},
+		if err := checkURL(endpoint, "http", "https"); err != nil {
+			panic(fmt.Sprintf("invalid CARTESI_RPC_GATEWAY_FALLBACK_HTTP_ENDPOINTS: %v", err))
 		}
-	case AuthKindMnemonicFile:
-		path := getAuthMnemonicFile()
-		mnemonic, err := os.ReadFile(path)
-		if err != nil {
-			panic(fmt.Sprintf("failed to read mnemonic file: %v", err))
+		endpoints = append(endpoints, endpoint)
+	}
+	return Redacted[[]string]{endpoints}
+}
+
+// Parses the comma-separated list of method=limit pairs.
+// The format is already checked by the getter.
+func rateLimitsFromEnv() map[string]float64 {
+	limits := make(map[string]float64)
+	s := getRpcGatewayRateLimits()
+	if s == "" {
+		return limits
+	}
+	for _, pair := range strings.Split(s, ",") {
+		method, value, _ := strings.Cut(pair, "=")
+		limit, err := strconv.ParseFloat(value, 64)
+		if err != nil || limit <= 0 {
+			panic(fmt.Sprintf("invalid CARTESI_RPC_GATEWAY_RATE_LIMITS '%v': "+
+				"expected a positive limit for %v", s, method))
+		}
+		limits[method] = limit
+	}
+	return limits
+}
+
+func authFromEnv() Auth {
+	switch getAuthKind() {
+	case AuthKindPrivateKeyVar, AuthKindPrivateKeyFile:
+		return AuthPrivateKey{
+			PrivateKey: getAuthPrivateKey(),
 		}
+	case AuthKindMnemonicVar, AuthKindMnemonicFile:
 		return AuthMnemonic{
-			Mnemonic:     Redacted[string]{string(mnemonic)},
-			AccountIndex: Redacted[int]{getAuthMnemonicAccountIndex()},
+			Mnemonic:     getAuthMnemonic(),
+			AccountIndex: getAuthMnemonicAccountIndex(),
 		}
 	case AuthKindAWS:
 		return AuthAWS{
-			KeyID:  Redacted[string]{getAuthAwsKmsKeyId()},
-			Region: Redacted[string]{getAuthAwsKmsRegion()},
+			KeyID:  getAuthAwsKmsKeyId(),
+			Region: getAuthAwsKmsRegion(),
+		}
+	case AuthKindKeystore:
+		return keystoreFromEnv()
+	case AuthKindRemote:
+		return AuthRemote{
+			URL:     getAuthRemoteSignerUrl(),
+			Account: getAuthRemoteSignerAccount(),
 		}
 	default:
 		panic("invalid auth kind")
 	}
 }
+
+// Loads the keystore auth. The paths are made absolute because the claimer runs in the work
+// directory, and the password file is passed on rather than read, so the claimer reads it with
+// the same rule as the CLI; see ethutil.ReadPasswordFile.
+func keystoreFromEnv() AuthKeystore {
+	path, err := filepath.Abs(getAuthKeystorePath())
+	if err == nil {
+		_, err = os.Stat(path)
+	}
+	if err != nil {
+		panic(fmt.Sprintf("invalid CARTESI_AUTH_KEYSTORE_PATH: %v", err))
+	}
+	auth := AuthKeystore{Path: path}
+	if passwordFile, ok := lookupSecretFile("CARTESI_AUTH_KEYSTORE_PASSWORD"); ok {
+		auth.PasswordFile, err = filepath.Abs(passwordFile)
+		if err == nil {
+			err = checkSecretFile(auth.PasswordFile)
+		}
+		if err != nil {
+			panic(fmt.Sprintf("invalid CARTESI_AUTH_KEYSTORE_PASSWORD_FILE: %v", err))
+		}
+	} else {
+		auth.Password = getAuthKeystorePassword()
+	}
+	return auth
+}
@@ -4,9 +4,16 @@
 package config
 
 import (
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
 	"os"
+	"path/filepath"
 	"testing"
 
+	"github.com/spf13/pflag"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -19,10 +26,10 @@ func (s *ConfigTestSuite) SetupSuite() {
 	os.Setenv("CARTESI_BLOCKCHAIN_ID", "31337")
 	os.Setenv("CARTESI_BLOCKCHAIN_HTTP_ENDPOINT", "http://localhost:8545")
 	os.Setenv("CARTESI_BLOCKCHAIN_WS_ENDPOINT", "ws://localhost:8545")
-	os.Setenv("CARTESI_CONTRACTS_APPLICATION_ADDRESS", "0x")
-	os.Setenv("CARTESI_CONTRACTS_HISTORY_ADDRESS", "0x")
-	os.Setenv("CARTESI_CONTRACTS_AUTHORITY_ADDRESS", "0x")
-	os.Setenv("CARTESI_CONTRACTS_INPUT_BOX_ADDRESS", "0x")
+	os.Setenv("CARTESI_CONTRACTS_APPLICATION_ADDRESS", "0x7C54E3f7A8070a54223469965A871fB8f6f88c22")
+	os.Setenv("CARTESI_CONTRACTS_HISTORY_ADDRESS", "0x325272217ae6815b494bF38cED004c5Eb8a7CdA7")
+	os.Setenv("CARTESI_CONTRACTS_AUTHORITY_ADDRESS", "0x58c93F83fb3304730C95aad2E360cdb88b782010")
+	os.Setenv("CARTESI_CONTRACTS_INPUT_BOX_ADDRESS", "0x59b22D57D4f067708AB0c00552767405926dc768")
 	os.Setenv("CARTESI_CONTRACTS_INPUT_BOX_DEPLOYMENT_BLOCK_NUMBER", "0")
 	os.Setenv("CARTESI_SNAPSHOT_DIR", "/tmp")
 }
@@ -43,6 +50,260 @@ func (s *ConfigTestSuite) TestExperimentalSunodoValidatorRedisEndpointIsRedacted
 	assert.Equal(s.T(), "[REDACTED]", c.ExperimentalSunodoValidatorRedisEndpoint.String())
 }
 
+func (s *ConfigTestSuite) TestSecretIsLoadedFromFile() {
+	path := s.writeFile("  postgres://user:p@ssw0rd@hostname:5432/db\n", 0600)
+	s.T().Setenv("CARTESI_POSTGRES_ENDPOINT_FILE", path)
+	c := FromEnv()
+	assert.Equal(s.T(), "postgres://user:p@ssw0rd@hostname:5432/db", c.PostgresEndpoint.Value)
+	assert.Equal(s.T(), "[REDACTED]", c.PostgresEndpoint.String())
+}
+
+func (s *ConfigTestSuite) TestSecretFileWritableByOthersIsRejected() {
+	path := s.writeFile("postgres://user:p@ssw0rd@hostname:5432/db", 0666)
+	s.T().Setenv("CARTESI_POSTGRES_ENDPOINT_FILE", path)
+	assert.Panics(s.T(), func() { FromEnv() })
+}
+
+func (s *ConfigTestSuite) TestSecretAndSecretFileCannotBothBeSet() {
+	path := s.writeFile("postgres://user:p@ssw0rd@hostname:5432/db", 0600)
+	s.T().Setenv("CARTESI_POSTGRES_ENDPOINT_FILE", path)
+	s.T().Setenv("CARTESI_POSTGRES_ENDPOINT", "postgres://hostname:5432/db")
+	assert.Panics(s.T(), func() { FromEnv() })
+}
+
+func (s *ConfigTestSuite) TestEnvFileTakesPrecedenceOverEnvironment() {
+	path := s.writeFile(`
+# comment
+CARTESI_LOG_LEVEL=debug
+export CARTESI_HTTP_PORT="20000"
+`, 0600)
+	s.T().Setenv("CARTESI_ENV_FILE", path)
+	s.T().Setenv("CARTESI_LOG_LEVEL", "error")
+	c := FromEnv()
+	assert.Equal(s.T(), slog.LevelDebug, c.LogLevel)
+	assert.Equal(s.T(), 20000, c.HttpPort)
+}
+
+func (s *ConfigTestSuite) TestFlagsTakePrecedenceOverEnvironment() {
+	defer clear(flagVars)
+	s.T().Setenv("CARTESI_HTTP_PORT", "20000")
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	AddFlags(flags)
+	s.Require().Nil(flags.Parse([]string{"--http-port=30000", "--feature-host-mode"}))
+	c := FromEnv()
+	assert.Equal(s.T(), 30000, c.HttpPort)
+	assert.True(s.T(), c.FeatureHostMode)
+}
+
+func (s *ConfigTestSuite) TestSecretsOnlyHaveFileFlags() {
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	AddFlags(flags)
+	s.Nil(flags.Lookup("auth-private-key"))
+	s.Nil(flags.Lookup("postgres-endpoint"))
+	s.NotNil(flags.Lookup("auth-private-key-file"))
+	s.NotNil(flags.Lookup("postgres-endpoint-file"))
+}
+
+func (s *ConfigTestSuite) TestSecretFileFlagTakesPrecedenceOverEnvironment() {
+	defer clear(flagVars)
+	s.T().Setenv("CARTESI_POSTGRES_ENDPOINT", "postgres://env")
+	s.T().Setenv("CARTESI_POSTGRES_ENDPOINT_FILE", s.writeFile("postgres://env-file", 0600))
+	flags := pflag.NewFlagSet("test", pflag.ContinueOnError)
+	AddFlags(flags)
+	path := s.writeFile("postgres://flag\n", 0600)
+	s.Require().Nil(flags.Parse([]string{"--postgres-endpoint-file=" + path}))
+	c := FromEnv()
+	s.Equal("postgres://flag", c.PostgresEndpoint.Value)
+}
+
+func (s *ConfigTestSuite) TestKeystoreIsPassedWithThePasswordFile() {
+	keystorePath := s.writeFile("{}", 0600)
+	passwordPath := s.writeFile("p@ssw0rd\n", 0600)
+	s.T().Setenv("CARTESI_FEATURE_DISABLE_CLAIMER", "false")
+	s.T().Setenv("CARTESI_AUTH_KIND", "keystore")
+	s.T().Setenv("CARTESI_AUTH_KEYSTORE_PATH", keystorePath)
+	s.T().Setenv("CARTESI_AUTH_KEYSTORE_PASSWORD_FILE", passwordPath)
+	c := FromEnv()
+	s.Equal(AuthKeystore{Path: keystorePath, PasswordFile: passwordPath}, c.Auth)
+}
+
+func (s *ConfigTestSuite) TestKeystoreIsPassedWithThePassword() {
+	keystorePath := s.writeFile("{}", 0600)
+	s.T().Setenv("CARTESI_FEATURE_DISABLE_CLAIMER", "false")
+	s.T().Setenv("CARTESI_AUTH_KIND", "keystore")
+	s.T().Setenv("CARTESI_AUTH_KEYSTORE_PATH", keystorePath)
+	s.T().Setenv("CARTESI_AUTH_KEYSTORE_PASSWORD", " p@ssw0rd ")
+	c := FromEnv()
+	s.Equal(AuthKeystore{Path: keystorePath, Password: Redacted[string]{" p@ssw0rd "}}, c.Auth)
+}
+
+func (s *ConfigTestSuite) TestKeystorePasswordFileMustBeProtected() {
+	s.T().Setenv("CARTESI_FEATURE_DISABLE_CLAIMER", "false")
+	s.T().Setenv("CARTESI_AUTH_KIND", "keystore")
+	s.T().Setenv("CARTESI_AUTH_KEYSTORE_PATH", s.writeFile("{}", 0600))
+	s.T().Setenv("CARTESI_AUTH_KEYSTORE_PASSWORD_FILE", s.writeFile("p@ssw0rd", 0666))
+	s.Panics(func() { FromEnv() })
+}
+
+func (s *ConfigTestSuite) TestRemoteSignerIsLoaded() {
+	s.T().Setenv("CARTESI_FEATURE_DISABLE_CLAIMER", "false")
+	s.T().Setenv("CARTESI_AUTH_KIND", "remote")
+	s.T().Setenv("CARTESI_AUTH_REMOTE_SIGNER_URL", "http://localhost:8550")
+	s.T().Setenv("CARTESI_AUTH_REMOTE_SIGNER_ACCOUNT",
+		"0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	c := FromEnv()
+	s.Equal(AuthRemote{
+		URL:     Redacted[string]{"http://localhost:8550"},
+		Account: "0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266",
+	}, c.Auth)
+}
+
+func (s *ConfigTestSuite) TestRemoteSignerAccountIsOptional() {
+	s.T().Setenv("CARTESI_FEATURE_DISABLE_CLAIMER", "false")
+	s.T().Setenv("CARTESI_AUTH_KIND", "remote")
+	s.T().Setenv("CARTESI_AUTH_REMOTE_SIGNER_URL", "unix:///run/clef/clef.ipc")
+	s.unsetenv("CARTESI_AUTH_REMOTE_SIGNER_ACCOUNT")
+	c := FromEnv()
+	s.Equal(AuthRemote{URL: Redacted[string]{"unix:///run/clef/clef.ipc"}}, c.Auth)
+}
+
+func (s *ConfigTestSuite) TestTryFromEnvReturnsError() {
+	s.T().Setenv("CARTESI_HTTP_PORT", "not a port")
+	_, err := TryFromEnv()
+	assert.ErrorContains(s.T(), err, "CARTESI_HTTP_PORT")
+}
+
+func (s *ConfigTestSuite) TestConstraintsAreChecked() {
+	s.T().Setenv("CARTESI_HTTP_PORT", "70000")
+	s.PanicsWithValue(
+		"invalid CARTESI_HTTP_PORT '70000': expected a value less than or equal to 65515",
+		func() { FromEnv() },
+	)
+}
+
+func (s *ConfigTestSuite) TestParseErrorsAreClear() {
+	s.T().Setenv("CARTESI_EPOCH_LENGTH", "1d")
+	s.PanicsWithValue(
+		"invalid CARTESI_EPOCH_LENGTH '1d': expected a non-negative integer",
+		func() { FromEnv() },
+	)
+}
+
+func (s *ConfigTestSuite) TestSecretValuesAreNotPrinted() {
+	s.T().Setenv("CARTESI_BLOCKCHAIN_HTTP_ENDPOINT", "ftp://user:p@ssw0rd@hostname")
+	s.PanicsWithValue(
+		"invalid CARTESI_BLOCKCHAIN_HTTP_ENDPOINT: "+
+			"expected a URL with one of the schemes http, https",
+		func() { FromEnv() },
+	)
+}
+
+func (s *ConfigTestSuite) TestDeprecatedAliasIsUsedWhenVariableIsNotSet() {
+	s.T().Setenv("CARTESI_OLD_NAME", "old")
+	value, ok, err := lookupVar("CARTESI_NEW_NAME", false, "CARTESI_OLD_NAME")
+	s.Require().Nil(err)
+	s.True(ok)
+	s.Equal("old", value)
+
+	s.T().Setenv("CARTESI_NEW_NAME", "new")
+	value, ok, err = lookupVar("CARTESI_NEW_NAME", false, "CARTESI_OLD_NAME")
+	s.Require().Nil(err)
+	s.True(ok)
+	s.Equal("new", value)
+}
+
+func (s *ConfigTestSuite) TestDiffReturnsChangedFields() {
+	old := FromEnv()
+	new := old
+	new.LogLevel = slog.LevelError
+	new.PostgresEndpoint = Redacted[string]{"postgres://hostname:5432/db"}
+	assert.Equal(s.T(), []string{"LogLevel", "PostgresEndpoint"}, Diff(old, new))
+	assert.Empty(s.T(), Diff(old, old))
+}
+
+func (s *ConfigTestSuite) TestPresetFillsInVariablesThatAreNotSet() {
+	s.unsetenv("CARTESI_BLOCKCHAIN_ID")
+	s.unsetenv("CARTESI_CONTRACTS_INPUT_BOX_ADDRESS")
+	s.unsetenv("CARTESI_CONTRACTS_INPUT_BOX_DEPLOYMENT_BLOCK_NUMBER")
+	s.T().Setenv("CARTESI_BLOCKCHAIN_PRESET", "sepolia")
+	s.T().Setenv("CARTESI_BLOCKCHAIN_FINALITY_OFFSET", "5")
+	c := FromEnv()
+	s.Equal(uint64(11155111), c.BlockchainID)
+	s.Equal("0x59b22D57D4f067708AB0c00552767405926dc768", c.ContractsInputBoxAddress)
+	s.Equal(int64(3963384), c.ContractsInputBoxDeploymentBlockNumber)
+	s.False(c.BlockchainIsLegacy)
+	s.Equal(5, c.BlockchainFinalityOffset)
+}
+
+func (s *ConfigTestSuite) TestPresetIsSelectedByChainID() {
+	s.unsetenv("CARTESI_CONTRACTS_INPUT_BOX_DEPLOYMENT_BLOCK_NUMBER")
+	s.unsetenv("CARTESI_BLOCKCHAIN_FINALITY_OFFSET")
+	c := FromEnv()
+	s.Equal(int64(20), c.ContractsInputBoxDeploymentBlockNumber)
+	s.Equal(1, c.BlockchainFinalityOffset)
+}
+
+func (s *ConfigTestSuite) TestPresetMustMatchChainID() {
+	s.T().Setenv("CARTESI_BLOCKCHAIN_PRESET", "sepolia")
+	s.PanicsWithValue(
+		"CARTESI_BLOCKCHAIN_ID 31337 does not match the sepolia preset (11155111)",
+		func() { FromEnv() },
+	)
+}
+
+func (s *ConfigTestSuite) TestDeploymentBlockIsRequiredWhenPresetHasNone() {
+	s.T().Setenv("CARTESI_BLOCKCHAIN_ID", "42161")
+	s.unsetenv("CARTESI_CONTRACTS_INPUT_BOX_ADDRESS")
+	s.unsetenv("CARTESI_CONTRACTS_INPUT_BOX_DEPLOYMENT_BLOCK_NUMBER")
+	s.PanicsWithValue("missing env var CARTESI_CONTRACTS_INPUT_BOX_DEPLOYMENT_BLOCK_NUMBER",
+		func() { FromEnv() })
+
+	s.T().Setenv("CARTESI_CONTRACTS_INPUT_BOX_DEPLOYMENT_BLOCK_NUMBER", "1234")
+	c := FromEnv()
+	s.Equal("0x59b22D57D4f067708AB0c00552767405926dc768", c.ContractsInputBoxAddress)
+	s.Equal(int64(1234), c.ContractsInputBoxDeploymentBlockNumber)
+	s.Equal(10, c.BlockchainFinalityOffset)
+}
+
+func (s *ConfigTestSuite) TestVariablesAreRequiredWithoutPreset() {
+	s.T().Setenv("CARTESI_BLOCKCHAIN_ID", "12345")
+	s.unsetenv("CARTESI_CONTRACTS_INPUT_BOX_ADDRESS")
+	s.PanicsWithValue("missing env var CARTESI_CONTRACTS_INPUT_BOX_ADDRESS", func() { FromEnv() })
+}
+
+func (s *ConfigTestSuite) TestChainIDIsQueriedWhenNotSet() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var request struct {
+			ID     json.RawMessage `json:"id"`
+			Method string          `json:"method"`
+		}
+		s.Require().Nil(json.NewDecoder(r.Body).Decode(&request))
+		s.Equal("eth_chainId", request.Method)
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"jsonrpc":"2.0","id":%s,"result":"0xaa36a7"}`, request.ID)
+	}))
+	defer server.Close()
+	s.unsetenv("CARTESI_BLOCKCHAIN_ID")
+	s.T().Setenv("CARTESI_BLOCKCHAIN_HTTP_ENDPOINT", server.URL)
+	s.unsetenv("CARTESI_CONTRACTS_INPUT_BOX_DEPLOYMENT_BLOCK_NUMBER")
+	c := FromEnv()
+	s.Equal(uint64(11155111), c.BlockchainID)
+	s.Equal(int64(3963384), c.ContractsInputBoxDeploymentBlockNumber)
+}
+
+func (s *ConfigTestSuite) writeFile(content string, perm os.FileMode) string {
+	path := filepath.Join(s.T().TempDir(), "secret")
+	s.Require().Nil(os.WriteFile(path, []byte(content), perm))
+	s.Require().Nil(os.Chmod(path, perm))
+	return path
+}
+
+// Unsets the variable until the end of the test.
+func (s *ConfigTestSuite) unsetenv(name string) {
+	s.T().Setenv(name, "") // restores the variable on cleanup
+	s.Require().Nil(os.Unsetenv(name))
+}
+
 func enableSunodoValidatorMode() {
 	os.Setenv("CARTESI_EXPERIMENTAL_SUNODO_VALIDATOR_ENABLED", "true")
 	os.Setenv("CARTESI_EXPERIMENTAL_SUNODO_VALIDATOR_REDIS_ENDPOINT",
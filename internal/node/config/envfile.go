@@ -0,0 +1,113 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Variables loaded from CARTESI_ENV_FILE.
+// They take precedence over the process environment.
+var envFileVars map[string]string
+
+// Looks up the variable in the command-line flags, then in the env file, and then in the
+// process environment.
+func lookupEnv(name string) (string, bool) {
+	for _, lookup := range envSources() {
+		if value, ok := lookup(name); ok {
+			return value, true
+		}
+	}
+	return "", false
+}
+
+// Sources of the variables in order of precedence: the command-line flags, the env file, and
+// the process environment.
+func envSources() []func(string) (string, bool) {
+	return []func(string) (string, bool){
+		lookupMap(flagVars),
+		lookupMap(envFileVars),
+		os.LookupEnv,
+	}
+}
+
+// Looks up the file of the secret variable in the same order as its value, so it reports false
+// when the secret is set directly or not set at all.
+func lookupSecretFile(name string) (string, bool) {
+	for _, lookup := range envSources() {
+		if _, ok := lookup(name); ok {
+			return "", false
+		}
+		if path, ok := lookup(name + "_FILE"); ok {
+			return path, true
+		}
+	}
+	return "", false
+}
+
+func lookupMap(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		value, ok := vars[name]
+		return value, ok
+	}
+}
+
+// Loads the variables from the file given by CARTESI_ENV_FILE, if any.
+// Variables from a previously loaded file are discarded.
+func loadEnvFile() {
+	envFileVars = nil
+	path := getEnvFile()
+	if path == "" {
+		return
+	}
+	vars, err := readEnvFile(path)
+	if err != nil {
+		panic(fmt.Sprintf("failed to load CARTESI_ENV_FILE: %v", err))
+	}
+	envFileVars = vars
+}
+
+// Reads the variables from the file with one NAME=value per line.
+// Empty lines and lines starting with # are ignored.
+// Values may be surrounded by single or double quotes.
+func readEnvFile(path string) (map[string]string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	vars := make(map[string]string)
+	scanner := bufio.NewScanner(file)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		name, value, ok := strings.Cut(line, "=")
+		name = strings.TrimSpace(strings.TrimPrefix(name, "export "))
+		if !ok || name == "" {
+			return nil, fmt.Errorf("%v:%v: expected NAME=value", path, lineNumber)
+		}
+		vars[name] = unquote(strings.TrimSpace(value))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return vars, nil
+}
+
+// Removes the matching single or double quotes surrounding the value.
+func unquote(value string) string {
+	if len(value) >= 2 {
+		first, last := value[0], value[len(value)-1]
+		if first == last && (first == '"' || first == '\'') {
+			return value[1 : len(value)-1]
+		}
+	}
+	return value
+}
@@ -0,0 +1,53 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package config
+
+import (
+	"github.com/spf13/pflag"
+)
+
+// A command-line flag that sets a config variable.
+type flagDefinition struct {
+	name     string
+	env      string
+	goType   string
+	defValue string
+	usage    string
+}
+
+// Variables set through command-line flags.
+// They take precedence over the env file and the process environment.
+var flagVars = make(map[string]string)
+
+// Stores the flag value as the corresponding variable.
+// The value is parsed and checked later, along with the other variables.
+type flagValue struct {
+	definition flagDefinition
+}
+
+func (v flagValue) String() string {
+	return flagVars[v.definition.env]
+}
+
+func (v flagValue) Set(s string) error {
+	flagVars[v.definition.env] = s
+	return nil
+}
+
+func (v flagValue) Type() string {
+	return v.definition.goType
+}
+
+// AddFlags adds a flag for each config variable to the flag set.
+// For instance, CARTESI_HTTP_PORT may be set with --http-port.
+// The flags must be parsed before loading the config.
+func AddFlags(flags *pflag.FlagSet) {
+	for _, definition := range flagDefinitions {
+		flag := flags.VarPF(flagValue{definition}, definition.name, "", definition.usage)
+		flag.DefValue = definition.defValue
+		if definition.goType == "bool" {
+			flag.NoOptDefVal = "true" // allows --flag instead of --flag=true
+		}
+	}
+}
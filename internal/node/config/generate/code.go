@@ -6,6 +6,7 @@ package main
 import (
 	"bytes"
 	"os"
+	"strconv"
 	"strings"
 	"text/template"
 
@@ -17,6 +18,16 @@ import (
 
 // generateCodeFile generates a Go file with the getters for the config variables.
 func generateCodeFile(path string, env []Env) {
+	code := generateCode(env)
+	var perm os.FileMode = 0644
+	err := os.WriteFile(path, code, perm)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// generateCode generates the formatted Go code with the getters for the config variables.
+func generateCode(env []Env) []byte {
 	// Load template
 	funcMap := template.FuncMap{
 		"toFunctionName": func(env string) string {
@@ -30,6 +41,15 @@ func generateCodeFile(path string, env []Env) {
 		"toGoFunc": func(goType string) string {
 			return "to" + strings.ToUpper(goType[:1]) + goType[1:]
 		},
+		"backquote": func(s string) string {
+			return "`" + s + "`"
+		},
+		"toFlagName": toFlagName,
+		"toUsage": func(description string) string {
+			// use the first paragraph of the description in a single line
+			paragraph, _, _ := strings.Cut(description, "\n\n")
+			return strconv.Quote(strings.Join(strings.Fields(paragraph), " "))
+		},
 	}
 	tmpl := template.Must(template.New("code").Funcs(funcMap).Parse(codeTemplate))
 
@@ -45,13 +65,14 @@ func generateCodeFile(path string, env []Env) {
 	if err != nil {
 		panic(err)
 	}
+	return code
+}
 
-	// Write file
-	var perm os.FileMode = 0644
-	err = os.WriteFile(path, code, perm)
-	if err != nil {
-		panic(err)
-	}
+// Converts the variable name to the flag name by removing the prefix and using kebab case.
+// For instance, CARTESI_HTTP_PORT becomes http-port.
+func toFlagName(env string) string {
+	name := strings.TrimPrefix(env, "CARTESI_")
+	return strings.ReplaceAll(strings.ToLower(name), "_", "-")
 }
 
 const codeTemplate string = `// Code generated by internal/node/config/generate.
@@ -63,10 +84,15 @@ const codeTemplate string = `// Code generated by internal/node/config/generate.
 package config
 
 import (
+	"errors"
 	"fmt"
 	"log/slog"
+	"net/url"
 	"os"
+	"regexp"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -87,19 +113,52 @@ const (
 	AuthKindMnemonicVar
 	AuthKindMnemonicFile
 	AuthKindAWS
+	AuthKindKeystore
+	AuthKindRemote
 )
 
 // ------------------------------------------------------------------------------------------------
 // Parsing functions
 // ------------------------------------------------------------------------------------------------
 
+// Describes the strconv error without repeating the value, which may be a secret.
+func toNumError(err error, expected string) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return errors.New("value out of range")
+	}
+	return errors.New(expected)
+}
+
+func toBoolFromString(s string) (bool, error) {
+	value, err := strconv.ParseBool(s)
+	if err != nil {
+		return false, errors.New("expected a boolean (true or false)")
+	}
+	return value, nil
+}
+
+func toIntFromString(s string) (int, error) {
+	value, err := strconv.Atoi(s)
+	if err != nil {
+		return 0, toNumError(err, "expected an integer")
+	}
+	return value, nil
+}
+
 func toInt64FromString(s string) (int64, error) {
-	return strconv.ParseInt(s, 10, 64)
+	value, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return 0, toNumError(err, "expected an integer")
+	}
+	return value, nil
 }
 
 func toUint64FromString(s string) (uint64, error) {
-       value, err := strconv.ParseUint(s, 10, 64)
-       return value, err
+	value, err := strconv.ParseUint(s, 10, 64)
+	if err != nil {
+		return 0, toNumError(err, "expected a non-negative integer")
+	}
+	return value, nil
 }
 
 func toStringFromString(s string) (string, error) {
@@ -107,7 +166,11 @@ func toStringFromString(s string) (string, error) {
 }
 
 func toDurationFromSeconds(s string) (time.Duration, error) {
-	return time.ParseDuration(s + "s")
+	value, err := time.ParseDuration(s + "s")
+	if err != nil {
+		return 0, errors.New("expected a number of seconds")
+	}
+	return value, nil
 }
 
 func toLogLevelFromString(s string) (LogLevel, error) {
@@ -121,7 +184,7 @@ func toLogLevelFromString(s string) (LogLevel, error) {
 		return v, nil
 	} else {
 		var zeroValue LogLevel
-		return zeroValue, fmt.Errorf("invalid log level '%s'", s)
+		return zeroValue, errors.New("expected one of debug, info, warn, error")
 	}
 }
 
@@ -132,19 +195,22 @@ func toAuthKindFromString(s string) (AuthKind, error) {
 		"mnemonic":         AuthKindMnemonicVar,
 		"mnemonic_file":    AuthKindMnemonicFile,
 		"aws":              AuthKindAWS,
+		"keystore":         AuthKindKeystore,
+		"remote":           AuthKindRemote,
 	}
 	if v, ok := m[s]; ok {
 		return v, nil
 	} else {
 		var zeroValue AuthKind
-		return zeroValue, fmt.Errorf("invalid auth kind '%s'", s)
+		return zeroValue, errors.New(
+			"expected one of private_key, private_key_file, mnemonic, mnemonic_file, aws, keystore, remote")
 	}
 }
 
 // Aliases to be used by the generated functions.
 var (
-	toBool     = strconv.ParseBool
-	toInt      = strconv.Atoi
+	toBool     = toBoolFromString
+	toInt      = toIntFromString
 	toInt64    = toInt64FromString
 	toUint64   = toUint64FromString
 	toString   = toStringFromString
@@ -153,24 +219,241 @@ var (
 	toAuthKind = toAuthKindFromString
 )
 
+// ------------------------------------------------------------------------------------------------
+// Constraints
+// ------------------------------------------------------------------------------------------------
+
+var addressRegex = regexp.MustCompile("^0x[0-9a-fA-F]{40}$")
+
+func checkEnum(s string, values ...string) error {
+	if !slices.Contains(values, s) {
+		return fmt.Errorf("expected one of %v", strings.Join(values, ", "))
+	}
+	return nil
+}
+
+func checkRegex(s string, pattern string) error {
+	if !regexp.MustCompile("^(?:" + pattern + ")$").MatchString(s) {
+		return fmt.Errorf("expected a value matching %v", pattern)
+	}
+	return nil
+}
+
+func checkURL(s string, schemes ...string) error {
+	u, err := url.Parse(s)
+	// Unix socket URLs, such as unix:///run/clef/clef.ipc, have a path instead of a host
+	if err != nil || (u.Host == "" && (u.Scheme != "unix" || u.Path == "")) {
+		return errors.New("expected a URL")
+	}
+	if !slices.Contains(schemes, u.Scheme) {
+		return fmt.Errorf("expected a URL with one of the schemes %v", strings.Join(schemes, ", "))
+	}
+	return nil
+}
+
+func checkAddress(s string) error {
+	if !addressRegex.MatchString(s) {
+		return errors.New("expected an address in hex format (0x followed by 40 hex digits)")
+	}
+	return nil
+}
+
+// ------------------------------------------------------------------------------------------------
+// Secrets
+// ------------------------------------------------------------------------------------------------
+
+// Looks up the secret variable, either directly or from the file given by its _FILE variant.
+// It is an error to set both variables.
+// Each source of variables is searched in order of precedence, so a file given by a flag
+// overrides a value given by the environment. Setting both in the same source is an error.
+func lookupSecretEnv(name string, fileName string) (string, bool, error) {
+	for _, lookup := range envSources() {
+		s, ok := lookup(name)
+		path, fromFile := lookup(fileName)
+		if !fromFile {
+			if ok {
+				return s, true, nil
+			}
+			continue
+		}
+		if ok {
+			return "", false, fmt.Errorf("only one of %v and %v may be set", name, fileName)
+		}
+		s, err := readSecretFile(path)
+		if err != nil {
+			return "", false, fmt.Errorf("%v: %w", fileName, err)
+		}
+		return s, true, nil
+	}
+	return "", false, nil
+}
+
+// Reads the secret from the given file, trimming the surrounding whitespace.
+// The file must be a regular file that is not writable by the group or by others.
+func readSecretFile(path string) (string, error) {
+	if err := checkSecretFile(path); err != nil {
+		return "", err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(string(data)), nil
+}
+
+// Checks that the secret file is a regular file that is not writable by the group or by others.
+func checkSecretFile(path string) error {
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	if !info.Mode().IsRegular() {
+		return fmt.Errorf("'%v' is not a regular file", path)
+	}
+	if perm := info.Mode().Perm(); perm&0022 != 0 {
+		return fmt.Errorf("'%v' is writable by group or others (%v)", path, perm)
+	}
+	return nil
+}
+
+// ------------------------------------------------------------------------------------------------
+// Lookup
+// ------------------------------------------------------------------------------------------------
+
+// Looks up the variable or, if it is not set, its deprecated aliases.
+// Secret variables may also be loaded from files.
+func lookupVar(name string, secret bool, aliases ...string) (string, bool, error) {
+	for i, candidate := range append([]string{name}, aliases...) {
+		var s string
+		var ok bool
+		var err error
+		if secret {
+			s, ok, err = lookupSecretEnv(candidate, candidate+"_FILE")
+		} else {
+			s, ok = lookupEnv(candidate)
+		}
+		if err != nil {
+			return "", false, err
+		}
+		if ok {
+			if i > 0 {
+				slog.Warn("Config variable is deprecated", "variable", candidate, "use", name)
+			}
+			return s, true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// ------------------------------------------------------------------------------------------------
+// Flags
+// ------------------------------------------------------------------------------------------------
+
+// Command-line flags for the config variables.
+// Secret variables only have the flag of their _FILE variant, so their values are not exposed
+// in the command line of the process.
+var flagDefinitions = []flagDefinition{
+{{- range .}}
+	{{- if .Secret}}
+	{
+		name:   "{{toFlagName .FileName}}",
+		env:    "{{.FileName}}",
+		goType: "string",
+		usage:  {{toUsage (printf "File with the value of %v. %v" .Name .Description)}},
+	},
+	{{- else}}
+	{
+		name:     "{{toFlagName .Name}}",
+		env:      "{{.Name}}",
+		goType:   "{{.GoType}}",
+		{{- if .Default}}
+		defValue: "{{.Default}}",
+		{{- end}}
+		usage:    {{toUsage .Description}},
+	},
+	{{- end}}
+{{- end}}
+}
+
 // ------------------------------------------------------------------------------------------------
 // Getters
 // ------------------------------------------------------------------------------------------------
 
 {{range .}}
+{{- if .Secret}}
+func get{{toFunctionName .Name}}() Redacted[{{.GoType}}] {
+{{- else if .Preset}}
+func get{{toFunctionName .Name}}() ({{.GoType}}, bool) {
+{{- else}}
 func get{{toFunctionName .Name}}() {{.GoType}} {
-	s, ok := os.LookupEnv("{{.Name}}")
+{{- end}}
+	s, ok, err := lookupVar("{{.Name}}", {{.Secret}}{{range .DeprecatedAliases}}, "{{.}}"{{end}})
+	if err != nil {
+		panic(fmt.Sprintf("failed to load {{.Name}}: %v", err))
+	}
+	{{- if and .Preset .Default}}
+	set := ok
+	{{- end}}
 	if !ok {
 		{{- if .Default}}
 		s = "{{.Default}}"
+		{{- else if .Preset}}
+		var zeroValue {{.GoType}}
+		return zeroValue, false
+		{{- else if .Secret}}
+		panic("missing env var {{.Name}} or {{.FileName}}")
 		{{- else}}
 		panic("missing env var {{.Name}}")
 		{{- end}}
 	}
-	val, err := {{toGoFunc .GoType}}(s)
+	{{- if .Enum}}
+	err = checkEnum(s{{range .Enum}}, "{{.}}"{{end}})
+	{{- end}}
+	{{- if .Regex}}
+	if err == nil {
+		err = checkRegex(s, {{backquote .Regex}})
+	}
+	{{- end}}
+	{{- if .URLSchemes}}
+	// the defaults, which are empty for the optional URLs and addresses, aren't checked
+	if err == nil && ok {
+		err = checkURL(s{{range .URLSchemes}}, "{{.}}"{{end}})
+	}
+	{{- end}}
+	{{- if .Address}}
+	if err == nil && ok {
+		err = checkAddress(s)
+	}
+	{{- end}}
+	val, parseErr := {{toGoFunc .GoType}}(s)
+	if err == nil {
+		err = parseErr
+	}
+	{{- if .Min}}
+	if err == nil && val < {{.Min}} {
+		err = fmt.Errorf("expected a value greater than or equal to {{.Min}}")
+	}
+	{{- end}}
+	{{- if .Max}}
+	if err == nil && val > {{.Max}} {
+		err = fmt.Errorf("expected a value less than or equal to {{.Max}}")
+	}
+	{{- end}}
 	if err != nil {
-		panic(fmt.Sprintf("failed to parse {{.Name}}: %v", err))
+		{{- if .Secret}}
+		panic(fmt.Sprintf("invalid {{.Name}}: %v", err))
+		{{- else}}
+		panic(fmt.Sprintf("invalid {{.Name}} '%v': %v", s, err))
+		{{- end}}
 	}
+	{{- if .Secret}}
+	return Redacted[{{.GoType}}]{val}
+	{{- else if and .Preset .Default}}
+	return val, set
+	{{- else if .Preset}}
+	return val, true
+	{{- else}}
 	return val
+	{{- end}}
 }
 {{end}}`
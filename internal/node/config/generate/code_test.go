@@ -0,0 +1,87 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// Test that runs in the config package, against the getters generated with the aliases.
+const aliasesTest = `package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGeneratedGettersAcceptTheDeprecatedAliases(t *testing.T) {
+	t.Setenv("CARTESI_HTTP_PORT", "")
+	os.Unsetenv("CARTESI_HTTP_PORT")
+	t.Setenv("CARTESI_OLD_HTTP_PORT", "8000")
+	if port := getHttpPort(); port != 8000 {
+		t.Fatalf("expected the port of the alias, got %v", port)
+	}
+	t.Setenv("CARTESI_HTTP_PORT", "9000")
+	if port := getHttpPort(); port != 9000 {
+		t.Fatalf("expected the port of the variable, got %v", port)
+	}
+
+	path := filepath.Join(t.TempDir(), "mnemonic")
+	if err := os.WriteFile(path, []byte("test mnemonic\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("CARTESI_AUTH_MNEMONIC", "")
+	os.Unsetenv("CARTESI_AUTH_MNEMONIC")
+	t.Setenv("CARTESI_OLD_MNEMONIC_FILE", path)
+	if mnemonic := getAuthMnemonic(); mnemonic.Value != "test mnemonic" {
+		t.Fatalf("expected the mnemonic in the file of the alias, got %q", mnemonic.Value)
+	}
+}
+`
+
+func TestGeneratedGettersAcceptTheDeprecatedAliases(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the config package")
+	}
+	config := decodeTOML(readTOML("Config.toml"))
+	config["http"]["CARTESI_HTTP_PORT"].DeprecatedAliases = []string{"CARTESI_OLD_HTTP_PORT"}
+	config["auth"]["CARTESI_AUTH_MNEMONIC"].DeprecatedAliases = []string{"CARTESI_OLD_MNEMONIC"}
+	envs := sortConfig(config)
+	for _, env := range envs {
+		env.validate()
+	}
+	validateNames(envs)
+
+	// replace the generated code of the config package with an overlay
+	dir := t.TempDir()
+	pkgDir, err := filepath.Abs("..")
+	require.Nil(t, err)
+	files := map[string][]byte{
+		"generated.go":    generateCode(envs),
+		"aliases_test.go": []byte(aliasesTest),
+	}
+	replace := make(map[string]string)
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.Nil(t, os.WriteFile(path, content, 0600))
+		replace[filepath.Join(pkgDir, name)] = path
+	}
+	overlay, err := json.Marshal(map[string]any{"Replace": replace})
+	require.Nil(t, err)
+	overlayPath := filepath.Join(dir, "overlay.json")
+	require.Nil(t, os.WriteFile(overlayPath, overlay, 0600))
+
+	cmd := exec.Command("go", "test", "-count=1", "-overlay", overlayPath,
+		"-run", "^TestGeneratedGettersAcceptTheDeprecatedAliases$", ".")
+	cmd.Dir = pkgDir
+	output, err := cmd.CombinedOutput()
+	require.Nil(t, err, string(output))
+}
@@ -5,6 +5,8 @@ package main
 
 import (
 	"os"
+	"strconv"
+	"strings"
 	"text/template"
 )
 
@@ -25,6 +27,17 @@ func generateDocsFile(path string, env []Env) {
 		"quote": func(s string) string {
 			return `"` + s + `"`
 		},
+		"toFlagName": toFlagName,
+		"formatInt": func(value *int64) string {
+			return strconv.FormatInt(*value, 10)
+		},
+		"backticks": func(values []string) string {
+			quoted := make([]string, len(values))
+			for i, value := range values {
+				quoted[i] = "`" + value + "`"
+			}
+			return strings.Join(quoted, ", ")
+		},
 	}
 	tmpl := template.Must(template.New("docs").Funcs(funcMap).Parse(docsTemplate))
 
@@ -44,10 +57,29 @@ DO NOT EDIT.
 # Node Configuration
 
 The node is configurable through environment variables.
-(There is no other way to configure it.)
+These variables may also be set in the file given by {{backtick "CARTESI_ENV_FILE"}},
+or through the command-line flags listed below, which take precedence over both.
+Run {{backtick "cartesi-rollups-node --help"}} for the list of flags.
 
 This file documents the configuration options.
 
+Variables marked as secret may also be loaded from a file by setting the variable
+with the {{backtick "_FILE"}} suffix to the file path, as done by Docker and Kubernetes secrets.
+Surrounding whitespace is trimmed from the file contents, except for the keystore password,
+and the file must not be writable by group or others.
+Setting both the variable and its {{backtick "_FILE"}} variant in the same place is an error,
+but a flag still takes precedence over the environment.
+Secret variables only have the flag of their {{backtick "_FILE"}} variant, such as
+{{backtick "--auth-private-key-file"}}, so their values are not exposed in the process list.
+
+The node checks the constraints documented for each variable on startup.
+Variables that were renamed keep working through their deprecated aliases for a release cycle,
+but the node logs a warning when they are used.
+
+Variables marked as preset are filled in by the chain preset when they are not set.
+The preset is selected by {{backtick "CARTESI_BLOCKCHAIN_PRESET"}} or, if that is not set, by the
+chain ID.
+
 <!-- markdownlint-disable MD012 -->
 {{- range .}}
 
@@ -56,8 +88,40 @@ This file documents the configuration options.
 {{.Description}}
 
 * **Type:** {{backtick .GoType}}
+{{- if .Secret}}
+* **Flag:** {{toFlagName .FileName | printf "--%v" | backtick}}, with the path of the file
+{{- else}}
+* **Flag:** {{toFlagName .Name | printf "--%v" | backtick}}
+{{- end}}
 {{- if .Default}}
 * **Default:** {{.Default | quote | backtick}}
 {{- end}}
+{{- if .Secret}}
+* **Secret:** may be loaded from the file given by {{backtick .FileName}} instead
+{{- end}}
+{{- if .Min}}
+* **Minimum:** {{formatInt .Min | backtick}}
+{{- end}}
+{{- if .Max}}
+* **Maximum:** {{formatInt .Max | backtick}}
+{{- end}}
+{{- if .Enum}}
+* **Allowed values:** {{backticks .Enum}}
+{{- end}}
+{{- if .Regex}}
+* **Pattern:** {{backtick .Regex}}
+{{- end}}
+{{- if .URLSchemes}}
+* **URL schemes:** {{backticks .URLSchemes}}
+{{- end}}
+{{- if .Address}}
+* **Format:** address in hex format, starting with {{backtick "0x"}}
+{{- end}}
+{{- if .Preset}}
+* **Preset:** filled in by the chain preset when not set
+{{- end}}
+{{- if .DeprecatedAliases}}
+* **Deprecated aliases:** {{backticks .DeprecatedAliases}}
+{{- end}}
 {{- end}}
 `
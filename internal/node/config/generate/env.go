@@ -3,6 +3,12 @@
 
 package main
 
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
 // An entry in the toml's top level table representing an environment variable.
 type Env struct {
 	// Name of the environment variable.
@@ -19,8 +25,50 @@ type Env struct {
 	// A brief description of the environment variable.
 	// This field is required.
 	Description string `toml:"description"`
+
+	// Whether the variable holds a secret.
+	// Secret variables are loaded into Redacted[T] and may also be read from the file
+	// given by the variable with the _FILE suffix.
+	// This field is optional.
+	Secret bool `toml:"secret"`
+
+	// The minimum and maximum values for numeric variables.
+	// These fields are optional.
+	Min *int64 `toml:"min"`
+	Max *int64 `toml:"max"`
+
+	// A regular expression the value must fully match.
+	// This field is optional.
+	Regex string `toml:"regex"`
+
+	// The values the variable may take.
+	// This field is optional.
+	Enum []string `toml:"enum"`
+
+	// The schemes the value must use, for variables holding URLs.
+	// This field is optional.
+	URLSchemes []string `toml:"url-schemes"`
+
+	// Whether the value must be an Ethereum address in hex format, starting with 0x.
+	// This field is optional.
+	Address bool `toml:"address"`
+
+	// Old names of the variable that are still accepted, with a warning.
+	// This field is optional.
+	DeprecatedAliases []string `toml:"deprecated-aliases"`
+
+	// Whether the chain preset fills in the variable when it is not set.
+	// The getter of a preset variable also reports whether the variable was set.
+	// This field is optional.
+	Preset bool `toml:"preset"`
 }
 
+// Suffix of the variable that points to the file containing a secret.
+const secretFileSuffix = "_FILE"
+
+// Go types that accept min and max constraints.
+var numericTypes = []string{"int", "int64", "uint64"}
+
 // Validates whether the fields of the environment variables were initialized correctly
 // and sets defaults for optional fields.
 func (e *Env) validate() {
@@ -30,4 +78,62 @@ func (e *Env) validate() {
 	if e.Description == "" {
 		panic("missing description for " + e.Name)
 	}
+	if e.Secret && strings.HasSuffix(e.Name, secretFileSuffix) {
+		panic("secret variable name must not end with " + secretFileSuffix + ": " + e.Name)
+	}
+	if (e.Min != nil || e.Max != nil) && !slices.Contains(numericTypes, e.GoType) {
+		panic("min and max require a numeric go-type for " + e.Name)
+	}
+	if e.Min != nil && e.Max != nil && *e.Min > *e.Max {
+		panic("min is greater than max for " + e.Name)
+	}
+	if e.GoType == "uint64" && e.Min != nil && *e.Min < 0 {
+		panic("negative min for unsigned " + e.Name)
+	}
+	if (e.Regex != "" || e.URLSchemes != nil || e.Address) && e.GoType != "string" {
+		panic("regex, url-schemes, and address require the string go-type for " + e.Name)
+	}
+	if e.Regex != "" {
+		regex := regexp.MustCompile("^(?:" + e.Regex + ")$")
+		if e.Default != nil && !regex.MatchString(*e.Default) {
+			panic("default does not match the regex for " + e.Name)
+		}
+	}
+	if e.Preset && e.Secret {
+		panic("secret variables cannot be filled in by the preset: " + e.Name)
+	}
+	if e.Enum != nil && e.Default != nil && !slices.Contains(e.Enum, *e.Default) {
+		panic("default is not one of the enum values for " + e.Name)
+	}
+}
+
+// FileName returns the name of the variable that points to the secret file.
+func (e Env) FileName() string {
+	return e.Name + secretFileSuffix
+}
+
+// Checks whether the variable names, including the generated _FILE variants and the
+// deprecated aliases, are unique.
+func validateNames(envs []Env) {
+	names := make(map[string]string) // variable names to the env that declares them
+	declare := func(name string, env string) {
+		if other, ok := names[name]; ok {
+			panic(name + " is declared by both " + other + " and " + env)
+		}
+		names[name] = env
+	}
+	for _, env := range envs {
+		declare(env.Name, env.Name)
+	}
+	for _, env := range envs {
+		if env.Secret {
+			declare(env.FileName(), env.Name)
+		}
+		for _, alias := range env.DeprecatedAliases {
+			declare(alias, env.Name)
+			if env.Secret {
+				declare(alias+secretFileSuffix, env.Name)
+			}
+		}
+	}
 }
@@ -10,6 +10,8 @@
 // Each table entry in the toml file translates into an environment variable.
 // In Go, this becomes a map[string](map[string]Env), with the keys of the outter map being topic
 // names, and the keys of the inner map being variable names.
+//
+// Variables marked as secret also get a _FILE variant, which loads the value from a file.
 package main
 
 func main() {
@@ -19,6 +21,7 @@ func main() {
 	for _, env := range envs {
 		env.validate()
 	}
+	validateNames(envs)
 	generateDocsFile("../../../../docs/config.md", envs)
 	generateCodeFile("../generated.go", envs)
 }
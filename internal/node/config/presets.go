@@ -0,0 +1,193 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Preset contains the blockchain and contracts settings for a well-known network.
+type Preset struct {
+	Name                          string
+	ChainID                       uint64
+	InputBoxAddress               string
+	InputBoxDeploymentBlockNumber int64 // zero if not recorded
+	IsLegacy                      bool
+	FinalityOffset                int
+}
+
+// The rollups contracts are deployed deterministically, so the InputBox has the same address
+// on every supported network.
+const inputBoxAddress = "0x59b22D57D4f067708AB0c00552767405926dc768"
+
+// Presets lists the built-in chain presets.
+//
+// Only the InputBox deployment blocks recorded in build/compose-sepolia.yaml and setup_env.sh
+// are filled in. The node reads the inputs from the deployment block on, so a guessed block
+// could miss inputs; the other presets leave it as zero, and the node then requires
+// CARTESI_CONTRACTS_INPUT_BOX_DEPLOYMENT_BLOCK_NUMBER.
+var Presets = []Preset{
+	{
+		Name:            "mainnet",
+		ChainID:         1,
+		InputBoxAddress: inputBoxAddress,
+		FinalityOffset:  10,
+	},
+	{
+		Name:                          "sepolia",
+		ChainID:                       11155111,
+		InputBoxAddress:               inputBoxAddress,
+		InputBoxDeploymentBlockNumber: 3_963_384,
+		FinalityOffset:                1,
+	},
+	{
+		Name:            "arbitrum",
+		ChainID:         42161,
+		InputBoxAddress: inputBoxAddress,
+		FinalityOffset:  10,
+	},
+	{
+		Name:            "arbitrum-sepolia",
+		ChainID:         421614,
+		InputBoxAddress: inputBoxAddress,
+		FinalityOffset:  1,
+	},
+	{
+		Name:            "optimism",
+		ChainID:         10,
+		InputBoxAddress: inputBoxAddress,
+		FinalityOffset:  10,
+	},
+	{
+		Name:            "optimism-sepolia",
+		ChainID:         11155420,
+		InputBoxAddress: inputBoxAddress,
+		FinalityOffset:  1,
+	},
+	{
+		Name:            "base",
+		ChainID:         8453,
+		InputBoxAddress: inputBoxAddress,
+		FinalityOffset:  10,
+	},
+	{
+		Name:            "base-sepolia",
+		ChainID:         84532,
+		InputBoxAddress: inputBoxAddress,
+		FinalityOffset:  1,
+	},
+	{
+		Name:                          "devnet",
+		ChainID:                       31337,
+		InputBoxAddress:               inputBoxAddress,
+		InputBoxDeploymentBlockNumber: 20,
+		FinalityOffset:                1,
+	},
+}
+
+// PresetByName returns the preset with the given name.
+func PresetByName(name string) (*Preset, bool) {
+	for i := range Presets {
+		if Presets[i].Name == name {
+			return &Presets[i], true
+		}
+	}
+	return nil, false
+}
+
+// PresetByChainID returns the preset for the given chain.
+func PresetByChainID(chainID uint64) (*Preset, bool) {
+	for i := range Presets {
+		if Presets[i].ChainID == chainID {
+			return &Presets[i], true
+		}
+	}
+	return nil, false
+}
+
+// Loads the variables that may be filled in by the chain preset.
+func presetVarsFromEnv(config *NodeConfig) {
+	chainID, preset := presetFromEnv()
+	config.BlockchainID = chainID
+	if preset != nil {
+		config.BlockchainIsLegacy = preset.IsLegacy
+		config.BlockchainFinalityOffset = preset.FinalityOffset
+		config.ContractsInputBoxAddress = preset.InputBoxAddress
+		config.ContractsInputBoxDeploymentBlockNumber = preset.InputBoxDeploymentBlockNumber
+	}
+	if isLegacy, ok := getBlockchainIsLegacy(); ok || preset == nil {
+		config.BlockchainIsLegacy = isLegacy
+	}
+	if finalityOffset, ok := getBlockchainFinalityOffset(); ok || preset == nil {
+		config.BlockchainFinalityOffset = finalityOffset
+	}
+	if address, ok := getContractsInputBoxAddress(); ok {
+		config.ContractsInputBoxAddress = address
+	} else if preset == nil {
+		panic("missing env var CARTESI_CONTRACTS_INPUT_BOX_ADDRESS")
+	}
+	if blockNumber, ok := getContractsInputBoxDeploymentBlockNumber(); ok {
+		config.ContractsInputBoxDeploymentBlockNumber = blockNumber
+	} else if preset == nil || preset.InputBoxDeploymentBlockNumber == 0 {
+		panic("missing env var CARTESI_CONTRACTS_INPUT_BOX_DEPLOYMENT_BLOCK_NUMBER")
+	}
+}
+
+// Returns the chain ID and the preset, which is selected by name or, if the name is not set, by
+// the chain ID. The chain ID is queried from the blockchain when it is not set either.
+// The preset is nil when there is none for the chain.
+func presetFromEnv() (uint64, *Preset) {
+	chainID, chainIDSet := getBlockchainId()
+	if name := getBlockchainPreset(); name != "" {
+		preset, ok := PresetByName(name)
+		if !ok {
+			panic(fmt.Sprintf("invalid CARTESI_BLOCKCHAIN_PRESET '%v': expected one of %v",
+				name, strings.Join(presetNames(), ", ")))
+		}
+		if chainIDSet && chainID != preset.ChainID {
+			panic(fmt.Sprintf("CARTESI_BLOCKCHAIN_ID %v does not match the %v preset (%v)",
+				chainID, preset.Name, preset.ChainID))
+		}
+		return preset.ChainID, preset
+	}
+	if !chainIDSet {
+		timeout := time.Duration(getBlockchainBlockTimeout()) * time.Second
+		var err error
+		chainID, err = queryChainID(getBlockchainHttpEndpoint().Value, timeout)
+		if err != nil {
+			panic(fmt.Sprintf("missing env var CARTESI_BLOCKCHAIN_ID: %v", err))
+		}
+	}
+	preset, _ := PresetByChainID(chainID)
+	return chainID, preset
+}
+
+// Queries the chain ID from the blockchain with eth_chainId.
+func queryChainID(endpoint string, timeout time.Duration) (uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	client, err := ethclient.DialContext(ctx, endpoint)
+	if err != nil {
+		return 0, fmt.Errorf("create client: %v", err)
+	}
+	defer client.Close()
+	chainID, err := client.ChainID(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("get chain id: %v", err)
+	}
+	return chainID.Uint64(), nil
+}
+
+func presetNames() []string {
+	var names []string
+	for _, preset := range Presets {
+		names = append(names, preset.Name)
+	}
+	return names
+}
@@ -27,6 +27,16 @@ func newHttpServiceHandler(c config.NodeConfig) http.Handler {
 	handler.Handle("/inspect", inspectProxy)
 	handler.Handle("/inspect/", inspectProxy)
 
+	if c.RpcGatewayEnabled {
+		gatewayProxy := newReverseProxy(localhost, getPort(c, portOffsetRpcGateway))
+		handler.Handle("/rpc-gateway/metrics", http.StripPrefix("/rpc-gateway", gatewayProxy))
+	}
+
+	if c.WatchtowerEnabled {
+		watchtowerProxy := newReverseProxy(localhost, getPort(c, portOffsetWatchtower))
+		handler.Handle("/watchtower/metrics", http.StripPrefix("/watchtower", watchtowerProxy))
+	}
+
 	if c.FeatureHostMode {
 		hostProxy := newReverseProxy(c.HttpAddress, getPort(c, portOffsetHostRunnerRollups))
 		handler.Handle("/rollup/", http.StripPrefix("/rollup", hostProxy))
@@ -5,13 +5,29 @@ package node
 
 import (
 	"context"
+	"sync"
 
 	"github.com/cartesi/rollups-node/internal/node/config"
 	"github.com/cartesi/rollups-node/internal/services"
 )
 
+// Node is the top-level supervisor of the rollups node.
+// Its child services can be restarted when the configuration is reloaded.
+type Node struct {
+	services.SupervisorService
+	config   config.NodeConfig
+	workDir  string
+	children map[string]restarter
+	mutex    sync.Mutex
+}
+
+// Child service that can be replaced while the node is running.
+type restarter interface {
+	Restart(ctx context.Context, service services.Service) error
+}
+
 // Setup creates the Node top-level supervisor.
-func Setup(ctx context.Context, c config.NodeConfig, workDir string) (services.Service, error) {
+func Setup(ctx context.Context, c config.NodeConfig, workDir string) (*Node, error) {
 	// checks
 	err := validateChainId(ctx, c.BlockchainID, c.BlockchainHttpEndpoint.Value)
 	if err != nil {
@@ -30,5 +46,19 @@ func Setup(ctx context.Context, c config.NodeConfig, workDir string) (services.S
 	}
 
 	// create service
-	return newSupervisorService(c, workDir), nil
+	return newNode(c, workDir), nil
+}
+
+func newNode(c config.NodeConfig, workDir string) *Node {
+	supervisor, restartable := newSupervisorService(c, workDir)
+	children := make(map[string]restarter, len(restartable))
+	for name, service := range restartable {
+		children[name] = service
+	}
+	return &Node{
+		SupervisorService: supervisor,
+		config:            c,
+		workDir:           workDir,
+		children:          children,
+	}
 }
@@ -0,0 +1,171 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package node
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/cartesi/rollups-node/internal/node/config"
+	"github.com/cartesi/rollups-node/internal/services"
+)
+
+// Max time to restore the services that were restarted before a reload failed.
+const rollbackTimeout = 30 * time.Second
+
+const (
+	reasonApplicationState = "the node state belongs to a single application"
+	reasonServiceSet       = "it changes the set of services"
+)
+
+// Config fields that cannot be changed while the node is running, and the reason why.
+var nonReloadableFields = map[string]string{
+	"RollupsEpochLength":                     "the epochs already closed would not match",
+	"BlockchainID":                           reasonApplicationState,
+	"ContractsApplicationAddress":            reasonApplicationState,
+	"ContractsHistoryAddress":                reasonApplicationState,
+	"ContractsAuthorityAddress":              reasonApplicationState,
+	"ContractsInputBoxAddress":               reasonApplicationState,
+	"ContractsInputBoxDeploymentBlockNumber": reasonApplicationState,
+	"SnapshotDir":                            "the snapshot is only loaded on startup",
+	"HttpPort":                               "every service port is derived from it",
+	"FeatureHostMode":                        reasonServiceSet,
+	"RpcGatewayEnabled":                      reasonServiceSet,
+	"WatchtowerEnabled":                      reasonServiceSet,
+	"FeatureDisableClaimer":                  reasonServiceSet,
+	"FeatureDisableMachineHashCheck":         "the machine hash is only checked on startup",
+	"ExperimentalSunodoValidatorEnabled":     reasonServiceSet,
+}
+
+// Services that must be restarted along with the service they depend on.
+var dependentServices = map[string][]string{
+	"server-manager": {"advance-runner", "inspect-server"},
+	"host-runner":    {"advance-runner", "inspect-server"},
+	"state-server":   {"dispatcher"},
+}
+
+// Reload applies the new configuration to the running node.
+// It restarts only the child services affected by the changed fields.
+// If a field that cannot be changed while the node is running changed, it logs the reason
+// and returns an error without applying anything.
+// If a service fails to restart, the services already restarted are restarted again with the
+// current configuration, which the node keeps.
+func (n *Node) Reload(ctx context.Context, c config.NodeConfig) error {
+	n.mutex.Lock()
+	defer n.mutex.Unlock()
+
+	changed := config.Diff(n.config, c)
+	if len(changed) == 0 {
+		slog.Info("Configuration did not change")
+		return nil
+	}
+
+	var rejected []string
+	for _, field := range changed {
+		if reason, ok := nonReloadableFields[field]; ok {
+			slog.Error("Configuration field cannot be reloaded", "field", field, "reason", reason)
+			rejected = append(rejected, field)
+		}
+	}
+	if len(rejected) > 0 {
+		return fmt.Errorf("reload config: cannot change %v", strings.Join(rejected, ", "))
+	}
+
+	if c.BlockchainHttpEndpoint != n.config.BlockchainHttpEndpoint {
+		err := validateChainId(ctx, c.BlockchainID, c.BlockchainHttpEndpoint.Value)
+		if err != nil {
+			return fmt.Errorf("reload config: %w", err)
+		}
+	}
+
+	slog.Info("Reloading configuration", "changed", changed)
+	current := make(map[string]services.Service)
+	for _, service := range newServices(n.config, n.workDir) {
+		current[service.String()] = service
+	}
+	err := restartServices(ctx, n.children, servicesToRestart(n.config, c, n.workDir), current)
+	if err != nil {
+		return fmt.Errorf("reload config: %w", err)
+	}
+	n.config = c
+	return nil
+}
+
+// Restart the children with the new services in order.
+// If one fails, the ones already restarted and the failed one are restarted with the current
+// services, so they match the configuration the node keeps.
+func restartServices(
+	ctx context.Context,
+	children map[string]restarter,
+	new []services.Service,
+	current map[string]services.Service,
+) error {
+	for i, service := range new {
+		err := children[service.String()].Restart(ctx, service)
+		if err == nil {
+			continue
+		}
+		err = fmt.Errorf("restart %v: %w", service, err)
+
+		// the context may be canceled, but the rollback must still be tried
+		rollbackCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), rollbackTimeout)
+		defer cancel()
+		for _, restarted := range new[:i+1] {
+			name := restarted.String()
+			slog.Warn("Rolling back service to the current configuration", "service", name)
+			rollbackErr := children[name].Restart(rollbackCtx, current[name])
+			if rollbackErr != nil {
+				slog.Error("Failed to roll back service; it may run with the new configuration",
+					"service", name, "error", rollbackErr)
+				err = errors.Join(err, fmt.Errorf("roll back %v: %w", name, rollbackErr))
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// Returns the services that must be restarted to apply the new configuration.
+// They are returned in the order they were started, so dependencies come first.
+func servicesToRestart(
+	old config.NodeConfig,
+	new config.NodeConfig,
+	workDir string,
+) []services.Service {
+	oldServices := newServices(old, workDir)
+	newServices := newServices(new, workDir)
+	restart := make(map[string]bool)
+	for i, service := range newServices {
+		if serviceChanged(oldServices[i], service) {
+			restart[service.String()] = true
+			for _, dependent := range dependentServices[service.String()] {
+				restart[dependent] = true
+			}
+		}
+	}
+	var s []services.Service
+	for _, service := range newServices {
+		if restart[service.String()] {
+			s = append(s, service)
+		}
+	}
+	return s
+}
+
+// Reports whether the service must be restarted to apply the new configuration.
+func serviceChanged(old services.Service, new services.Service) bool {
+	switch old := old.(type) {
+	case services.HttpService:
+		// The handler only depends on the address and on fields that cannot be reloaded.
+		// It can't be compared directly because it contains functions.
+		return old.Address != new.(services.HttpService).Address
+	default:
+		return !reflect.DeepEqual(old, new)
+	}
+}
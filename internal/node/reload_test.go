@@ -0,0 +1,137 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package node
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"reflect"
+	"testing"
+
+	"github.com/cartesi/rollups-node/internal/node/config"
+	"github.com/cartesi/rollups-node/internal/services"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReloadSuite struct {
+	suite.Suite
+	config config.NodeConfig
+}
+
+func TestReload(t *testing.T) {
+	suite.Run(t, new(ReloadSuite))
+}
+
+func (s *ReloadSuite) SetupTest() {
+	s.config = config.NodeConfig{
+		LogLevel:               slog.LevelInfo,
+		BlockchainID:           31337,
+		BlockchainHttpEndpoint: config.Redacted[string]{Value: "http://localhost:8545"},
+		BlockchainWsEndpoint:   config.Redacted[string]{Value: "ws://localhost:8545"},
+		PostgresEndpoint:       config.Redacted[string]{Value: "postgres://localhost:5432"},
+		HttpAddress:            "127.0.0.1",
+		HttpPort:               10000,
+		SnapshotDir:            "/tmp/snapshot",
+		FeatureDisableClaimer:  true,
+	}
+}
+
+func (s *ReloadSuite) TestItRejectsFieldsThatCannotBeReloaded() {
+	node := newNode(s.config, "")
+	c := s.config
+	c.LogLevel = slog.LevelDebug
+	c.ContractsApplicationAddress = "0x7C54E3f7A8070a54223469965A871fB8f6f88c22"
+
+	err := node.Reload(context.Background(), c)
+	s.ErrorContains(err, "ContractsApplicationAddress")
+	s.Equal(s.config, node.config)
+}
+
+func (s *ReloadSuite) TestItDoesNothingIfConfigDidNotChange() {
+	node := newNode(s.config, "")
+	s.Nil(node.Reload(context.Background(), s.config))
+}
+
+func (s *ReloadSuite) TestItRestartsServicesThatDependOnPostgres() {
+	c := s.config
+	c.PostgresEndpoint = config.Redacted[string]{Value: "postgres://localhost:5433"}
+	s.Equal([]string{"graphql-server", "indexer"}, serviceNames(servicesToRestart(s.config, c, "")))
+}
+
+func (s *ReloadSuite) TestItRestartsDependentsOfTheStateServer() {
+	c := s.config
+	c.BlockchainWsEndpoint = config.Redacted[string]{Value: "ws://localhost:8546"}
+	s.Equal([]string{"state-server", "dispatcher"},
+		serviceNames(servicesToRestart(s.config, c, "")))
+}
+
+func (s *ReloadSuite) TestItRestartsTheHttpServiceWhenTheAddressChanges() {
+	c := s.config
+	c.HttpAddress = "0.0.0.0"
+	s.Equal([]string{"http"}, serviceNames(servicesToRestart(s.config, c, "")))
+}
+
+func (s *ReloadSuite) TestItRestartsOnlyTheRpcGatewayWhenItIsEnabled() {
+	s.config.RpcGatewayEnabled = true
+	c := s.config
+	c.BlockchainHttpEndpoint = config.Redacted[string]{Value: "http://localhost:8546"}
+	s.Equal([]string{"rpc-gateway"}, serviceNames(servicesToRestart(s.config, c, "")))
+}
+
+func (s *ReloadSuite) TestItRestartsOnlyTheWatchtowerWhenTheGracePeriodChanges() {
+	s.config.WatchtowerEnabled = true
+	c := s.config
+	c.WatchtowerGracePeriod = 100
+	s.Equal([]string{"watchtower"}, serviceNames(servicesToRestart(s.config, c, "")))
+}
+
+func (s *ReloadSuite) TestItRollsBackTheRestartedServicesWhenARestartFails() {
+	c := s.config
+	c.PostgresEndpoint = config.Redacted[string]{Value: "postgres://localhost:5433"}
+	current := make(map[string]services.Service)
+	for _, service := range newServices(s.config, "") {
+		current[service.String()] = service
+	}
+	restarts := servicesToRestart(s.config, c, "")
+	s.Require().Len(restarts, 2)
+
+	failing := restarts[1]
+	children := make(map[string]restarter)
+	fake := &fakeRestarter{fail: failing}
+	for _, service := range restarts {
+		children[service.String()] = fake
+	}
+
+	err := restartServices(context.Background(), children, restarts, current)
+	s.ErrorContains(err, "restart indexer: failed")
+	s.Equal([]services.Service{
+		restarts[0],
+		restarts[1],
+		current["graphql-server"],
+		current["indexer"],
+	}, fake.restarts)
+}
+
+// Records the restarts, failing the restart with one of the services.
+type fakeRestarter struct {
+	fail     services.Service
+	restarts []services.Service
+}
+
+func (r *fakeRestarter) Restart(_ context.Context, service services.Service) error {
+	r.restarts = append(r.restarts, service)
+	if reflect.DeepEqual(service, r.fail) {
+		return errors.New("failed")
+	}
+	return nil
+}
+
+func serviceNames(s []services.Service) []string {
+	var names []string
+	for _, service := range s {
+		names = append(names, service.String())
+	}
+	return names
+}
@@ -8,8 +8,13 @@ import (
 	"log/slog"
 	"os"
 
+	"github.com/cartesi/rollups-node/internal/gateway"
 	"github.com/cartesi/rollups-node/internal/node/config"
 	"github.com/cartesi/rollups-node/internal/services"
+	"github.com/cartesi/rollups-node/internal/watchtower"
+	"github.com/cartesi/rollups-node/pkg/addresses"
+	"github.com/cartesi/rollups-node/pkg/claims"
+	"github.com/ethereum/go-ethereum/common"
 )
 
 // We use an enum to define the ports of each service and avoid conflicts.
@@ -30,6 +35,8 @@ const (
 	portOffsetRedis
 	portOffsetServerManager
 	portOffsetStateServer
+	portOffsetRpcGateway
+	portOffsetWatchtower
 )
 
 const (
@@ -51,6 +58,15 @@ func getRedisEndpoint(c config.NodeConfig) string {
 	}
 }
 
+// Get the blockchain HTTP endpoint for the services, which is the RPC gateway if it is enabled.
+func getBlockchainHttpEndpoint(c config.NodeConfig) string {
+	if c.RpcGatewayEnabled {
+		return fmt.Sprintf("http://%v:%v", localhost, getPort(c, portOffsetRpcGateway))
+	} else {
+		return c.BlockchainHttpEndpoint.Value
+	}
+}
+
 // Create the RUST_LOG variable using the config log level.
 // If the log level is set to debug, set tracing log for the given rust module.
 func getRustLog(c config.NodeConfig, rustModule string) string {
@@ -84,7 +100,7 @@ func newAdvanceRunner(c config.NodeConfig, workDir string) services.CommandServi
 	s.Env = append(s.Env, fmt.Sprintf("DAPP_CONTRACT_ADDRESS=%v",
 		c.ContractsApplicationAddress))
 	s.Env = append(s.Env, fmt.Sprintf("PROVIDER_HTTP_ENDPOINT=%v",
-		c.BlockchainHttpEndpoint.Value))
+		getBlockchainHttpEndpoint(c)))
 	s.Env = append(s.Env, fmt.Sprintf("ADVANCE_RUNNER_HEALTHCHECK_PORT=%v",
 		getPort(c, portOffsetAdvanceRunner)))
 	s.Env = append(s.Env, fmt.Sprintf("READER_MODE=%v", c.FeatureReaderModeEnabled))
@@ -108,7 +124,7 @@ func newAuthorityClaimer(c config.NodeConfig, workDir string) services.CommandSe
 	s.Env = append(s.Env, "LOG_ENABLE_COLOR=false")
 	s.Env = append(s.Env, getRustLog(c, "authority_claimer"))
 	s.Env = append(s.Env, fmt.Sprintf("TX_PROVIDER_HTTP_ENDPOINT=%v",
-		c.BlockchainHttpEndpoint.Value))
+		getBlockchainHttpEndpoint(c)))
 	s.Env = append(s.Env, fmt.Sprintf("TX_CHAIN_ID=%v", c.BlockchainID))
 	s.Env = append(s.Env, fmt.Sprintf("TX_CHAIN_IS_LEGACY=%v", c.BlockchainIsLegacy))
 	s.Env = append(s.Env, fmt.Sprintf("TX_DEFAULT_CONFIRMATIONS=%v",
@@ -135,6 +151,20 @@ func newAuthorityClaimer(c config.NodeConfig, workDir string) services.CommandSe
 		s.Env = append(s.Env, fmt.Sprintf("TX_SIGNING_AWS_KMS_KEY_ID=%v", auth.KeyID.Value))
 		s.Env = append(s.Env, fmt.Sprintf("TX_SIGNING_AWS_KMS_REGION=%v",
 			auth.Region.Value))
+	case config.AuthKeystore:
+		s.Env = append(s.Env, fmt.Sprintf("TX_SIGNING_KEYSTORE_FILE=%v", auth.Path))
+		if auth.PasswordFile != "" {
+			s.Env = append(s.Env, fmt.Sprintf("TX_SIGNING_KEYSTORE_PASSWORD_FILE=%v",
+				auth.PasswordFile))
+		} else {
+			s.Env = append(s.Env, fmt.Sprintf("TX_SIGNING_KEYSTORE_PASSWORD=%v",
+				auth.Password.Value))
+		}
+	case config.AuthRemote:
+		s.Env = append(s.Env, fmt.Sprintf("TX_SIGNING_REMOTE_URL=%v", auth.URL.Value))
+		if auth.Account != "" {
+			s.Env = append(s.Env, fmt.Sprintf("TX_SIGNING_REMOTE_ACCOUNT=%v", auth.Account))
+		}
 	default:
 		panic("invalid auth config")
 	}
@@ -297,7 +327,7 @@ func newStateServer(c config.NodeConfig, workDir string) services.CommandService
 	s.Env = append(s.Env, fmt.Sprintf("SF_SAFETY_MARGIN=%v", c.BlockchainFinalityOffset))
 	s.Env = append(s.Env, fmt.Sprintf("BH_WS_ENDPOINT=%v", c.BlockchainWsEndpoint.Value))
 	s.Env = append(s.Env, fmt.Sprintf("BH_HTTP_ENDPOINT=%v",
-		c.BlockchainHttpEndpoint.Value))
+		getBlockchainHttpEndpoint(c)))
 	s.Env = append(s.Env, fmt.Sprintf("BLOCKCHAIN_BLOCK_TIMEOUT=%v", c.BlockchainBlockTimeout))
 	s.Env = append(s.Env, fmt.Sprintf("SS_SERVER_ADDRESS=%v:%v", localhost,
 		getPort(c, portOffsetStateServer)))
@@ -306,7 +336,8 @@ func newStateServer(c config.NodeConfig, workDir string) services.CommandService
 	return s
 }
 
-func newSupervisorService(c config.NodeConfig, workDir string) services.SupervisorService {
+// Create the child services of the node.
+func newServices(c config.NodeConfig, workDir string) []services.Service {
 	var s []services.Service
 
 	if !c.ExperimentalSunodoValidatorEnabled {
@@ -315,9 +346,12 @@ func newSupervisorService(c config.NodeConfig, workDir string) services.Supervis
 	}
 
 	// add services without dependencies
+	if c.RpcGatewayEnabled {
+		s = append(s, newRpcGateway(c))
+	}
 	s = append(s, newGraphQLServer(c, workDir))
 	s = append(s, newIndexer(c, workDir))
-	s = append(s, newStateServer(c, workDir))
+	s = append(s, newStateServer(c, workDir)) // Depends on the RPC gateway, if enabled
 
 	// start either the server manager or host runner
 	if c.FeatureHostMode {
@@ -336,13 +370,74 @@ func newSupervisorService(c config.NodeConfig, workDir string) services.Supervis
 	s = append(s, newDispatcher(c, workDir))    // Depends on the state server
 	s = append(s, newInspectServer(c, workDir)) // Depends on the server-manager/host-runner
 
+	if c.WatchtowerEnabled {
+		s = append(s, newWatchtower(c)) // Depends on the GraphQL server
+	}
+
 	s = append(s, newHttpService(c))
 
+	return s
+}
+
+// Create the supervisor with the child services wrapped so they can be restarted on reload.
+func newSupervisorService(
+	c config.NodeConfig,
+	workDir string,
+) (services.SupervisorService, map[string]*services.RestartableService) {
+	var s []services.Service
+	restartable := make(map[string]*services.RestartableService)
+	for _, service := range newServices(c, workDir) {
+		r := services.NewRestartableService(service)
+		restartable[service.String()] = r
+		s = append(s, r)
+	}
+
 	supervisor := services.SupervisorService{
 		Name:     "rollups-node",
 		Services: s,
 	}
-	return supervisor
+	return supervisor, restartable
+}
+
+func newRpcGateway(c config.NodeConfig) gateway.Service {
+	upstreams := []string{c.BlockchainHttpEndpoint.Value}
+	upstreams = append(upstreams, c.RpcGatewayFallbackHttpEndpoints.Value...)
+	return gateway.Service{
+		Name:    "rpc-gateway",
+		Address: fmt.Sprintf("%v:%v", localhost, getPort(c, portOffsetRpcGateway)),
+		Config: gateway.Config{
+			Upstreams:           upstreams,
+			HealthcheckInterval: c.RpcGatewayHealthcheckInterval,
+			RequestTimeout:      c.RpcGatewayRequestTimeout,
+			RateLimits:          c.RpcGatewayRateLimits,
+			CacheSize:           c.RpcGatewayCacheSize,
+			FinalityOffset:      uint64(c.BlockchainFinalityOffset),
+		},
+	}
+}
+
+func newWatchtower(c config.NodeConfig) watchtower.Service {
+	return watchtower.Service{
+		Name:    "watchtower",
+		Address: fmt.Sprintf("%v:%v", localhost, getPort(c, portOffsetWatchtower)),
+		Config: watchtower.Config{
+			BlockchainHttpEndpoint: getBlockchainHttpEndpoint(c),
+			GraphQLEndpoint: fmt.Sprintf("http://%v:%v/graphql",
+				localhost, getPort(c, portOffsetGraphQLServer)),
+			Book: addresses.Book{
+				CartesiDApp:      common.HexToAddress(c.ContractsApplicationAddress),
+				HistoryAddress:   common.HexToAddress(c.ContractsHistoryAddress),
+				AuthorityAddress: common.HexToAddress(c.ContractsAuthorityAddress),
+				InputBox:         common.HexToAddress(c.ContractsInputBoxAddress),
+			},
+			Epochs: claims.EpochConfig{
+				Length:       c.RollupsEpochLength,
+				GenesisBlock: uint64(c.ContractsInputBoxDeploymentBlockNumber),
+				GracePeriod:  c.WatchtowerGracePeriod,
+			},
+			PollingInterval: c.WatchtowerPollingInterval,
+		},
+	}
 }
 
 func newHttpService(c config.NodeConfig) services.HttpService {
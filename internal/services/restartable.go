@@ -0,0 +1,134 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package services
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+)
+
+// RestartableService wraps a service so it can be replaced while the supervisor is running.
+// When Restart is called, the running service is stopped and the new one is started in its
+// place. From the supervisor's point of view, the wrapper is a single service that exits
+// when the inner service exits by itself, or when it doesn't stop in time to be replaced.
+type RestartableService struct {
+	// The amount of time to wait for the running service to exit after its context is
+	// canceled by Restart. Default is 5 seconds
+	StopTimeout time.Duration
+
+	service Service
+	restart chan restartRequest
+	exited  chan struct{}
+}
+
+type restartRequest struct {
+	service Service
+
+	// Receives nil when the new service is ready or the error that prevented it.
+	result chan error
+}
+
+// Create a new restartable service that starts with the given service.
+func NewRestartableService(service Service) *RestartableService {
+	return &RestartableService{
+		service: service,
+		restart: make(chan restartRequest),
+		exited:  make(chan struct{}),
+	}
+}
+
+func (s *RestartableService) String() string {
+	return s.service.String()
+}
+
+// Restart stops the running service and starts the given one in its place.
+// It blocks until the new service is ready, it exits, or the context is canceled.
+// It fails right away if the wrapper already exited.
+func (s *RestartableService) Restart(ctx context.Context, service Service) error {
+	request := restartRequest{
+		service: service,
+		result:  make(chan error, 1),
+	}
+	select {
+	case s.restart <- request:
+	case <-s.exited:
+		return fmt.Errorf("%v is not running", s)
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+	select {
+	case err := <-request.result:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *RestartableService) Start(ctx context.Context, ready chan<- struct{}) error {
+	defer close(s.exited)
+	stopTimeout := s.StopTimeout
+	if stopTimeout <= 0 {
+		stopTimeout = DefaultServiceTimeout
+	}
+	service := s.service
+	isFirstStart := true
+	var pending chan error // result of the restart request being served
+	for {
+		serviceCtx, cancel := context.WithCancel(ctx)
+		serviceReady := make(chan struct{}, 1)
+		done := make(chan error, 1)
+		go func() {
+			done <- service.Start(serviceCtx, serviceReady)
+		}()
+
+	Wait:
+		for {
+			select {
+			case <-serviceReady:
+				if isFirstStart {
+					ready <- struct{}{}
+					isFirstStart = false
+				} else {
+					slog.Info("Service restarted", "service", service)
+				}
+				if pending != nil {
+					pending <- nil
+					pending = nil
+				}
+			case request := <-s.restart:
+				slog.Info("Restarting service", "service", service)
+				cancel()
+				if pending != nil {
+					pending <- fmt.Errorf("restart of %v was superseded", service)
+				}
+				select {
+				case <-done: // the service was stopped on purpose, so its error is ignored
+				case <-time.After(stopTimeout):
+					// starting the new service could conflict with the old one
+					err := fmt.Errorf("%v did not stop after %v", service, stopTimeout)
+					request.result <- err
+					return err
+				}
+				service = request.service
+				pending = request.result
+				break Wait
+			case err := <-done:
+				cancel()
+				if pending != nil {
+					pending <- fmt.Errorf("%v exited before being ready: %w", service, err)
+				}
+				return err
+			case <-ctx.Done():
+				err := <-done
+				cancel()
+				if pending != nil {
+					pending <- ctx.Err()
+				}
+				return err
+			}
+		}
+	}
+}
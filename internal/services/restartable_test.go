@@ -0,0 +1,161 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RestartableServiceSuite struct {
+	suite.Suite
+}
+
+func TestRestartableService(t *testing.T) {
+	suite.Run(t, new(RestartableServiceSuite))
+}
+
+func (s *RestartableServiceSuite) TestItReplacesTheRunningService() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	first := NewMockService("Mock", 0)
+	first.
+		On("Start", mock.Anything, mock.Anything).
+		Return(context.Canceled).
+		Run(waitForCancel)
+	secondStarted := make(chan struct{})
+	second := NewMockService("Mock", 0)
+	second.
+		On("Start", mock.Anything, mock.Anything).
+		Return(context.Canceled).
+		Run(func(args mock.Arguments) {
+			close(secondStarted)
+			waitForCancel(args)
+		})
+
+	service := NewRestartableService(first)
+	result := make(chan error, 1)
+	ready := make(chan struct{}, 1)
+	go func() {
+		result <- service.Start(ctx, ready)
+	}()
+
+	select {
+	case <-ready:
+	case <-time.After(DefaultServiceTimeout):
+		s.FailNow("timed out waiting for service to be ready")
+	}
+
+	s.Require().Nil(service.Restart(ctx, second))
+	select {
+	case <-secondStarted:
+	case <-time.After(DefaultServiceTimeout):
+		s.FailNow("timed out waiting for service to restart")
+	}
+
+	cancel()
+	select {
+	case err := <-result:
+		s.ErrorIs(err, context.Canceled)
+		first.AssertNumberOfCalls(s.T(), "Start", 1)
+		second.AssertNumberOfCalls(s.T(), "Start", 1)
+	case <-time.After(DefaultServiceTimeout):
+		s.FailNow("timed out waiting for service to return")
+	}
+}
+
+func (s *RestartableServiceSuite) TestItExitsWhenTheServiceFails() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockErr := errors.New("err")
+	mock1 := NewMockService("Mock", 0)
+	mock1.
+		On("Start", mock.Anything, mock.Anything).
+		Return(mockErr).
+		After(100 * time.Millisecond)
+
+	service := NewRestartableService(mock1)
+	result := make(chan error, 1)
+	ready := make(chan struct{}, 1)
+	go func() {
+		result <- service.Start(ctx, ready)
+	}()
+
+	select {
+	case err := <-result:
+		s.ErrorIs(err, mockErr)
+	case <-time.After(DefaultServiceTimeout):
+		s.FailNow("timed out waiting for service to return")
+	}
+}
+
+func (s *RestartableServiceSuite) TestItFailsToRestartAfterExiting() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockErr := errors.New("err")
+	mock1 := NewMockService("Mock", 0)
+	mock1.
+		On("Start", mock.Anything, mock.Anything).
+		Return(mockErr)
+
+	service := NewRestartableService(mock1)
+	s.ErrorIs(service.Start(ctx, make(chan struct{}, 1)), mockErr)
+
+	restarted := make(chan error, 1)
+	go func() {
+		restarted <- service.Restart(ctx, NewMockService("Mock", 0))
+	}()
+	select {
+	case err := <-restarted:
+		s.ErrorContains(err, "Mock is not running")
+	case <-time.After(DefaultServiceTimeout):
+		s.FailNow("timed out waiting for restart to fail")
+	}
+}
+
+func (s *RestartableServiceSuite) TestItExitsWhenTheServiceDoesNotStop() {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	release := make(chan struct{})
+	defer close(release)
+	stuck := NewMockService("Mock", 0)
+	stuck.
+		On("Start", mock.Anything, mock.Anything).
+		Return(nil).
+		Run(func(mock.Arguments) { <-release })
+	second := NewMockService("Mock", 0)
+
+	service := NewRestartableService(stuck)
+	service.StopTimeout = 100 * time.Millisecond
+	result := make(chan error, 1)
+	ready := make(chan struct{}, 1)
+	go func() {
+		result <- service.Start(ctx, ready)
+	}()
+	<-ready
+
+	s.ErrorContains(service.Restart(ctx, second), "Mock did not stop after 100ms")
+	select {
+	case err := <-result:
+		s.ErrorContains(err, "did not stop")
+		second.AssertNotCalled(s.T(), "Start", mock.Anything, mock.Anything)
+	case <-time.After(DefaultServiceTimeout):
+		s.FailNow("timed out waiting for service to return")
+	}
+}
+
+// Blocks the mocked Start call until its context is canceled.
+func waitForCancel(args mock.Arguments) {
+	ctx := args.Get(0).(context.Context)
+	<-ctx.Done()
+}
@@ -0,0 +1,73 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package watchtower
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const metricsNamespace = "cartesi_watchtower"
+
+// Metrics of the watchtower.
+// Each watchtower has its own registry, so it may be restarted without registering them twice.
+type metrics struct {
+	registry       *prometheus.Registry
+	checks         prometheus.Counter
+	checkErrors    prometheus.Counter
+	lastCheck      prometheus.Gauge
+	head           prometheus.Gauge
+	claims         prometheus.Gauge
+	epochs         prometheus.Gauge
+	verifiedEpochs prometheus.Gauge
+	findings       *prometheus.GaugeVec
+}
+
+func newMetrics() *metrics {
+	m := &metrics{
+		registry: prometheus.NewRegistry(),
+		checks: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "checks_total",
+			Help:      "Number of verifications of the claims.",
+		}),
+		checkErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: metricsNamespace,
+			Name:      "check_errors_total",
+			Help:      "Number of verifications that failed to read the claims or the outputs.",
+		}),
+		lastCheck: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "last_check_timestamp_seconds",
+			Help:      "Time of the last successful verification.",
+		}),
+		head: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "head_block",
+			Help:      "Latest block in the last successful verification.",
+		}),
+		claims: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "claims",
+			Help:      "Number of claims of the application.",
+		}),
+		epochs: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "epochs",
+			Help:      "Number of epochs with inputs.",
+		}),
+		verifiedEpochs: prometheus.NewGauge(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "verified_epochs",
+			Help:      "Number of epochs whose claim matches the output proofs.",
+		}),
+		findings: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: metricsNamespace,
+			Name:      "findings",
+			Help:      "Number of problems found in the claims by kind.",
+		}, []string{"kind"}),
+	}
+	m.registry.MustRegister(m.checks, m.checkErrors, m.lastCheck, m.head, m.claims, m.epochs,
+		m.verifiedEpochs, m.findings)
+	return m
+}
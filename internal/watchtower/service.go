@@ -0,0 +1,38 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package watchtower
+
+import (
+	"context"
+
+	"github.com/cartesi/rollups-node/internal/services"
+)
+
+// Service runs the watchtower along with an HTTP server for its metrics.
+type Service struct {
+	Name    string
+	Address string
+	Config  Config
+}
+
+func (s Service) String() string {
+	return s.Name
+}
+
+func (s Service) Start(ctx context.Context, ready chan<- struct{}) error {
+	watchtower, err := New(ctx, s.Config)
+	if err != nil {
+		return err
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go watchtower.Run(ctx)
+
+	server := services.HttpService{
+		Name:    s.Name,
+		Address: s.Address,
+		Handler: watchtower,
+	}
+	return server.Start(ctx, ready)
+}
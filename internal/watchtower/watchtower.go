@@ -0,0 +1,168 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+// This package implements the watchtower, which periodically verifies the claims of the
+// application against the outputs reported by the node.
+//
+// The watchtower recomputes the epoch hash of each claim from the output proofs and reports the
+// mismatching, missing, and late claims in the logs and in the metrics it exports.
+// It keeps the claims and the inputs it read between verifications, so each verification only
+// reads the new blocks and the inputs of the epochs that may still change.
+package watchtower
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/cartesi/rollups-node/pkg/addresses"
+	"github.com/cartesi/rollups-node/pkg/claims"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Config for the watchtower.
+type Config struct {
+	// Blockchain HTTP endpoint used to read the claims.
+	BlockchainHttpEndpoint string
+
+	// GraphQL endpoint of the node used to read the outputs.
+	GraphQLEndpoint string
+
+	// Addresses of the application, the History, and the Authority.
+	Book addresses.Book
+
+	// Epochs of the application.
+	Epochs claims.EpochConfig
+
+	// Interval between verifications.
+	PollingInterval time.Duration
+}
+
+// Kinds of findings exported in the metrics.
+var findingKinds = []claims.FindingKind{
+	claims.FindingMismatch,
+	claims.FindingMissing,
+	claims.FindingLate,
+	claims.FindingRange,
+}
+
+// verifier is implemented by claims.Verifier.
+type verifier interface {
+	Verify(ctx context.Context) (*claims.Report, error)
+}
+
+// Watchtower is an http.Handler that serves the verification metrics on /metrics.
+type Watchtower struct {
+	config   Config
+	verifier verifier
+	metrics  *metrics
+	handler  http.Handler
+
+	// Findings already logged, so they are logged only once.
+	logged map[claims.Finding]bool
+}
+
+// New creates the watchtower.
+func New(ctx context.Context, config Config) (*Watchtower, error) {
+	if config.PollingInterval <= 0 {
+		return nil, errors.New("the polling interval must be positive")
+	}
+	client, err := ethclient.DialContext(ctx, config.BlockchainHttpEndpoint)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to blockchain: %v", err)
+	}
+	reader, err := claims.NewReader(ctx, client, &config.Book)
+	if err != nil {
+		return nil, err
+	}
+	graphqlClient := graphql.NewClient(config.GraphQLEndpoint, nil)
+	verifier, err := claims.NewVerifier(reader, graphqlClient, config.Epochs)
+	if err != nil {
+		return nil, err
+	}
+	return newWatchtower(config, verifier), nil
+}
+
+func newWatchtower(config Config, verifier verifier) *Watchtower {
+	w := &Watchtower{
+		config:   config,
+		verifier: verifier,
+		metrics:  newMetrics(),
+		logged:   make(map[claims.Finding]bool),
+	}
+	for _, kind := range findingKinds {
+		w.metrics.findings.WithLabelValues(string(kind)).Set(0)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", promhttp.HandlerFor(w.metrics.registry, promhttp.HandlerOpts{}))
+	w.handler = mux
+	return w
+}
+
+func (w *Watchtower) ServeHTTP(rw http.ResponseWriter, r *http.Request) {
+	w.handler.ServeHTTP(rw, r)
+}
+
+// Run verifies the claims periodically until the context is canceled.
+func (w *Watchtower) Run(ctx context.Context) {
+	ticker := time.NewTicker(w.config.PollingInterval)
+	defer ticker.Stop()
+	for {
+		w.check(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Verify the claims once and update the metrics.
+func (w *Watchtower) check(ctx context.Context) {
+	w.metrics.checks.Inc()
+	report, err := w.verifier.Verify(ctx)
+	if err != nil {
+		if ctx.Err() == nil {
+			slog.Error("Watchtower failed to verify the claims", "error", err)
+			w.metrics.checkErrors.Inc()
+		}
+		return
+	}
+	w.update(report)
+}
+
+func (w *Watchtower) update(report *claims.Report) {
+	w.metrics.lastCheck.SetToCurrentTime()
+	w.metrics.head.Set(float64(report.Head))
+	w.metrics.claims.Set(float64(report.Claims))
+	w.metrics.epochs.Set(float64(len(report.Epochs)))
+	verified := 0
+	for _, epoch := range report.Epochs {
+		if epoch.Verified {
+			verified++
+		}
+	}
+	w.metrics.verifiedEpochs.Set(float64(verified))
+
+	counts := make(map[claims.FindingKind]int)
+	for _, finding := range report.Findings {
+		counts[finding.Kind]++
+		key := finding
+		key.ClaimIndex = nil
+		if !w.logged[key] {
+			w.logged[key] = true
+			slog.Warn("Watchtower found a problem in the claims", "kind", finding.Kind,
+				"epoch", finding.Epoch, "message", finding.Message)
+		}
+	}
+	for _, kind := range findingKinds {
+		w.metrics.findings.WithLabelValues(string(kind)).Set(float64(counts[kind]))
+	}
+	slog.Debug("Watchtower verified the claims", "head", report.Head, "claims", report.Claims,
+		"verified", verified, "findings", len(report.Findings))
+}
@@ -0,0 +1,91 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package watchtower
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cartesi/rollups-node/pkg/claims"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+)
+
+// Verifier that returns the given reports in order, or the error if it is set.
+type fakeVerifier struct {
+	reports []*claims.Report
+	err     error
+}
+
+func (v *fakeVerifier) Verify(ctx context.Context) (*claims.Report, error) {
+	if v.err != nil {
+		return nil, v.err
+	}
+	report := v.reports[0]
+	v.reports = v.reports[1:]
+	return report, nil
+}
+
+func TestCheckUpdatesMetrics(t *testing.T) {
+	verifier := &fakeVerifier{reports: []*claims.Report{{
+		Head:   100,
+		Claims: 2,
+		Epochs: []claims.EpochReport{{Epoch: 0, Verified: true}, {Epoch: 1}, {Epoch: 3}},
+		Findings: []claims.Finding{
+			{Kind: claims.FindingMismatch, Epoch: 1},
+			{Kind: claims.FindingMissing, Epoch: 3},
+		},
+	}, {
+		Head:   110,
+		Claims: 3,
+		Epochs: []claims.EpochReport{{Epoch: 0, Verified: true}, {Epoch: 1}, {Epoch: 3}},
+		Findings: []claims.Finding{
+			{Kind: claims.FindingMismatch, Epoch: 1},
+		},
+	}}}
+	w := newWatchtower(Config{}, verifier)
+	ctx := context.Background()
+
+	w.check(ctx)
+	require.Equal(t, 100.0, testutil.ToFloat64(w.metrics.head))
+	require.Equal(t, 2.0, testutil.ToFloat64(w.metrics.claims))
+	require.Equal(t, 3.0, testutil.ToFloat64(w.metrics.epochs))
+	require.Equal(t, 1.0, testutil.ToFloat64(w.metrics.verifiedEpochs))
+	require.Equal(t, 1.0, testutil.ToFloat64(w.metrics.findings.WithLabelValues("mismatch")))
+	require.Equal(t, 1.0, testutil.ToFloat64(w.metrics.findings.WithLabelValues("missing")))
+	require.Equal(t, 0.0, testutil.ToFloat64(w.metrics.findings.WithLabelValues("late")))
+
+	// the findings are recomputed on every check
+	w.check(ctx)
+	require.Equal(t, 3.0, testutil.ToFloat64(w.metrics.claims))
+	require.Equal(t, 0.0, testutil.ToFloat64(w.metrics.findings.WithLabelValues("missing")))
+	require.Equal(t, 2.0, testutil.ToFloat64(w.metrics.checks))
+	require.Len(t, w.logged, 2)
+
+	verifier.err = errors.New("connection refused")
+	w.check(ctx)
+	require.Equal(t, 1.0, testutil.ToFloat64(w.metrics.checkErrors))
+	require.Equal(t, 110.0, testutil.ToFloat64(w.metrics.head))
+}
+
+func TestServesMetrics(t *testing.T) {
+	w := newWatchtower(Config{}, &fakeVerifier{})
+	server := httptest.NewServer(w)
+	defer server.Close()
+
+	resp, err := server.Client().Get(server.URL + "/metrics")
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	require.Nil(t, err)
+	require.Contains(t, string(body), `cartesi_watchtower_findings{kind="mismatch"} 0`)
+}
+
+func TestNewRejectsInvalidPollingInterval(t *testing.T) {
+	_, err := New(context.Background(), Config{})
+	require.ErrorContains(t, err, "polling interval")
+}
@@ -17,8 +17,8 @@ use std::{fs, str::FromStr};
 use crate::config::{
     error::{
         AuthorityClaimerConfigError, ContractsSnafu, InvalidRegionSnafu,
-        MnemonicFileSnafu, TxManagerSnafu, TxSigningConfigError,
-        TxSigningSnafu,
+        MnemonicFileSnafu, PasswordFileSnafu, TxManagerSnafu,
+        TxSigningConfigError, TxSigningSnafu,
     },
     AuthorityClaimerConfig, ContractsConfig, TxSigningConfig,
 };
@@ -116,11 +116,11 @@ pub(crate) struct TxSigningCLIConfig {
     #[arg(long, env)]
     tx_signing_private_key_file: Option<String>,
 
-    /// Signer mnemonic, overrides `tx_signing_mnemonic_file` and `tx_signing_aws_kms_*`
+    /// Signer mnemonic, overrides `tx_signing_mnemonic_file`, `tx_signing_remote_*` and `tx_signing_aws_kms_*`
     #[arg(long, env)]
     tx_signing_mnemonic: Option<String>,
 
-    /// Signer mnemonic file path, overrides `tx_signing_aws_kms_*`
+    /// Signer mnemonic file path, overrides `tx_signing_keystore_*`, `tx_signing_remote_*` and `tx_signing_aws_kms_*`
     #[arg(long, env)]
     tx_signing_mnemonic_file: Option<String>,
 
@@ -128,6 +128,26 @@ pub(crate) struct TxSigningCLIConfig {
     #[arg(long, env)]
     tx_signing_mnemonic_account_index: Option<u32>,
 
+    /// Signer keystore file, in the go-ethereum v3 format, overrides `tx_signing_remote_*` and `tx_signing_aws_kms_*`
+    #[arg(long, env)]
+    tx_signing_keystore_file: Option<String>,
+
+    /// Keystore password, overrides `tx_signing_keystore_password_file`
+    #[arg(long, env)]
+    tx_signing_keystore_password: Option<String>,
+
+    /// Keystore password file path, of which only the first line is used, as in go-ethereum
+    #[arg(long, env)]
+    tx_signing_keystore_password_file: Option<String>,
+
+    /// URL of an external JSON-RPC signer, overrides `tx_signing_aws_kms_*`
+    #[arg(long, env)]
+    tx_signing_remote_url: Option<String>,
+
+    /// Account of the external JSON-RPC signer, defaults to its first account
+    #[arg(long, env)]
+    tx_signing_remote_account: Option<String>,
+
     /// AWS KMS signer key-id
     #[arg(long, env)]
     tx_signing_aws_kms_key_id: Option<String>,
@@ -168,6 +188,28 @@ impl TryFrom<TxSigningCLIConfig> for TxSigningConfig {
                 mnemonic: Redacted::new(mnemonic),
                 account_index,
             })
+        } else if let Some(path) = cli.tx_signing_keystore_file {
+            let password = match (
+                cli.tx_signing_keystore_password,
+                cli.tx_signing_keystore_password_file,
+            ) {
+                (Some(password), _) => password,
+                (None, Some(password_path)) => {
+                    read_password_file(password_path)?
+                }
+                (None, None) => {
+                    return Err(TxSigningConfigError::MissingKeystorePassword)
+                }
+            };
+            Ok(TxSigningConfig::Keystore {
+                path,
+                password: Redacted::new(password),
+            })
+        } else if let Some(url) = cli.tx_signing_remote_url {
+            Ok(TxSigningConfig::Remote {
+                url: Redacted::new(url),
+                account: cli.tx_signing_remote_account,
+            })
         } else {
             match (cli.tx_signing_aws_kms_key_id, cli.tx_signing_aws_kms_region)
             {
@@ -182,3 +224,35 @@ impl TryFrom<TxSigningCLIConfig> for TxSigningConfig {
         }
     }
 }
+
+/// Reads the password from the first line of the file, as go-ethereum does,
+/// so the password files work the same for the node and the CLI.
+fn read_password_file(path: String) -> Result<String, TxSigningConfigError> {
+    let contents =
+        fs::read_to_string(path.clone()).context(PasswordFileSnafu { path })?;
+    let line = contents.split('\n').next().unwrap_or_default();
+    Ok(line.trim_end_matches('\r').to_string())
+}
+
+#[cfg(test)]
+mod tests {
+    use std::fs;
+
+    use super::read_password_file;
+
+    #[test]
+    fn read_password_file_keeps_the_first_line() {
+        let path = std::env::temp_dir().join("authority-claimer-password");
+        fs::write(&path, " p@ssw0rd \r\nsecond line\n").unwrap();
+        let password =
+            read_password_file(path.to_string_lossy().to_string()).unwrap();
+        fs::remove_file(&path).unwrap();
+        assert_eq!(password, " p@ssw0rd ");
+    }
+
+    #[test]
+    fn read_password_file_fails_when_missing() {
+        let result = read_password_file("/nonexistent/password".to_string());
+        assert!(result.is_err());
+    }
+}
@@ -32,6 +32,15 @@ pub enum TxSigningConfigError {
         source: std::io::Error,
     },
 
+    #[snafu(display("Could not read password file at path `{}`", path,))]
+    PasswordFileError {
+        path: String,
+        source: std::io::Error,
+    },
+
+    #[snafu(display("Missing keystore password"))]
+    MissingKeystorePassword,
+
     #[snafu(display("Missing AWS region"))]
     MissingRegion,
 
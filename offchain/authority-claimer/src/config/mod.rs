@@ -45,10 +45,20 @@ pub enum TxSigningConfig {
         account_index: Option<u32>,
     },
 
+    Keystore {
+        path: String,
+        password: Redacted<String>,
+    },
+
     Aws {
         key_id: String,
         region: Region,
     },
+
+    Remote {
+        url: Redacted<String>,
+        account: Option<String>,
+    },
 }
 
 impl Config {
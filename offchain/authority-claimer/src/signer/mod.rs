@@ -3,6 +3,7 @@
 
 mod aws_credentials;
 mod aws_signer;
+mod remote_signer;
 mod signer;
 
 pub use signer::{ConditionalSigner, ConditionalSignerError};
@@ -0,0 +1,432 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+use async_trait::async_trait;
+use ethers::{
+    providers::{Http, Ipc, IpcError, Provider, ProviderError},
+    signers::Signer,
+    types::{
+        transaction::{
+            eip2718::{TypedTransaction, TypedTransactionError},
+            eip712::Eip712,
+        },
+        Address, Bytes, Signature, SignatureError,
+    },
+    utils::rlp::Rlp,
+};
+use serde::{de::DeserializeOwned, Deserialize, Serialize};
+use snafu::{ensure, OptionExt, ResultExt, Snafu};
+use std::fmt::Debug;
+
+/// The `RemoteSigner` delegates signing to an external JSON-RPC signer, such
+/// as Clef or Web3Signer, so the private key never enters this process.
+///
+/// It connects to the signer through HTTP or, for URLs starting with
+/// `unix://`, through a Unix socket, which is how Clef is usually run.
+/// It uses the `eth_signTransaction` method or, if the signer only has the
+/// `account` namespace like Clef, `account_signTransaction`.
+#[derive(Debug, Clone)]
+pub struct RemoteSigner {
+    provider: RemoteProvider,
+    address: Address,
+    chain_id: u64,
+    sign_method: &'static str,
+}
+
+/// Connection to the remote signer.
+#[derive(Debug, Clone)]
+enum RemoteProvider {
+    Http(Provider<Http>),
+    Ipc(Provider<Ipc>),
+}
+
+impl RemoteProvider {
+    async fn connect(url: &str) -> Result<Self, RemoteSignerError> {
+        match url.strip_prefix("unix://") {
+            Some(path) => {
+                let ipc = Ipc::connect(path).await.context(IpcSnafu)?;
+                Ok(Self::Ipc(Provider::new(ipc)))
+            }
+            None => Provider::<Http>::try_from(url)
+                .map(Self::Http)
+                .context(InvalidUrlSnafu),
+        }
+    }
+
+    async fn request<T, R>(
+        &self,
+        method: &str,
+        params: T,
+    ) -> Result<R, ProviderError>
+    where
+        T: Debug + Serialize + Send + Sync,
+        R: Serialize + DeserializeOwned + Debug + Send,
+    {
+        match self {
+            Self::Http(provider) => provider.request(method, params).await,
+            Self::Ipc(provider) => provider.request(method, params).await,
+        }
+    }
+}
+
+#[derive(Debug, Snafu)]
+pub enum RemoteSignerError {
+    #[snafu(display("Invalid remote signer URL"))]
+    InvalidUrl { source: url::ParseError },
+
+    #[snafu(display("Could not connect to remote signer socket"))]
+    Ipc { source: IpcError },
+
+    #[snafu(display("Invalid remote signer account `{}`", account))]
+    InvalidAccount { account: String },
+
+    #[snafu(display("Remote signer has no accounts"))]
+    NoAccounts,
+
+    #[snafu(display("Remote signer request failed"))]
+    Request { source: ProviderError },
+
+    #[snafu(display("Unexpected response from remote signer"))]
+    InvalidResponse { source: serde_json::Error },
+
+    #[snafu(display("Invalid transaction signed by remote signer"))]
+    InvalidTransaction { source: TypedTransactionError },
+
+    #[snafu(display("Invalid signature from remote signer"))]
+    InvalidSignature { source: SignatureError },
+
+    #[snafu(display("Signed by {:?} instead of {:?}", signer, expected))]
+    WrongSigner { signer: Address, expected: Address },
+
+    #[snafu(display("Remote signer does not sign typed data"))]
+    TypedDataUnsupported,
+}
+
+/// Web3Signer returns the raw transaction, while geth and Clef return it in
+/// an object.
+#[derive(Deserialize)]
+#[serde(untagged)]
+enum SignTransactionResponse {
+    Raw(Bytes),
+    Object { raw: Bytes },
+}
+
+impl RemoteSigner {
+    /// Uses the first account of the signer if `account` is `None`.
+    pub async fn new(
+        url: &str,
+        account: Option<String>,
+        chain_id: u64,
+    ) -> Result<Self, RemoteSignerError> {
+        let provider = RemoteProvider::connect(url).await?;
+        let address = match account {
+            Some(ref account) => Some(
+                account
+                    .parse::<Address>()
+                    .ok()
+                    .context(InvalidAccountSnafu { account })?,
+            ),
+            None => None,
+        };
+
+        // Clef only implements the account namespace
+        let (accounts, sign_method) = match provider
+            .request::<_, Vec<Address>>("eth_accounts", ())
+            .await
+        {
+            Ok(accounts) => (accounts, "eth_signTransaction"),
+            Err(err) => {
+                let accounts: Vec<Address> = provider
+                    .request("account_list", ())
+                    .await
+                    .map_err(|_| err)
+                    .context(RequestSnafu)?;
+                (accounts, "account_signTransaction")
+            }
+        };
+        let address = match address {
+            Some(address) => address,
+            None => *accounts.first().context(NoAccountsSnafu)?,
+        };
+        Ok(Self {
+            provider,
+            address,
+            chain_id,
+            sign_method,
+        })
+    }
+
+    async fn request_signed_transaction(
+        &self,
+        transaction: &TypedTransaction,
+    ) -> Result<Bytes, RemoteSignerError> {
+        let response: serde_json::Value = self
+            .provider
+            .request(self.sign_method, [transaction])
+            .await
+            .context(RequestSnafu)?;
+        let response: SignTransactionResponse =
+            serde_json::from_value(response).context(InvalidResponseSnafu)?;
+        match response {
+            SignTransactionResponse::Raw(raw) => Ok(raw),
+            SignTransactionResponse::Object { raw } => Ok(raw),
+        }
+    }
+}
+
+#[async_trait]
+impl Signer for RemoteSigner {
+    type Error = RemoteSignerError;
+
+    async fn sign_message<S: Send + Sync + AsRef<[u8]>>(
+        &self,
+        message: S,
+    ) -> Result<Signature, Self::Error> {
+        let message = Bytes::from(message.as_ref().to_vec());
+        let signature: Bytes = self
+            .provider
+            .request("eth_sign", (self.address, message))
+            .await
+            .context(RequestSnafu)?;
+        Signature::try_from(signature.as_ref()).context(InvalidSignatureSnafu)
+    }
+
+    async fn sign_transaction(
+        &self,
+        message: &TypedTransaction,
+    ) -> Result<Signature, Self::Error> {
+        let mut transaction = message.clone();
+        transaction.set_from(self.address);
+        transaction.set_chain_id(self.chain_id);
+
+        let raw = self.request_signed_transaction(&transaction).await?;
+        let rlp = Rlp::new(raw.as_ref());
+        let (_, signature) = TypedTransaction::decode_signed(&rlp)
+            .context(InvalidTransactionSnafu)?;
+
+        // Recovering the signer from our own transaction hash also ensures
+        // that the remote signer did not change the transaction.
+        let signer = signature
+            .recover(transaction.sighash())
+            .context(InvalidSignatureSnafu)?;
+        ensure!(
+            signer == self.address,
+            WrongSignerSnafu {
+                signer,
+                expected: self.address,
+            }
+        );
+        Ok(signature)
+    }
+
+    async fn sign_typed_data<T: Eip712 + Send + Sync>(
+        &self,
+        _payload: &T,
+    ) -> Result<Signature, Self::Error> {
+        TypedDataUnsupportedSnafu.fail()
+    }
+
+    fn address(&self) -> Address {
+        self.address
+    }
+
+    fn chain_id(&self) -> u64 {
+        self.chain_id
+    }
+
+    fn with_chain_id<T: Into<u64>>(self, chain_id: T) -> Self {
+        Self {
+            chain_id: chain_id.into(),
+            ..self
+        }
+    }
+}
+
+#[cfg(test)]
+mod tests {
+    use ethers::{
+        signers::{LocalWallet, Signer},
+        types::{
+            transaction::eip2718::TypedTransaction, Address,
+            Eip1559TransactionRequest,
+        },
+    };
+    use serde_json::{json, Value};
+    use std::path::PathBuf;
+    use tokio::{
+        io::{AsyncReadExt, AsyncWriteExt},
+        net::{UnixListener, UnixStream},
+    };
+
+    use super::RemoteSigner;
+
+    const CHAIN_ID: u64 = 1;
+    const PRIVATE_KEY: &str =
+        "59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d";
+
+    #[tokio::test]
+    async fn new_uses_the_first_account_of_the_signer() {
+        let (url, _socket) = serve("first-account", false);
+        let signer = RemoteSigner::new(&url, None, CHAIN_ID).await.unwrap();
+        assert_eq!(signer.address(), wallet().address());
+        assert_eq!(signer.sign_method, "eth_signTransaction");
+    }
+
+    #[tokio::test]
+    async fn new_lists_the_accounts_of_clef() {
+        let (url, _socket) = serve("clef-accounts", true);
+        let account = Address::repeat_byte(1);
+        let signer =
+            RemoteSigner::new(&url, Some(format!("{:?}", account)), CHAIN_ID)
+                .await
+                .unwrap();
+        assert_eq!(signer.address(), account);
+        assert_eq!(signer.sign_method, "account_signTransaction");
+    }
+
+    #[tokio::test]
+    async fn new_fails_with_an_invalid_account() {
+        let (url, _socket) = serve("invalid-account", false);
+        let result =
+            RemoteSigner::new(&url, Some("0x1234".to_string()), CHAIN_ID).await;
+        assert!(result.is_err());
+    }
+
+    #[tokio::test]
+    async fn sign_transaction_through_eth_namespace() {
+        let (url, _socket) = serve("eth-sign", false);
+        let signer = RemoteSigner::new(&url, None, CHAIN_ID).await.unwrap();
+        let transaction = eip1559_transaction();
+        let signature = signer.sign_transaction(&transaction).await.unwrap();
+        let expected = wallet().sign_transaction_sync(&signed(&transaction));
+        assert_eq!(signature, expected);
+    }
+
+    #[tokio::test]
+    async fn sign_transaction_through_clef() {
+        let (url, _socket) = serve("clef-sign", true);
+        let signer = RemoteSigner::new(&url, None, CHAIN_ID).await.unwrap();
+        let transaction = eip1559_transaction();
+        let signature = signer.sign_transaction(&transaction).await.unwrap();
+        let expected = wallet().sign_transaction_sync(&signed(&transaction));
+        assert_eq!(signature, expected);
+    }
+
+    // --------------------------------------------------------------------------------------------
+    // auxiliary
+    // --------------------------------------------------------------------------------------------
+
+    fn wallet() -> LocalWallet {
+        PRIVATE_KEY
+            .parse::<LocalWallet>()
+            .unwrap()
+            .with_chain_id(CHAIN_ID)
+    }
+
+    fn eip1559_transaction() -> TypedTransaction {
+        TypedTransaction::Eip1559(
+            Eip1559TransactionRequest::new()
+                .to(Address::default())
+                .gas(21000)
+                .value(1337)
+                .nonce(1)
+                .max_priority_fee_per_gas(10)
+                .max_fee_per_gas(20),
+        )
+    }
+
+    /// The transaction as the remote signer receives it.
+    fn signed(transaction: &TypedTransaction) -> TypedTransaction {
+        let mut transaction = transaction.clone();
+        transaction.set_from(wallet().address());
+        transaction.set_chain_id(CHAIN_ID);
+        transaction
+    }
+
+    /// Removes the socket file when the test ends.
+    struct Socket(PathBuf);
+
+    impl Drop for Socket {
+        fn drop(&mut self) {
+            let _ = std::fs::remove_file(&self.0);
+        }
+    }
+
+    /// Serves a signer with the wallet account through a Unix socket. If
+    /// `clef` is set, it only implements the `account` namespace, like Clef.
+    fn serve(name: &str, clef: bool) -> (String, Socket) {
+        let path = std::env::temp_dir().join(format!(
+            "authority-claimer-{}-{}.ipc",
+            name,
+            std::process::id()
+        ));
+        let _ = std::fs::remove_file(&path);
+        let listener = UnixListener::bind(&path).unwrap();
+        tokio::spawn(async move {
+            while let Ok((stream, _)) = listener.accept().await {
+                tokio::spawn(handle(stream, clef));
+            }
+        });
+        (format!("unix://{}", path.display()), Socket(path))
+    }
+
+    async fn handle(mut stream: UnixStream, clef: bool) {
+        let mut buffer = Vec::new();
+        let mut chunk = [0u8; 4096];
+        loop {
+            let n = match stream.read(&mut chunk).await {
+                Ok(0) | Err(_) => return,
+                Ok(n) => n,
+            };
+            buffer.extend_from_slice(&chunk[..n]);
+            let mut requests =
+                serde_json::Deserializer::from_slice(&buffer).into_iter();
+            let mut consumed = 0;
+            let mut responses = Vec::new();
+            while let Some(Ok(request)) = requests.next() {
+                consumed = requests.byte_offset();
+                responses.push(respond(&request, clef));
+            }
+            buffer.drain(..consumed);
+            for response in responses {
+                let data = serde_json::to_vec(&response).unwrap();
+                if stream.write_all(&data).await.is_err() {
+                    return;
+                }
+            }
+        }
+    }
+
+    fn respond(request: &Value, clef: bool) -> Value {
+        let id = request["id"].clone();
+        let method = request["method"].as_str().unwrap_or_default();
+        let namespace = if clef { "account_" } else { "eth_" };
+        if !method.starts_with(namespace) {
+            return json!({
+                "jsonrpc": "2.0",
+                "id": id,
+                "error": {
+                    "code": -32601,
+                    "message": format!("the method {} does not exist", method),
+                },
+            });
+        }
+        let result = match method {
+            "eth_accounts" | "account_list" => json!([wallet().address()]),
+            "eth_signTransaction" | "account_signTransaction" => {
+                let transaction: TypedTransaction =
+                    serde_json::from_value(request["params"][0].clone())
+                        .unwrap();
+                let signature = wallet().sign_transaction_sync(&transaction);
+                let raw = transaction.rlp_signed(&signature);
+                if clef {
+                    json!({ "raw": raw, "tx": transaction })
+                } else {
+                    json!(raw)
+                }
+            }
+            _ => Value::Null,
+        };
+        json!({ "jsonrpc": "2.0", "id": id, "result": result })
+    }
+}
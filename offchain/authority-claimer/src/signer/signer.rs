@@ -14,7 +14,13 @@ use ethers::{
 };
 use snafu::{ResultExt, Snafu};
 
-use crate::{config::TxSigningConfig, signer::aws_signer::AwsSigner};
+use crate::{
+    config::TxSigningConfig,
+    signer::{
+        aws_signer::AwsSigner,
+        remote_signer::{RemoteSigner, RemoteSignerError},
+    },
+};
 
 /// The `ConditionalSigner` is implementing conditional dispatch (instead of
 /// dynamic dispatch) by hand for objects that implement the `Sender` trait.
@@ -26,6 +32,7 @@ use crate::{config::TxSigningConfig, signer::aws_signer::AwsSigner};
 pub enum ConditionalSigner {
     LocalWallet(LocalWallet),
     AwsSigner(AwsSigner),
+    RemoteSigner(RemoteSigner),
 }
 
 #[derive(Debug, Snafu)]
@@ -35,6 +42,9 @@ pub enum ConditionalSignerError {
 
     #[snafu(display("AWS KMS signer error"))]
     AwsSigner { source: AwsSignerError },
+
+    #[snafu(display("Remote signer error"))]
+    RemoteSigner { source: RemoteSignerError },
 }
 
 impl ConditionalSigner {
@@ -67,12 +77,25 @@ impl ConditionalSigner {
                     .with_chain_id(chain_id);
                 Ok(ConditionalSigner::LocalWallet(wallet))
             }
+            TxSigningConfig::Keystore { path, password } => {
+                let wallet =
+                    LocalWallet::decrypt_keystore(path, password.inner())
+                        .context(LocalWalletSnafu)?
+                        .with_chain_id(chain_id);
+                Ok(ConditionalSigner::LocalWallet(wallet))
+            }
             TxSigningConfig::Aws { key_id, region } => {
                 AwsSigner::new(key_id, chain_id, region)
                     .await
                     .map(ConditionalSigner::AwsSigner)
                     .context(AwsSignerSnafu)
             }
+            TxSigningConfig::Remote { url, account } => {
+                RemoteSigner::new(url.inner().as_str(), account, chain_id)
+                    .await
+                    .map(ConditionalSigner::RemoteSigner)
+                    .context(RemoteSignerSnafu)
+            }
         }
     }
 }
@@ -94,6 +117,10 @@ impl Signer for ConditionalSigner {
                 .sign_message(message)
                 .await
                 .context(AwsSignerSnafu),
+            Self::RemoteSigner(remote_signer) => remote_signer
+                .sign_message(message)
+                .await
+                .context(RemoteSignerSnafu),
         }
     }
 
@@ -110,6 +137,10 @@ impl Signer for ConditionalSigner {
                 .sign_transaction(message)
                 .await
                 .context(AwsSignerSnafu),
+            Self::RemoteSigner(remote_signer) => remote_signer
+                .sign_transaction(message)
+                .await
+                .context(RemoteSignerSnafu),
         }
     }
 
@@ -126,6 +157,10 @@ impl Signer for ConditionalSigner {
                 .sign_typed_data(payload)
                 .await
                 .context(AwsSignerSnafu),
+            Self::RemoteSigner(remote_signer) => remote_signer
+                .sign_typed_data(payload)
+                .await
+                .context(RemoteSignerSnafu),
         }
     }
 
@@ -133,6 +168,7 @@ impl Signer for ConditionalSigner {
         match &self {
             Self::LocalWallet(local_wallet) => local_wallet.address(),
             Self::AwsSigner(aws_signer) => aws_signer.address(),
+            Self::RemoteSigner(remote_signer) => remote_signer.address(),
         }
     }
 
@@ -140,6 +176,7 @@ impl Signer for ConditionalSigner {
         match &self {
             Self::LocalWallet(local_wallet) => local_wallet.chain_id(),
             Self::AwsSigner(aws_signer) => aws_signer.chain_id(),
+            Self::RemoteSigner(remote_signer) => remote_signer.chain_id(),
         }
     }
 
@@ -151,15 +188,22 @@ impl Signer for ConditionalSigner {
             Self::AwsSigner(aws_signer) => {
                 Self::AwsSigner(aws_signer.clone().with_chain_id(chain_id))
             }
+            Self::RemoteSigner(remote_signer) => Self::RemoteSigner(
+                remote_signer.clone().with_chain_id(chain_id),
+            ),
         }
     }
 }
 
 #[cfg(test)]
 mod tests {
-    use ethers::types::{
-        transaction::{eip2718::TypedTransaction, eip2930::AccessList},
-        Address, Eip1559TransactionRequest,
+    use ethers::{
+        core::rand::thread_rng,
+        signers::LocalWallet,
+        types::{
+            transaction::{eip2718::TypedTransaction, eip2930::AccessList},
+            Address, Eip1559TransactionRequest,
+        },
     };
     use ethers_signers::Signer;
     use redacted::Redacted;
@@ -190,6 +234,27 @@ mod tests {
         ));
     }
 
+    #[tokio::test]
+    async fn new_local_wallet_keystore_conditional_signer() {
+        let dir = std::env::temp_dir();
+        let (wallet, name) =
+            LocalWallet::new_keystore(&dir, &mut thread_rng(), PASSWORD, None)
+                .unwrap();
+        let path = dir.join(name);
+        let tx_signing_config = TxSigningConfig::Keystore {
+            path: path.to_string_lossy().to_string(),
+            password: Redacted::new(PASSWORD.to_string()),
+        };
+        let result = ConditionalSigner::new(CHAIN_ID, &tx_signing_config).await;
+        std::fs::remove_file(path).unwrap();
+        let conditional_signer = result.unwrap();
+        assert!(matches!(
+            conditional_signer,
+            ConditionalSigner::LocalWallet(_)
+        ));
+        assert_eq!(conditional_signer.address(), wallet.address());
+    }
+
     // --------------------------------------------------------------------------------------------
     // sign_transaction
     // --------------------------------------------------------------------------------------------
@@ -222,6 +287,7 @@ mod tests {
         "59c6995e998f97a5a0044966f0945389dc9e86dae88c7a8412f4603b6b78690d";
     const MNEMONIC: &str =
         "indoor dish desk flag debris potato excuse depart ticket judge file exit";
+    const PASSWORD: &str = "p@ssw0rd";
 
     async fn local_wallet_mnemonic_conditional_signer() -> ConditionalSigner {
         let tx_signing_config = TxSigningConfig::Mnemonic {
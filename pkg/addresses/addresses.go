@@ -5,15 +5,12 @@
 //
 // The addresses depend on the deployment of the contracts and should be provided by the node user.
 // This module offers an option to load these addresses from a config file, compatible with the
-// output of `sunodo address-book --json`.
+// output of `sunodo address-book --json`, the deployment info of gen-devnet, hardhat-deploy
+// deployments, foundry broadcasts and multi-application books.
 // This package also contain the addresses for the test environment as hard-coded values.
 package addresses
 
 import (
-	"encoding/json"
-	"fmt"
-	"os"
-
 	"github.com/ethereum/go-ethereum/common"
 )
 
@@ -52,16 +49,9 @@ func GetTestBook() *Book {
 	}
 }
 
-// Get the address book from json File.
+// Get the address book from a file or directory in any of the supported formats.
+// Multi-application books must have a single application.
 func GetBookFromFile(path string) (*Book, error) {
-	data, err := os.ReadFile(path)
-	if err != nil {
-		return nil, fmt.Errorf("read address book file: %v", err)
-	}
-	var book Book
-	err = json.Unmarshal(data, &book)
-	if err != nil {
-		return nil, fmt.Errorf("parse address book json: %v", err)
-	}
-	return &book, nil
+	book, _, err := readBook(path, "")
+	return book, err
 }
@@ -0,0 +1,396 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package addresses
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Contract of the book.
+type contract struct {
+	// Names of the contract in the address book files. The first one is the name of the contract.
+	names []string
+
+	// Environment variable that overrides the address.
+	env string
+
+	// The address must be set for the book to be valid.
+	required bool
+
+	field func(*Book) *common.Address
+}
+
+var bookContracts = []contract{
+	{
+		names: []string{"AuthorityHistoryPairFactory"},
+		env:   "CARTESI_CONTRACTS_AUTHORITY_HISTORY_PAIR_FACTORY_ADDRESS",
+		field: func(b *Book) *common.Address { return &b.AuthorityHistoryPairFactory },
+	},
+	{
+		names: []string{"CartesiDAppFactory"},
+		env:   "CARTESI_CONTRACTS_APPLICATION_FACTORY_ADDRESS",
+		field: func(b *Book) *common.Address { return &b.CartesiDAppFactory },
+	},
+	{
+		names: []string{"DAppAddressRelay"},
+		env:   "CARTESI_CONTRACTS_DAPP_ADDRESS_RELAY_ADDRESS",
+		field: func(b *Book) *common.Address { return &b.DAppAddressRelay },
+	},
+	{
+		names: []string{"ERC1155BatchPortal"},
+		env:   "CARTESI_CONTRACTS_ERC1155_BATCH_PORTAL_ADDRESS",
+		field: func(b *Book) *common.Address { return &b.ERC1155BatchPortal },
+	},
+	{
+		names: []string{"ERC1155SinglePortal"},
+		env:   "CARTESI_CONTRACTS_ERC1155_SINGLE_PORTAL_ADDRESS",
+		field: func(b *Book) *common.Address { return &b.ERC1155SinglePortal },
+	},
+	{
+		names: []string{"ERC20Portal"},
+		env:   "CARTESI_CONTRACTS_ERC20_PORTAL_ADDRESS",
+		field: func(b *Book) *common.Address { return &b.ERC20Portal },
+	},
+	{
+		names: []string{"ERC721Portal"},
+		env:   "CARTESI_CONTRACTS_ERC721_PORTAL_ADDRESS",
+		field: func(b *Book) *common.Address { return &b.ERC721Portal },
+	},
+	{
+		names: []string{"EtherPortal"},
+		env:   "CARTESI_CONTRACTS_ETHER_PORTAL_ADDRESS",
+		field: func(b *Book) *common.Address { return &b.EtherPortal },
+	},
+	{
+		names:    []string{"InputBox"},
+		env:      "CARTESI_CONTRACTS_INPUT_BOX_ADDRESS",
+		required: true,
+		field:    func(b *Book) *common.Address { return &b.InputBox },
+	},
+	{
+		names:    []string{"CartesiDApp", "Application"},
+		env:      "CARTESI_CONTRACTS_APPLICATION_ADDRESS",
+		required: true,
+		field:    func(b *Book) *common.Address { return &b.CartesiDApp },
+	},
+	{
+		names: []string{"History", "HistoryAddress"},
+		env:   "CARTESI_CONTRACTS_HISTORY_ADDRESS",
+		field: func(b *Book) *common.Address { return &b.HistoryAddress },
+	},
+	{
+		names: []string{"Authority", "AuthorityAddress"},
+		env:   "CARTESI_CONTRACTS_AUTHORITY_ADDRESS",
+		field: func(b *Book) *common.Address { return &b.AuthorityAddress },
+	},
+}
+
+// Find the contract with the given name, ignoring the case.
+func findContract(name string) *contract {
+	for i := range bookContracts {
+		for _, n := range bookContracts[i].names {
+			if strings.EqualFold(n, name) {
+				return &bookContracts[i]
+			}
+		}
+	}
+	return nil
+}
+
+// Set the address of the contract with the given name. Unknown contracts are ignored.
+func (b *Book) set(name string, value string) error {
+	c := findContract(name)
+	if c == nil {
+		return nil
+	}
+	if !common.IsHexAddress(value) {
+		return fmt.Errorf("invalid %v address %q", c.names[0], value)
+	}
+	*c.field(b) = common.HexToAddress(value)
+	return nil
+}
+
+// Merge the addresses set in the other book into this one.
+func (b *Book) Merge(other *Book) {
+	for _, c := range bookContracts {
+		if address := *c.field(other); address != (common.Address{}) {
+			*c.field(b) = address
+		}
+	}
+}
+
+// Override the addresses with the environment variables, such as
+// CARTESI_CONTRACTS_APPLICATION_ADDRESS, using the lookup function, such as os.LookupEnv.
+func (b *Book) ApplyEnvironment(lookup func(string) (string, bool)) error {
+	for _, c := range bookContracts {
+		value, ok := lookup(c.env)
+		if !ok || value == "" {
+			continue
+		}
+		if !common.IsHexAddress(value) {
+			return fmt.Errorf("invalid %v %q", c.env, value)
+		}
+		*c.field(b) = common.HexToAddress(value)
+	}
+	return nil
+}
+
+// Load the address book from the files, merged in the given order, and override the addresses
+// with the environment variables.
+// The files may be in any format supported by GetBookFromFile. The application selects the
+// application of the multi-application books; it may be empty if they have a single one.
+func LoadBook(application string, paths ...string) (*Book, error) {
+	book := &Book{}
+	multi := false
+	for _, path := range paths {
+		other, isMulti, err := readBook(path, application)
+		if err != nil {
+			return nil, err
+		}
+		multi = multi || isMulti
+		book.Merge(other)
+	}
+	if application != "" && !multi {
+		return nil, fmt.Errorf("application %q given without a multi-application address book",
+			application)
+	}
+	err := book.ApplyEnvironment(os.LookupEnv)
+	if err != nil {
+		return nil, err
+	}
+	return book, nil
+}
+
+// Read the address book in any of the supported formats.
+// It also returns whether the file is a multi-application book.
+func readBook(path string, application string) (*Book, bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, false, fmt.Errorf("read address book file: %v", err)
+	}
+	if info.IsDir() {
+		book, err := readHardhatDeployments(path)
+		return book, false, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false, fmt.Errorf("read address book file: %v", err)
+	}
+	var fields map[string]json.RawMessage
+	err = json.Unmarshal(data, &fields)
+	if err != nil {
+		return nil, false, fmt.Errorf("parse address book json: %v", err)
+	}
+	var book *Book
+	multi := false
+	switch {
+	case fields["applications"] != nil:
+		multi = true
+		book, err = parseMultiBook(fields, application)
+	case fields["transactions"] != nil:
+		book, err = parseFoundryBroadcast(fields["transactions"])
+	case fields["contracts"] != nil:
+		book, err = parseContracts(fields["contracts"])
+	case isDevnetDeployment(fields):
+		book, err = parseDevnetDeployment(fields)
+	default:
+		book, err = parseSunodoBook(fields)
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("parse address book %v: %v", path, err)
+	}
+	return book, multi, nil
+}
+
+// Parse the output of `sunodo address-book --json`, which maps the names to the addresses.
+func parseSunodoBook(fields map[string]json.RawMessage) (*Book, error) {
+	book := &Book{}
+	for name, raw := range fields {
+		if findContract(name) == nil {
+			continue
+		}
+		var value string
+		err := json.Unmarshal(raw, &value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %v address: %v", name, err)
+		}
+		err = book.set(name, value)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return book, nil
+}
+
+// Parse contracts that map the names to the addresses or to objects with the address, such as
+// the ones exported by hardhat-deploy.
+func parseContracts(raw json.RawMessage) (*Book, error) {
+	var fields map[string]json.RawMessage
+	err := json.Unmarshal(raw, &fields)
+	if err != nil {
+		return nil, fmt.Errorf("invalid contracts: %v", err)
+	}
+	for name, value := range fields {
+		var deployment struct {
+			Address string `json:"address"`
+		}
+		if findContract(name) == nil || json.Unmarshal(value, &deployment) != nil {
+			continue
+		}
+		fields[name], err = json.Marshal(deployment.Address)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return parseSunodoBook(fields)
+}
+
+// Parse a multi-application book, with the contracts shared by the applications and the
+// contracts of each application, indexed by name:
+//
+//	{
+//	  "contracts": {"InputBox": "0x...", ...},
+//	  "applications": {
+//	    "echo": {"CartesiDApp": "0x...", "Authority": "0x...", "History": "0x..."}
+//	  }
+//	}
+func parseMultiBook(fields map[string]json.RawMessage, application string) (*Book, error) {
+	var applications map[string]json.RawMessage
+	err := json.Unmarshal(fields["applications"], &applications)
+	if err != nil {
+		return nil, fmt.Errorf("invalid applications: %v", err)
+	}
+	names := make([]string, 0, len(applications))
+	for name := range applications {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	if application == "" {
+		if len(names) != 1 {
+			return nil, fmt.Errorf("no application selected; the book has %v",
+				strings.Join(names, ", "))
+		}
+		application = names[0]
+	}
+	raw, ok := applications[application]
+	if !ok {
+		return nil, fmt.Errorf("application %q not found; the book has %v",
+			application, strings.Join(names, ", "))
+	}
+	book := &Book{}
+	if fields["contracts"] != nil {
+		book, err = parseContracts(fields["contracts"])
+		if err != nil {
+			return nil, err
+		}
+	}
+	app, err := parseContracts(raw)
+	if err != nil {
+		return nil, fmt.Errorf("application %q: %v", application, err)
+	}
+	book.Merge(app)
+	return book, nil
+}
+
+// Whether the fields are the deployment info written by gen-devnet.
+func isDevnetDeployment(fields map[string]json.RawMessage) bool {
+	for name := range fields {
+		if strings.HasPrefix(name, "CARTESI_CONTRACTS_") {
+			return true
+		}
+	}
+	return false
+}
+
+// Parse the deployment info written by gen-devnet, which has the addresses of the application
+// and its consensus, named after their environment variables.
+// The devnet deploys the other contracts at the addresses of the test book, which fills them.
+func parseDevnetDeployment(fields map[string]json.RawMessage) (*Book, error) {
+	values := make(map[string]string)
+	for name, raw := range fields {
+		var value string
+		if json.Unmarshal(raw, &value) == nil {
+			values[name] = value
+		}
+	}
+	book := GetTestBook()
+	err := book.ApplyEnvironment(func(name string) (string, bool) {
+		value, ok := values[name]
+		return value, ok
+	})
+	if err != nil {
+		return nil, err
+	}
+	return book, nil
+}
+
+// Parse the transactions of a foundry broadcast file, such as
+// broadcast/Deploy.s.sol/31337/run-latest.json, taking the addresses of the contracts it created.
+func parseFoundryBroadcast(raw json.RawMessage) (*Book, error) {
+	var transactions []struct {
+		TransactionType string  `json:"transactionType"`
+		ContractName    *string `json:"contractName"`
+		ContractAddress *string `json:"contractAddress"`
+	}
+	err := json.Unmarshal(raw, &transactions)
+	if err != nil {
+		return nil, fmt.Errorf("invalid transactions: %v", err)
+	}
+	book := &Book{}
+	for _, tx := range transactions {
+		if tx.TransactionType != "CREATE" && tx.TransactionType != "CREATE2" ||
+			tx.ContractName == nil || tx.ContractAddress == nil {
+			continue
+		}
+		err = book.set(*tx.ContractName, *tx.ContractAddress)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return book, nil
+}
+
+// Read a hardhat-deploy deployment directory, such as deployments/localhost, which has a file
+// for each contract, named after it.
+func readHardhatDeployments(dir string) (*Book, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, fmt.Errorf("read deployments directory: %v", err)
+	}
+	book := &Book{}
+	found := false
+	for _, entry := range entries {
+		name, isJson := strings.CutSuffix(entry.Name(), ".json")
+		if entry.IsDir() || !isJson || findContract(name) == nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("read deployment file: %v", err)
+		}
+		var deployment struct {
+			Address string `json:"address"`
+		}
+		err = json.Unmarshal(data, &deployment)
+		if err != nil {
+			return nil, fmt.Errorf("parse deployment %v: %v", entry.Name(), err)
+		}
+		err = book.set(name, deployment.Address)
+		if err != nil {
+			return nil, fmt.Errorf("parse deployment %v: %v", entry.Name(), err)
+		}
+		found = true
+	}
+	if !found {
+		return nil, fmt.Errorf("no deployments of the rollups contracts found in %v", dir)
+	}
+	return book, nil
+}
@@ -0,0 +1,171 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package addresses
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testApplication = "0x7C54E3f7A8070a54223469965A871fB8f6f88c22"
+	testAuthority   = "0x58c93F83fb3304730C95aad2E360cdb88b782010"
+	testHistory     = "0x325272217ae6815b494bF38cED004c5Eb8a7CdA7"
+	testInputBox    = "0x59b22D57D4f067708AB0c00552767405926dc768"
+	otherAddress    = "0x1111111111111111111111111111111111111111"
+)
+
+func writeFile(t *testing.T, dir string, name string, content string) string {
+	path := filepath.Join(dir, name)
+	require.Nil(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestGetBookFromFile(t *testing.T) {
+	dir := t.TempDir()
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name: "sunodo",
+			content: `{
+				"CartesiDApp": "` + testApplication + `",
+				"InputBox": "` + testInputBox + `",
+				"Bitmask": "` + otherAddress + `"
+			}`,
+		},
+		{
+			name: "book",
+			content: `{
+				"CartesiDApp": "` + testApplication + `",
+				"InputBox": "` + testInputBox + `",
+				"HistoryAddress": "` + testHistory + `",
+				"AuthorityAddress": "` + testAuthority + `"
+			}`,
+		},
+		{
+			name: "hardhat-export",
+			content: `{
+				"name": "localhost",
+				"chainId": "31337",
+				"contracts": {
+					"InputBox": {"address": "` + testInputBox + `", "abi": []},
+					"CartesiDApp": {"address": "` + testApplication + `", "abi": []}
+				}
+			}`,
+		},
+		{
+			name: "foundry",
+			content: `{
+				"transactions": [
+					{
+						"transactionType": "CREATE",
+						"contractName": "InputBox",
+						"contractAddress": "` + testInputBox + `"
+					},
+					{
+						"transactionType": "CALL",
+						"contractName": null,
+						"contractAddress": "` + otherAddress + `"
+					},
+					{
+						"transactionType": "CREATE2",
+						"contractName": "CartesiDApp",
+						"contractAddress": "` + testApplication + `"
+					}
+				]
+			}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			path := writeFile(t, dir, test.name+".json", test.content)
+			book, err := GetBookFromFile(path)
+			require.Nil(t, err)
+			require.Equal(t, common.HexToAddress(testApplication), book.CartesiDApp)
+			require.Equal(t, common.HexToAddress(testInputBox), book.InputBox)
+		})
+	}
+}
+
+func TestGetBookFromDevnetDeployment(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "deployment.json", `{
+		"CARTESI_CONTRACTS_AUTHORITY_ADDRESS": "`+otherAddress+`",
+		"CARTESI_CONTRACTS_HISTORY_ADDRESS": "`+testHistory+`",
+		"CARTESI_CONTRACTS_APPLICATION_ADDRESS": "`+testApplication+`"
+	}`)
+	book, err := GetBookFromFile(path)
+	require.Nil(t, err)
+	expected := GetTestBook()
+	expected.AuthorityAddress = common.HexToAddress(otherAddress)
+	require.Equal(t, expected, book)
+}
+
+func TestGetBookFromHardhatDeployments(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, ".chainId", "31337")
+	writeFile(t, dir, "InputBox.json", `{"address": "`+testInputBox+`", "abi": []}`)
+	writeFile(t, dir, "Authority.json", `{"address": "`+testAuthority+`", "abi": []}`)
+	writeFile(t, dir, "Bitmask.json", `{"address": "`+otherAddress+`", "abi": []}`)
+
+	book, err := GetBookFromFile(dir)
+	require.Nil(t, err)
+	require.Equal(t, &Book{
+		InputBox:         common.HexToAddress(testInputBox),
+		AuthorityAddress: common.HexToAddress(testAuthority),
+	}, book)
+
+	_, err = GetBookFromFile(t.TempDir())
+	require.ErrorContains(t, err, "no deployments")
+}
+
+func TestGetBookFromFileInvalidAddress(t *testing.T) {
+	path := writeFile(t, t.TempDir(), "book.json", `{"InputBox": "0x1234"}`)
+	_, err := GetBookFromFile(path)
+	require.ErrorContains(t, err, `invalid InputBox address "0x1234"`)
+}
+
+func TestLoadBook(t *testing.T) {
+	dir := t.TempDir()
+	shared := writeFile(t, dir, "shared.json", `{
+		"InputBox": "`+testInputBox+`",
+		"CartesiDApp": "`+otherAddress+`"
+	}`)
+	multi := writeFile(t, dir, "multi.json", `{
+		"contracts": {"EtherPortal": "`+otherAddress+`"},
+		"applications": {
+			"echo": {"CartesiDApp": "`+testApplication+`", "Authority": "`+testAuthority+`"},
+			"other": {"CartesiDApp": "`+otherAddress+`"}
+		}
+	}`)
+	t.Setenv("CARTESI_CONTRACTS_HISTORY_ADDRESS", testHistory)
+
+	book, err := LoadBook("echo", shared, multi)
+	require.Nil(t, err)
+	require.Equal(t, &Book{
+		InputBox:         common.HexToAddress(testInputBox),
+		EtherPortal:      common.HexToAddress(otherAddress),
+		CartesiDApp:      common.HexToAddress(testApplication),
+		AuthorityAddress: common.HexToAddress(testAuthority),
+		HistoryAddress:   common.HexToAddress(testHistory),
+	}, book)
+
+	_, err = LoadBook("", multi)
+	require.ErrorContains(t, err, "no application selected; the book has echo, other")
+
+	_, err = LoadBook("missing", multi)
+	require.ErrorContains(t, err, `application "missing" not found`)
+
+	_, err = LoadBook("echo", shared)
+	require.ErrorContains(t, err, "without a multi-application address book")
+
+	t.Setenv("CARTESI_CONTRACTS_INPUT_BOX_ADDRESS", "invalid")
+	_, err = LoadBook("", shared)
+	require.ErrorContains(t, err, "invalid CARTESI_CONTRACTS_INPUT_BOX_ADDRESS")
+}
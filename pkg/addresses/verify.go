@@ -0,0 +1,122 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package addresses
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/cartesi/rollups-node/pkg/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Verify the book against the blockchain.
+// It checks that there is code at every address set, that the application and the InputBox are
+// set, that the consensus of the application is the Authority, that the History of the
+// Authority is the one in the book and is owned by it, and that the portals and the relay add
+// inputs to the InputBox in the book.
+// It returns all the problems found, joined in a single error.
+func (b *Book) Verify(ctx context.Context, client *ethclient.Client) error {
+	var problems []error
+	deployed := make(map[common.Address]bool)
+	for _, c := range bookContracts {
+		address := *c.field(b)
+		if address == (common.Address{}) {
+			if c.required {
+				problems = append(problems, fmt.Errorf("%v address is not set", c.names[0]))
+			}
+			continue
+		}
+		code, err := client.CodeAt(ctx, address, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get code of %v: %v", c.names[0], err)
+		}
+		if len(code) == 0 {
+			problems = append(problems, fmt.Errorf("no contract at %v address %v",
+				c.names[0], address))
+			continue
+		}
+		deployed[address] = true
+	}
+
+	opts := &bind.CallOpts{Context: ctx}
+	check := func(name string, expected common.Address, address common.Address,
+		getAddress func(common.Address) (common.Address, error)) error {
+		if !deployed[address] || expected == (common.Address{}) {
+			return nil
+		}
+		actual, err := getAddress(address)
+		if err != nil {
+			return fmt.Errorf("failed to get %v: %v", name, err)
+		}
+		if actual != expected {
+			problems = append(problems, fmt.Errorf("%v is %v, but the book has %v",
+				name, actual, expected))
+		}
+		return nil
+	}
+	err := check("consensus of the CartesiDApp", b.AuthorityAddress, b.CartesiDApp,
+		func(address common.Address) (common.Address, error) {
+			dapp, err := contracts.NewCartesiDApp(address, client)
+			if err != nil {
+				return common.Address{}, err
+			}
+			return dapp.GetConsensus(opts)
+		})
+	if err != nil {
+		return err
+	}
+	err = check("History of the Authority", b.HistoryAddress, b.AuthorityAddress,
+		func(address common.Address) (common.Address, error) {
+			authority, err := contracts.NewAuthority(address, client)
+			if err != nil {
+				return common.Address{}, err
+			}
+			return authority.GetHistory(opts)
+		})
+	if err != nil {
+		return err
+	}
+	err = check("owner of the History", b.AuthorityAddress, b.HistoryAddress,
+		func(address common.Address) (common.Address, error) {
+			history, err := contracts.NewHistory(address, client)
+			if err != nil {
+				return common.Address{}, err
+			}
+			return history.Owner(opts)
+		})
+	if err != nil {
+		return err
+	}
+
+	relays := []struct {
+		name    string
+		address common.Address
+	}{
+		{"DAppAddressRelay", b.DAppAddressRelay},
+		{"ERC1155BatchPortal", b.ERC1155BatchPortal},
+		{"ERC1155SinglePortal", b.ERC1155SinglePortal},
+		{"ERC20Portal", b.ERC20Portal},
+		{"ERC721Portal", b.ERC721Portal},
+		{"EtherPortal", b.EtherPortal},
+	}
+	for _, relay := range relays {
+		// the relays and the portals share the getInputBox function
+		err = check("InputBox of the "+relay.name, b.InputBox, relay.address,
+			func(address common.Address) (common.Address, error) {
+				portal, err := contracts.NewEtherPortal(address, client)
+				if err != nil {
+					return common.Address{}, err
+				}
+				return portal.GetInputBox(opts)
+			})
+		if err != nil {
+			return err
+		}
+	}
+	return errors.Join(problems...)
+}
@@ -0,0 +1,87 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package addresses
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// Stand-in for a blockchain node with the contracts of a book.
+// Every getter of a contract returns the same address.
+type fakeBookChain struct {
+	code    map[common.Address]bool
+	returns map[common.Address]common.Address
+}
+
+func (c *fakeBookChain) GetCode(address common.Address, _ string) hexutil.Bytes {
+	if c.code[address] {
+		return hexutil.Bytes{0x60, 0x80}
+	}
+	return hexutil.Bytes{}
+}
+
+func (c *fakeBookChain) Call(args map[string]any, _ string) hexutil.Bytes {
+	to := common.HexToAddress(args["to"].(string))
+	return common.LeftPadBytes(c.returns[to].Bytes(), 32)
+}
+
+// Chain where the test book is consistent.
+func newFakeBookChain(book *Book) *fakeBookChain {
+	chain := &fakeBookChain{
+		code:    make(map[common.Address]bool),
+		returns: make(map[common.Address]common.Address),
+	}
+	for _, c := range bookContracts {
+		chain.code[*c.field(book)] = true
+	}
+	chain.returns[book.CartesiDApp] = book.AuthorityAddress
+	chain.returns[book.AuthorityAddress] = book.HistoryAddress
+	chain.returns[book.HistoryAddress] = book.AuthorityAddress
+	for _, relay := range []common.Address{book.DAppAddressRelay, book.ERC1155BatchPortal,
+		book.ERC1155SinglePortal, book.ERC20Portal, book.ERC721Portal, book.EtherPortal} {
+		chain.returns[relay] = book.InputBox
+	}
+	return chain
+}
+
+func newBookClient(t *testing.T, chain *fakeBookChain) *ethclient.Client {
+	server := rpc.NewServer()
+	require.Nil(t, server.RegisterName("eth", chain))
+	t.Cleanup(server.Stop)
+	return ethclient.NewClient(rpc.DialInProc(server))
+}
+
+func TestVerify(t *testing.T) {
+	book := GetTestBook()
+	client := newBookClient(t, newFakeBookChain(book))
+
+	require.Nil(t, book.Verify(context.Background(), client))
+}
+
+func TestVerifyReportsProblems(t *testing.T) {
+	book := GetTestBook()
+	chain := newFakeBookChain(book)
+	client := newBookClient(t, chain)
+	other := common.HexToAddress(otherAddress)
+	delete(chain.code, book.ERC20Portal)
+	chain.returns[book.CartesiDApp] = other
+	chain.returns[book.EtherPortal] = other
+	book.InputBox = common.Address{}
+
+	err := book.Verify(context.Background(), client)
+	require.NotNil(t, err)
+	require.ErrorContains(t, err, "InputBox address is not set")
+	require.ErrorContains(t, err, "no contract at ERC20Portal address "+book.ERC20Portal.Hex())
+	require.ErrorContains(t, err, "consensus of the CartesiDApp is "+other.Hex()+
+		", but the book has "+book.AuthorityAddress.Hex())
+	// the InputBox is not set, so the portals are not checked against it
+	require.NotContains(t, err.Error(), "InputBox of the EtherPortal")
+}
@@ -0,0 +1,217 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+// This package reads the claims of an application from the History contract.
+package claims
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/cartesi/rollups-node/pkg/addresses"
+	"github.com/cartesi/rollups-node/pkg/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Max number of blocks in each request for claims by default.
+const DefaultChunkSize = 10_000
+
+// Claim of an epoch of the application, submitted to the History.
+type Claim struct {
+	// Position of the claim among the claims of the application.
+	Index uint64 `json:"index"`
+
+	// Hash of the epoch, which commits to the outputs and to the machine state.
+	EpochHash common.Hash `json:"epochHash"`
+
+	// Indices of the first and last inputs of the epoch.
+	FirstIndex uint64 `json:"firstIndex"`
+	LastIndex  uint64 `json:"lastIndex"`
+
+	// Transaction that submitted the claim.
+	BlockNumber uint64         `json:"blockNumber"`
+	BlockTime   time.Time      `json:"blockTime"`
+	TxHash      common.Hash    `json:"txHash"`
+	Submitter   common.Address `json:"submitter"`
+}
+
+// Options of ListClaims.
+// The zero value lists all the claims, searching from the genesis block.
+type ListOptions struct {
+	// Block where the search for claims starts. It must not be after the first claim of the
+	// application, unless FirstClaimIndex is set, so the claims are indexed correctly. Set it to
+	// the deployment block of the History to avoid searching the whole chain.
+	FromBlock uint64
+
+	// Last block searched. If nil, it searches up to the latest block.
+	ToBlock *uint64
+
+	// Max number of blocks in each request for claims.
+	// If zero, it uses DefaultChunkSize.
+	ChunkSize uint64
+
+	// Index of the first claim found, which is the number of claims of the application before
+	// FromBlock. Set it to continue a previous search.
+	FirstClaimIndex uint64
+}
+
+// Reader of the claims of an application.
+type Reader struct {
+	client         *ethclient.Client
+	dapp           common.Address
+	historyAddress common.Address
+	history        *contracts.History
+}
+
+// Create a reader for the claims of the application in the book.
+// It reads the History in the book or, if it is not set, the History of the Authority.
+func NewReader(
+	ctx context.Context,
+	client *ethclient.Client,
+	book *addresses.Book,
+) (*Reader, error) {
+	historyAddress := book.HistoryAddress
+	if historyAddress == (common.Address{}) {
+		authority, err := contracts.NewAuthority(book.AuthorityAddress, client)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to Authority contract: %v", err)
+		}
+		historyAddress, err = authority.GetHistory(&bind.CallOpts{Context: ctx})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get History of Authority: %v", err)
+		}
+	}
+	history, err := contracts.NewHistory(historyAddress, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to History contract: %v", err)
+	}
+	return &Reader{
+		client:         client,
+		dapp:           book.CartesiDApp,
+		historyAddress: historyAddress,
+		history:        history,
+	}, nil
+}
+
+// Address of the History read.
+func (r *Reader) HistoryAddress() common.Address {
+	return r.historyAddress
+}
+
+// List the claims of the application, in order.
+// If opts is nil, it uses the default options.
+func (r *Reader) ListClaims(ctx context.Context, opts *ListOptions) ([]Claim, error) {
+	if opts == nil {
+		opts = &ListOptions{}
+	}
+	chunkSize := opts.ChunkSize
+	if chunkSize == 0 {
+		chunkSize = DefaultChunkSize
+	}
+	var to uint64
+	if opts.ToBlock != nil {
+		to = *opts.ToBlock
+	} else {
+		head, err := r.client.BlockNumber(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get latest block: %v", err)
+		}
+		to = head
+	}
+
+	claims := []Claim{}
+	times := make(map[uint64]time.Time)
+	for start := opts.FromBlock; start <= to; start += chunkSize {
+		end := min(start+chunkSize-1, to)
+		it, err := r.history.FilterNewClaimToHistory(
+			&bind.FilterOpts{Start: start, End: &end, Context: ctx},
+			[]common.Address{r.dapp},
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to filter claims: %v", err)
+		}
+		for it.Next() {
+			index := opts.FirstClaimIndex + uint64(len(claims))
+			claim, err := r.newClaim(ctx, index, it.Event, times)
+			if err != nil {
+				it.Close()
+				return nil, err
+			}
+			claims = append(claims, *claim)
+		}
+		err = it.Error()
+		it.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to filter claims: %v", err)
+		}
+	}
+	return claims, nil
+}
+
+// Create the claim from the event, getting the block time and the sender of the transaction.
+func (r *Reader) newClaim(
+	ctx context.Context,
+	index uint64,
+	event *contracts.HistoryNewClaimToHistory,
+	times map[uint64]time.Time,
+) (*Claim, error) {
+	claim := &Claim{
+		Index:       index,
+		EpochHash:   event.Claim.EpochHash,
+		FirstIndex:  event.Claim.FirstIndex.Uint64(),
+		LastIndex:   event.Claim.LastIndex.Uint64(),
+		BlockNumber: event.Raw.BlockNumber,
+		TxHash:      event.Raw.TxHash,
+	}
+	blockTime, ok := times[claim.BlockNumber]
+	if !ok {
+		header, err := r.client.HeaderByNumber(ctx, new(big.Int).SetUint64(claim.BlockNumber))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get block %v: %v", claim.BlockNumber, err)
+		}
+		blockTime = time.Unix(int64(header.Time), 0).UTC()
+		times[claim.BlockNumber] = blockTime
+	}
+	claim.BlockTime = blockTime
+	tx, _, err := r.client.TransactionByHash(ctx, claim.TxHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get transaction %v: %v", claim.TxHash, err)
+	}
+	claim.Submitter, err = types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sender of transaction %v: %v", claim.TxHash, err)
+	}
+	return claim, nil
+}
+
+// Claimed epoch returned by GetClaim.
+type ClaimData struct {
+	EpochHash  common.Hash `json:"epochHash"`
+	FirstIndex uint64      `json:"firstIndex"`
+	LastIndex  uint64      `json:"lastIndex"`
+}
+
+// Get the claim of the application from the History, given the proof context, which is the
+// claim index encoded by ProofContext.
+func (r *Reader) GetClaim(ctx context.Context, proofContext []byte) (*ClaimData, error) {
+	epochHash, firstIndex, lastIndex, err := r.history.GetClaim(
+		&bind.CallOpts{Context: ctx}, r.dapp, proofContext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get claim: %v", err)
+	}
+	return &ClaimData{
+		EpochHash:  epochHash,
+		FirstIndex: firstIndex.Uint64(),
+		LastIndex:  lastIndex.Uint64(),
+	}, nil
+}
+
+// Encode the claim index as the proof context of the History, an ABI-encoded uint256.
+func ProofContext(claimIndex uint64) []byte {
+	return common.BigToHash(new(big.Int).SetUint64(claimIndex)).Bytes()
+}
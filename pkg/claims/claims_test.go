@@ -0,0 +1,242 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package claims
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/json"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cartesi/rollups-node/pkg/addresses"
+	"github.com/cartesi/rollups-node/pkg/contracts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+const testChainId = 31337
+
+// Stand-in for a blockchain node with the claims of the application in the History.
+type fakeClaimChain struct {
+	t     *testing.T
+	book  *addresses.Book
+	mutex sync.Mutex
+	head  uint64
+	logs  []types.Log
+	txs   map[common.Hash]*types.Transaction
+	// claims of the application, as submitted to the History
+	claims []contracts.HistoryClaim
+	ranges [][2]uint64
+}
+
+func newFakeClaimChain(t *testing.T, head uint64) *fakeClaimChain {
+	return &fakeClaimChain{
+		t:    t,
+		book: addresses.GetTestBook(),
+		head: head,
+		txs:  make(map[common.Hash]*types.Transaction),
+	}
+}
+
+func (c *fakeClaimChain) header(number uint64) *types.Header {
+	return &types.Header{
+		Number:     new(big.Int).SetUint64(number),
+		Difficulty: new(big.Int),
+		Time:       1_700_000_000 + 12*number,
+	}
+}
+
+func (c *fakeClaimChain) BlockNumber() hexutil.Uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return hexutil.Uint64(c.head)
+}
+
+func (c *fakeClaimChain) GetBlockByNumber(number rpc.BlockNumber, _ bool) *types.Header {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if number < 0 {
+		return c.header(c.head)
+	}
+	return c.header(uint64(number))
+}
+
+func (c *fakeClaimChain) GetLogs(query map[string]any) []types.Log {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	from := hexutil.MustDecodeUint64(query["fromBlock"].(string))
+	to := hexutil.MustDecodeUint64(query["toBlock"].(string))
+	c.ranges = append(c.ranges, [2]uint64{from, to})
+	logs := []types.Log{}
+	for _, log := range c.logs {
+		if log.BlockNumber >= from && log.BlockNumber <= to {
+			logs = append(logs, log)
+		}
+	}
+	return logs
+}
+
+func (c *fakeClaimChain) GetTransactionByHash(hash common.Hash) map[string]any {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	data, err := json.Marshal(c.txs[hash])
+	require.Nil(c.t, err)
+	var tx map[string]any
+	require.Nil(c.t, json.Unmarshal(data, &tx))
+	return tx
+}
+
+func (c *fakeClaimChain) Call(args map[string]any, _ string) hexutil.Bytes {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	input := hexutil.MustDecode(args["input"].(string))
+	to := common.HexToAddress(args["to"].(string))
+	if to == c.book.AuthorityAddress {
+		// getHistory
+		return common.LeftPadBytes(c.book.HistoryAddress.Bytes(), 32)
+	}
+	parsed, err := contracts.HistoryMetaData.GetAbi()
+	require.Nil(c.t, err)
+	method := parsed.Methods["getClaim"]
+	values, err := method.Inputs.Unpack(input[4:])
+	require.Nil(c.t, err)
+	index := new(big.Int).SetBytes(values[1].([]byte)).Uint64()
+	claim := c.claims[index]
+	output, err := method.Outputs.Pack(claim.EpochHash, claim.FirstIndex, claim.LastIndex)
+	require.Nil(c.t, err)
+	return output
+}
+
+// Add a claim of the application, submitted by the key in the given block.
+func (c *fakeClaimChain) addClaim(
+	blockNumber uint64,
+	firstIndex uint64,
+	lastIndex uint64,
+	key *keyPair,
+) contracts.HistoryClaim {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	claim := contracts.HistoryClaim{
+		EpochHash:  crypto.Keccak256Hash(big.NewInt(int64(len(c.claims))).Bytes()),
+		FirstIndex: new(big.Int).SetUint64(firstIndex),
+		LastIndex:  new(big.Int).SetUint64(lastIndex),
+	}
+	parsed, err := contracts.HistoryMetaData.GetAbi()
+	require.Nil(c.t, err)
+	event := parsed.Events["NewClaimToHistory"]
+	data, err := event.Inputs.NonIndexed().Pack(claim)
+	require.Nil(c.t, err)
+	tx, err := types.SignNewTx(key.private, types.LatestSignerForChainID(big.NewInt(testChainId)),
+		&types.DynamicFeeTx{
+			ChainID:   big.NewInt(testChainId),
+			Nonce:     uint64(len(c.claims)),
+			To:        &c.book.AuthorityAddress,
+			Gas:       100_000,
+			GasFeeCap: big.NewInt(1),
+		})
+	require.Nil(c.t, err)
+	c.txs[tx.Hash()] = tx
+	c.logs = append(c.logs, types.Log{
+		Address: c.book.HistoryAddress,
+		Topics: []common.Hash{
+			event.ID,
+			common.BytesToHash(c.book.CartesiDApp.Bytes()),
+		},
+		Data:        data,
+		BlockNumber: blockNumber,
+		BlockHash:   c.header(blockNumber).Hash(),
+		TxHash:      tx.Hash(),
+		Index:       uint(len(c.logs)),
+	})
+	c.claims = append(c.claims, claim)
+	return claim
+}
+
+type keyPair struct {
+	private *ecdsa.PrivateKey
+	address common.Address
+}
+
+func newKeyPair(t *testing.T) *keyPair {
+	private, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	return &keyPair{private, crypto.PubkeyToAddress(private.PublicKey)}
+}
+
+func newClaimClient(t *testing.T, chain *fakeClaimChain) *ethclient.Client {
+	server := rpc.NewServer()
+	require.Nil(t, server.RegisterName("eth", chain))
+	t.Cleanup(server.Stop)
+	return ethclient.NewClient(rpc.DialInProc(server))
+}
+
+func TestListClaims(t *testing.T) {
+	key := newKeyPair(t)
+	chain := newFakeClaimChain(t, 40)
+	first := chain.addClaim(5, 0, 2, key)
+	chain.addClaim(17, 3, 3, key)
+	chain.addClaim(17, 4, 9, key)
+	client := newClaimClient(t, chain)
+	ctx := context.Background()
+
+	// the History is taken from the Authority
+	book := *chain.book
+	book.HistoryAddress = common.Address{}
+	reader, err := NewReader(ctx, client, &book)
+	require.Nil(t, err)
+	require.Equal(t, chain.book.HistoryAddress, reader.HistoryAddress())
+
+	claims, err := reader.ListClaims(ctx, &ListOptions{FromBlock: 3, ChunkSize: 10})
+	require.Nil(t, err)
+	require.Equal(t, [][2]uint64{{3, 12}, {13, 22}, {23, 32}, {33, 40}}, chain.ranges)
+	require.Len(t, claims, 3)
+	require.Equal(t, Claim{
+		Index:       0,
+		EpochHash:   first.EpochHash,
+		FirstIndex:  0,
+		LastIndex:   2,
+		BlockNumber: 5,
+		BlockTime:   time.Unix(1_700_000_060, 0).UTC(),
+		TxHash:      chain.logs[0].TxHash,
+		Submitter:   key.address,
+	}, claims[0])
+	for i, claim := range claims {
+		require.Equal(t, uint64(i), claim.Index)
+	}
+	require.Equal(t, uint64(4), claims[2].FirstIndex)
+	require.Equal(t, uint64(9), claims[2].LastIndex)
+	require.Equal(t, claims[1].BlockTime, claims[2].BlockTime)
+}
+
+func TestGetClaim(t *testing.T) {
+	chain := newFakeClaimChain(t, 40)
+	chain.addClaim(5, 0, 2, newKeyPair(t))
+	second := chain.addClaim(8, 3, 7, newKeyPair(t))
+	client := newClaimClient(t, chain)
+	ctx := context.Background()
+
+	reader, err := NewReader(ctx, client, chain.book)
+	require.Nil(t, err)
+	claim, err := reader.GetClaim(ctx, ProofContext(1))
+	require.Nil(t, err)
+	require.Equal(t, &ClaimData{
+		EpochHash:  second.EpochHash,
+		FirstIndex: 3,
+		LastIndex:  7,
+	}, claim)
+}
+
+func TestProofContext(t *testing.T) {
+	require.Equal(t, common.FromHex(
+		"0x0000000000000000000000000000000000000000000000000000000000000102"),
+		ProofContext(258))
+}
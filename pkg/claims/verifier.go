@@ -0,0 +1,436 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package claims
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/cartesi/rollups-node/pkg/readerclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Epochs of the application.
+// Epoch i spans the blocks [GenesisBlock + i*Length, GenesisBlock + (i+1)*Length).
+// Only the epochs with inputs are claimed.
+type EpochConfig struct {
+	// Length of the epochs in blocks, as in CARTESI_EPOCH_LENGTH.
+	Length uint64
+
+	// Block where the first epoch starts, which is the deployment block of the InputBox.
+	GenesisBlock uint64
+
+	// Blocks tolerated after the deadline of a claim before it is reported as late or missing.
+	// The deadline of a claim is Length blocks after the end of its epoch.
+	GracePeriod uint64
+}
+
+// Epoch of the given block.
+func (c EpochConfig) Epoch(block uint64) uint64 {
+	if block < c.GenesisBlock {
+		return 0
+	}
+	return (block - c.GenesisBlock) / c.Length
+}
+
+// Last block of the epoch.
+func (c EpochConfig) LastBlock(epoch uint64) uint64 {
+	return c.GenesisBlock + (epoch+1)*c.Length - 1
+}
+
+// Last block in which the claim of the epoch may be submitted, including the grace period.
+func (c EpochConfig) Deadline(epoch uint64) uint64 {
+	return c.LastBlock(epoch) + c.Length + c.GracePeriod
+}
+
+// Epoch hash committed by the proof of an output, which must match the claim of its epoch.
+// It is the keccak256 of the vouchers epoch root hash, the notices epoch root hash, and the
+// machine state hash.
+func EpochHash(proof *readerclient.Proof) common.Hash {
+	return crypto.Keccak256Hash(
+		proof.VouchersEpochRootHash,
+		proof.NoticesEpochRootHash,
+		proof.MachineStateHash,
+	)
+}
+
+// Kind of problem found by the verifier.
+type FindingKind string
+
+const (
+	// The epoch hash of the claim doesn't match the output proofs.
+	FindingMismatch FindingKind = "mismatch"
+
+	// The epoch wasn't claimed before its deadline.
+	FindingMissing FindingKind = "missing"
+
+	// The claim was submitted after its deadline.
+	FindingLate FindingKind = "late"
+
+	// The inputs of the claim don't match the inputs of the epoch.
+	FindingRange FindingKind = "range"
+)
+
+// Problem found by the verifier.
+type Finding struct {
+	Kind  FindingKind `json:"kind"`
+	Epoch uint64      `json:"epoch"`
+
+	// Index of the claim, if the epoch was claimed.
+	ClaimIndex *uint64 `json:"claimIndex,omitempty"`
+
+	Message string `json:"message"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("epoch %v: %v: %v", f.Epoch, f.Kind, f.Message)
+}
+
+// Verification of an epoch with inputs.
+type EpochReport struct {
+	Epoch uint64 `json:"epoch"`
+
+	// Indices of the first and last inputs of the epoch, as reported by the reader.
+	FirstInput uint64 `json:"firstInput"`
+	LastInput  uint64 `json:"lastInput"`
+
+	// Last block in which the claim may be submitted.
+	Deadline uint64 `json:"deadline"`
+
+	// Whether the epoch ended before the latest block.
+	Closed bool `json:"closed"`
+
+	// Claim of the epoch, if any.
+	Claim *Claim `json:"claim,omitempty"`
+
+	// Epoch hash recomputed from the output proofs.
+	// It is nil if the reader has no proofs for the epoch, as when the epoch has no outputs.
+	ExpectedHash *common.Hash `json:"expectedHash,omitempty"`
+
+	// Whether the claim was checked against the output proofs and matched them.
+	Verified bool `json:"verified"`
+}
+
+// Result of the verification of the claims of an application.
+type Report struct {
+	// Latest block when the verification started.
+	Head uint64 `json:"head"`
+
+	// Number of claims of the application.
+	Claims int `json:"claims"`
+
+	Epochs   []EpochReport `json:"epochs"`
+	Findings []Finding     `json:"findings"`
+}
+
+// Verifier of the claims of an application against the outputs reported by a reader node.
+//
+// The verifier keeps what it read between calls to Verify, so each call only reads the blocks
+// after the previous call and the inputs of the epochs that may still change. An epoch doesn't
+// change after it is claimed, all its inputs are processed, and all its outputs have proofs.
+// A verifier must not be used by concurrent calls.
+type Verifier struct {
+	reader  *Reader
+	graphql graphql.Client
+	epochs  EpochConfig
+
+	// Max number of blocks in each request for claims. If zero, it uses DefaultChunkSize.
+	ChunkSize uint64
+
+	// Function that gets the pages of inputs, replaced in the tests.
+	getInputsPage readerclient.PageFunc[readerclient.Input]
+
+	// Next block searched for claims.
+	scanned uint64
+
+	// Claims found before the scanned block.
+	claims []Claim
+
+	// Inputs read from the reader, in order.
+	inputs []readerclient.Input
+
+	// Pages of the inputs. The inputs of the last page and after it are read again by the next
+	// call to Verify.
+	pages []inputPage
+}
+
+// Page of the inputs read by the verifier.
+type inputPage struct {
+	// Cursor used to get the page.
+	after string
+
+	// Position of the first input of the page in the inputs read.
+	first int
+}
+
+// Create a verifier of the claims read by the reader against the outputs reported by the
+// GraphQL API of a reader node.
+func NewVerifier(
+	reader *Reader,
+	graphqlClient graphql.Client,
+	epochs EpochConfig,
+) (*Verifier, error) {
+	if epochs.Length == 0 {
+		return nil, errors.New("the epoch length must be positive")
+	}
+	return &Verifier{
+		reader:        reader,
+		graphql:       graphqlClient,
+		epochs:        epochs,
+		getInputsPage: readerclient.GetInputsPage,
+		scanned:       epochs.GenesisBlock,
+	}, nil
+}
+
+// Verify the claims of the application up to the latest block.
+func (v *Verifier) Verify(ctx context.Context) (*Report, error) {
+	head, err := v.reader.client.BlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest block: %v", err)
+	}
+	if head >= v.scanned {
+		claims, err := v.reader.ListClaims(ctx, &ListOptions{
+			FromBlock:       v.scanned,
+			ToBlock:         &head,
+			ChunkSize:       v.ChunkSize,
+			FirstClaimIndex: uint64(len(v.claims)),
+		})
+		if err != nil {
+			return nil, err
+		}
+		v.claims = append(v.claims, claims...)
+		v.scanned = head + 1
+	}
+	err = v.readInputs(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get inputs: %v", err)
+	}
+	report := VerifyClaims(v.epochs, head, v.claims, v.inputs)
+	v.keepSettledInputs(report)
+	return report, nil
+}
+
+// Read the inputs from the last page read, replacing the ones read before.
+// If a request fails, the inputs read before are kept.
+func (v *Verifier) readInputs(ctx context.Context) error {
+	var pages []inputPage
+	var inputs []readerclient.Input
+	after := ""
+	if n := len(v.pages); n > 0 {
+		last := v.pages[n-1]
+		pages = slices.Clone(v.pages[:n-1])
+		inputs = slices.Clone(v.inputs[:last.first])
+		after = last.after
+	}
+	it := readerclient.NewIterator(v.graphql, v.getInputsPage, 0, after)
+	for {
+		cursor := it.Cursor()
+		if !it.Next(ctx) {
+			break
+		}
+		pages = append(pages, inputPage{after: cursor, first: len(inputs)})
+		inputs = append(inputs, it.Page()...)
+	}
+	if it.Err() != nil {
+		return it.Err()
+	}
+	v.pages = pages
+	v.inputs = inputs
+	return nil
+}
+
+// Keep the pages up to the one with the first input of an epoch that may still change, so the
+// next call to Verify reads the inputs again from that page.
+// If no epoch may change, the last page is read again to get the new inputs.
+func (v *Verifier) keepSettledInputs(report *Report) {
+	claimed := make(map[uint64]bool)
+	for _, epoch := range report.Epochs {
+		claimed[epoch.Epoch] = epoch.Claim != nil
+	}
+	position := len(v.inputs)
+	epochStart := 0
+	for i, input := range v.inputs {
+		epoch := v.epochs.Epoch(input.BlockNumber)
+		if i > 0 && epoch != v.epochs.Epoch(v.inputs[i-1].BlockNumber) {
+			epochStart = i
+		}
+		if !claimed[epoch] || !settled(input) {
+			position = epochStart
+			break
+		}
+	}
+	n := len(v.pages)
+	for n > 1 && v.pages[n-1].first > position {
+		n--
+	}
+	v.pages = v.pages[:n]
+}
+
+// Reports whether the input was processed and all its outputs have proofs, so the reader won't
+// report anything new about it.
+func settled(input readerclient.Input) bool {
+	if input.Status == readerclient.CompletionStatusUnprocessed {
+		return false
+	}
+	for _, voucher := range input.Vouchers {
+		if voucher.Proof == nil {
+			return false
+		}
+	}
+	for _, notice := range input.Notices {
+		if notice.Proof == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Verify the claims against the inputs reported by the reader, with their outputs.
+// The inputs are grouped into epochs by their block numbers. Each epoch must be claimed
+// with its inputs before its deadline, and the epoch hash of the claim must match the proofs
+// of the outputs of the epoch.
+func VerifyClaims(
+	epochs EpochConfig,
+	head uint64,
+	claims []Claim,
+	inputs []readerclient.Input,
+) *Report {
+	report := &Report{
+		Head:     head,
+		Claims:   len(claims),
+		Epochs:   []EpochReport{},
+		Findings: []Finding{},
+	}
+	inputEpochs := make(map[uint64]uint64)
+	proofs := make(map[uint64][]*readerclient.Proof)
+	for _, input := range inputs {
+		epoch := epochs.Epoch(input.BlockNumber)
+		index := uint64(input.Index)
+		inputEpochs[index] = epoch
+		n := len(report.Epochs)
+		if n == 0 || report.Epochs[n-1].Epoch != epoch {
+			report.Epochs = append(report.Epochs, EpochReport{
+				Epoch:      epoch,
+				FirstInput: index,
+				Deadline:   epochs.Deadline(epoch),
+				Closed:     epochs.LastBlock(epoch) < head,
+			})
+			n++
+		}
+		report.Epochs[n-1].LastInput = index
+		for _, voucher := range input.Vouchers {
+			if voucher.Proof != nil {
+				proofs[epoch] = append(proofs[epoch], voucher.Proof)
+			}
+		}
+		for _, notice := range input.Notices {
+			if notice.Proof != nil {
+				proofs[epoch] = append(proofs[epoch], notice.Proof)
+			}
+		}
+	}
+
+	claimsByInput := make(map[uint64]*Claim)
+	for i := range claims {
+		claim := &claims[i]
+		epoch, ok := inputEpochs[claim.FirstIndex]
+		if !ok {
+			// The reader hasn't seen the inputs of the claim yet.
+			continue
+		}
+		if !startsEpoch(report, claim) {
+			report.add(FindingRange, epoch, claim,
+				"claim of inputs %v to %v doesn't start at the first input of an epoch",
+				claim.FirstIndex, claim.LastIndex)
+			continue
+		}
+		if other, ok := claimsByInput[claim.FirstIndex]; ok {
+			report.add(FindingRange, epoch, claim, "the epoch was already claimed by claim %v",
+				other.Index)
+			continue
+		}
+		claimsByInput[claim.FirstIndex] = claim
+	}
+
+	for i := range report.Epochs {
+		epoch := &report.Epochs[i]
+		epoch.Claim = claimsByInput[epoch.FirstInput]
+		consistent := true
+		for _, proof := range proofs[epoch.Epoch] {
+			hash := EpochHash(proof)
+			if epoch.ExpectedHash == nil {
+				epoch.ExpectedHash = &hash
+			} else if *epoch.ExpectedHash != hash && consistent {
+				report.add(FindingMismatch, epoch.Epoch, epoch.Claim,
+					"the output proofs commit to different epoch hashes: %v and %v",
+					*epoch.ExpectedHash, hash)
+				consistent = false
+			}
+		}
+		report.verifyEpoch(epoch)
+		epoch.Verified = epoch.Verified && consistent
+	}
+	return report
+}
+
+// Check the claim of the epoch, if any.
+func (r *Report) verifyEpoch(epoch *EpochReport) {
+	claim := epoch.Claim
+	if claim == nil {
+		if r.Head > epoch.Deadline {
+			r.add(FindingMissing, epoch.Epoch, nil,
+				"inputs %v to %v weren't claimed until block %v",
+				epoch.FirstInput, epoch.LastInput, epoch.Deadline)
+		}
+		return
+	}
+	if claim.LastIndex != epoch.LastInput {
+		r.add(FindingRange, epoch.Epoch, claim,
+			"claim of inputs %v to %v, but the epoch has inputs %v to %v",
+			claim.FirstIndex, claim.LastIndex, epoch.FirstInput, epoch.LastInput)
+	}
+	if claim.BlockNumber > epoch.Deadline {
+		r.add(FindingLate, epoch.Epoch, claim,
+			"claim submitted in block %v, after the deadline in block %v",
+			claim.BlockNumber, epoch.Deadline)
+	}
+	if epoch.ExpectedHash != nil {
+		if *epoch.ExpectedHash != claim.EpochHash {
+			r.add(FindingMismatch, epoch.Epoch, claim,
+				"claim has epoch hash %v, but the output proofs commit to %v",
+				claim.EpochHash, *epoch.ExpectedHash)
+		} else {
+			epoch.Verified = true
+		}
+	}
+}
+
+func (r *Report) add(
+	kind FindingKind,
+	epoch uint64,
+	claim *Claim,
+	format string,
+	args ...any,
+) {
+	finding := Finding{Kind: kind, Epoch: epoch, Message: fmt.Sprintf(format, args...)}
+	if claim != nil {
+		index := claim.Index
+		finding.ClaimIndex = &index
+	}
+	r.Findings = append(r.Findings, finding)
+}
+
+// Reports whether the first input of the claim is the first input of an epoch.
+func startsEpoch(report *Report, claim *Claim) bool {
+	for _, epoch := range report.Epochs {
+		if epoch.FirstInput == claim.FirstIndex {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,216 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package claims
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/cartesi/rollups-node/pkg/readerclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var testEpochs = EpochConfig{Length: 10, GenesisBlock: 100, GracePeriod: 5}
+
+func newTestProof(machineStateHash string) *readerclient.Proof {
+	return &readerclient.Proof{
+		VouchersEpochRootHash: common.HexToHash("0x01").Bytes(),
+		NoticesEpochRootHash:  common.HexToHash("0x02").Bytes(),
+		MachineStateHash:      common.HexToHash(machineStateHash).Bytes(),
+	}
+}
+
+func newTestInput(index int, blockNumber uint64, proof *readerclient.Proof) readerclient.Input {
+	input := readerclient.Input{Index: index, BlockNumber: blockNumber}
+	if proof != nil {
+		input.Notices = []readerclient.Notice{{InputIndex: index, Proof: proof}}
+	}
+	return input
+}
+
+func TestEpochConfig(t *testing.T) {
+	require.Equal(t, uint64(0), testEpochs.Epoch(50))
+	require.Equal(t, uint64(0), testEpochs.Epoch(109))
+	require.Equal(t, uint64(1), testEpochs.Epoch(110))
+	require.Equal(t, uint64(119), testEpochs.LastBlock(1))
+	require.Equal(t, uint64(134), testEpochs.Deadline(1))
+}
+
+func TestEpochHash(t *testing.T) {
+	proof := newTestProof("0x03")
+	expected := crypto.Keccak256Hash(common.FromHex(
+		"0x0000000000000000000000000000000000000000000000000000000000000001" +
+			"0000000000000000000000000000000000000000000000000000000000000002" +
+			"0000000000000000000000000000000000000000000000000000000000000003"))
+	require.Equal(t, expected, EpochHash(proof))
+}
+
+func TestVerifyClaims(t *testing.T) {
+	first := newTestProof("0xaa")
+	second := newTestProof("0xbb")
+	inputs := []readerclient.Input{
+		newTestInput(0, 101, first),
+		newTestInput(1, 105, nil),
+		newTestInput(2, 115, second),
+		newTestInput(3, 125, nil),
+		newTestInput(4, 141, nil),
+	}
+	claims := []Claim{
+		{Index: 0, FirstIndex: 0, LastIndex: 1, EpochHash: EpochHash(first), BlockNumber: 112},
+		{Index: 1, FirstIndex: 2, LastIndex: 2, EpochHash: EpochHash(first), BlockNumber: 140},
+	}
+
+	report := VerifyClaims(testEpochs, 145, claims, inputs)
+	require.Equal(t, uint64(145), report.Head)
+	require.Equal(t, 2, report.Claims)
+	require.Len(t, report.Epochs, 4)
+
+	epoch := report.Epochs[0]
+	require.Equal(t, uint64(0), epoch.Epoch)
+	require.Equal(t, uint64(0), epoch.FirstInput)
+	require.Equal(t, uint64(1), epoch.LastInput)
+	require.Equal(t, uint64(124), epoch.Deadline)
+	require.True(t, epoch.Closed)
+	require.Equal(t, &claims[0], epoch.Claim)
+	require.True(t, epoch.Verified)
+
+	epoch = report.Epochs[1]
+	require.Equal(t, &claims[1], epoch.Claim)
+	require.Equal(t, EpochHash(second), *epoch.ExpectedHash)
+	require.False(t, epoch.Verified)
+
+	// the third epoch has no outputs, so it can't be verified
+	epoch = report.Epochs[2]
+	require.Nil(t, epoch.Claim)
+	require.Nil(t, epoch.ExpectedHash)
+
+	// the last epoch is still open
+	epoch = report.Epochs[3]
+	require.Equal(t, uint64(4), epoch.Epoch)
+	require.False(t, epoch.Closed)
+
+	one := uint64(1)
+	require.Equal(t, []Finding{
+		{
+			Kind:       FindingLate,
+			Epoch:      1,
+			ClaimIndex: &one,
+			Message:    "claim submitted in block 140, after the deadline in block 134",
+		},
+		{
+			Kind:       FindingMismatch,
+			Epoch:      1,
+			ClaimIndex: &one,
+			Message: "claim has epoch hash " + EpochHash(first).Hex() +
+				", but the output proofs commit to " + EpochHash(second).Hex(),
+		},
+		{
+			Kind:    FindingMissing,
+			Epoch:   2,
+			Message: "inputs 3 to 3 weren't claimed until block 144",
+		},
+	}, report.Findings)
+}
+
+func TestVerifyClaimsRanges(t *testing.T) {
+	inputs := []readerclient.Input{
+		newTestInput(0, 101, nil),
+		newTestInput(1, 105, nil),
+		newTestInput(2, 115, nil),
+	}
+	claims := []Claim{
+		{Index: 0, FirstIndex: 0, LastIndex: 0, BlockNumber: 112},
+		{Index: 1, FirstIndex: 1, LastIndex: 1, BlockNumber: 113},
+		{Index: 2, FirstIndex: 2, LastIndex: 2, BlockNumber: 121},
+		{Index: 3, FirstIndex: 2, LastIndex: 2, BlockNumber: 122},
+		// the reader hasn't seen the inputs of this claim yet
+		{Index: 4, FirstIndex: 3, LastIndex: 3, BlockNumber: 131},
+	}
+
+	report := VerifyClaims(testEpochs, 132, claims, inputs)
+	var messages []string
+	for _, finding := range report.Findings {
+		require.Equal(t, FindingRange, finding.Kind)
+		messages = append(messages, finding.String())
+	}
+	require.Equal(t, []string{
+		"epoch 0: range: claim of inputs 1 to 1 doesn't start at the first input of an epoch",
+		"epoch 1: range: the epoch was already claimed by claim 2",
+		"epoch 0: range: claim of inputs 0 to 0, but the epoch has inputs 0 to 1",
+	}, messages)
+	require.Equal(t, uint64(2), report.Epochs[1].Claim.Index)
+}
+
+// Stand-in for the inputs of a reader node, served in pages of two inputs.
+type fakeInputs struct {
+	inputs []readerclient.Input
+	// cursors of the pages requested
+	requests []string
+}
+
+func (f *fakeInputs) getPage(
+	_ context.Context,
+	_ graphql.Client,
+	_ int,
+	after string,
+) ([]readerclient.Input, *readerclient.PageInfo, error) {
+	const pageSize = 2
+	f.requests = append(f.requests, after)
+	start := 0
+	if after != "" {
+		start, _ = strconv.Atoi(after)
+	}
+	end := min(start+pageSize, len(f.inputs))
+	return slices.Clone(f.inputs[start:end]), &readerclient.PageInfo{
+		EndCursor:   strconv.Itoa(end),
+		HasNextPage: end < len(f.inputs),
+	}, nil
+}
+
+func TestVerifyReadsOnlyWhatChanged(t *testing.T) {
+	key := newKeyPair(t)
+	chain := newFakeClaimChain(t, 130)
+	chain.addClaim(112, 0, 1, key)
+	ctx := context.Background()
+	reader, err := NewReader(ctx, newClaimClient(t, chain), chain.book)
+	require.Nil(t, err)
+	unprocessed := newTestInput(3, 125, nil)
+	unprocessed.Status = readerclient.CompletionStatusUnprocessed
+	inputs := &fakeInputs{inputs: []readerclient.Input{
+		newTestInput(0, 101, newTestProof("0xaa")),
+		newTestInput(1, 105, newTestProof("0xaa")),
+		newTestInput(2, 115, newTestProof("0xbb")),
+		unprocessed,
+	}}
+	verifier, err := NewVerifier(reader, nil, testEpochs)
+	require.Nil(t, err)
+	verifier.ChunkSize = 1000
+	verifier.getInputsPage = inputs.getPage
+
+	report, err := verifier.Verify(ctx)
+	require.Nil(t, err)
+	require.Equal(t, 1, report.Claims)
+	require.Equal(t, [][2]uint64{{100, 130}}, chain.ranges)
+	require.Equal(t, []string{"", "2"}, inputs.requests)
+
+	// the first epoch is claimed and proved, so only the claims of the new blocks and the
+	// inputs from the page of the second epoch are read again
+	chain.addClaim(135, 2, 2, key)
+	chain.head = 140
+	inputs.inputs = append(inputs.inputs, newTestInput(4, 138, nil))
+	inputs.requests = nil
+	report, err = verifier.Verify(ctx)
+	require.Nil(t, err)
+	require.Equal(t, [][2]uint64{{100, 130}, {131, 140}}, chain.ranges)
+	require.Equal(t, []string{"2", "4"}, inputs.requests)
+
+	claims, err := reader.ListClaims(ctx, &ListOptions{FromBlock: testEpochs.GenesisBlock})
+	require.Nil(t, err)
+	require.Equal(t, VerifyClaims(testEpochs, 140, claims, inputs.inputs), report)
+}
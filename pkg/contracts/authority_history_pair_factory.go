@@ -0,0 +1,465 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// AuthorityHistoryPairFactoryMetaData contains all meta data concerning the AuthorityHistoryPairFactory contract.
+var AuthorityHistoryPairFactoryMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"contractIAuthorityFactory\",\"name\":\"_authorityFactory\",\"type\":\"address\"},{\"internalType\":\"contractIHistoryFactory\",\"name\":\"_historyFactory\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"contractIAuthorityFactory\",\"name\":\"authorityFactory\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"contractIHistoryFactory\",\"name\":\"historyFactory\",\"type\":\"address\"}],\"name\":\"AuthorityHistoryPairFactoryCreated\",\"type\":\"event\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_authorityOwner\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"_salt\",\"type\":\"bytes32\"}],\"name\":\"calculateAuthorityHistoryAddressPair\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"authorityAddress_\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"historyAddress_\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getAuthorityFactory\",\"outputs\":[{\"internalType\":\"contractIAuthorityFactory\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"getHistoryFactory\",\"outputs\":[{\"internalType\":\"contractIHistoryFactory\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_authorityOwner\",\"type\":\"address\"}],\"name\":\"newAuthorityHistoryPair\",\"outputs\":[{\"internalType\":\"contractAuthority\",\"name\":\"authority_\",\"type\":\"address\"},{\"internalType\":\"contractHistory\",\"name\":\"history_\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_authorityOwner\",\"type\":\"address\"},{\"internalType\":\"bytes32\",\"name\":\"_salt\",\"type\":\"bytes32\"}],\"name\":\"newAuthorityHistoryPair\",\"outputs\":[{\"internalType\":\"contractAuthority\",\"name\":\"authority_\",\"type\":\"address\"},{\"internalType\":\"contractHistory\",\"name\":\"history_\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// AuthorityHistoryPairFactoryABI is the input ABI used to generate the binding from.
+// Deprecated: Use AuthorityHistoryPairFactoryMetaData.ABI instead.
+var AuthorityHistoryPairFactoryABI = AuthorityHistoryPairFactoryMetaData.ABI
+
+// AuthorityHistoryPairFactory is an auto generated Go binding around an Ethereum contract.
+type AuthorityHistoryPairFactory struct {
+	AuthorityHistoryPairFactoryCaller     // Read-only binding to the contract
+	AuthorityHistoryPairFactoryTransactor // Write-only binding to the contract
+	AuthorityHistoryPairFactoryFilterer   // Log filterer for contract events
+}
+
+// AuthorityHistoryPairFactoryCaller is an auto generated read-only Go binding around an Ethereum contract.
+type AuthorityHistoryPairFactoryCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AuthorityHistoryPairFactoryTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type AuthorityHistoryPairFactoryTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AuthorityHistoryPairFactoryFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type AuthorityHistoryPairFactoryFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// AuthorityHistoryPairFactorySession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type AuthorityHistoryPairFactorySession struct {
+	Contract     *AuthorityHistoryPairFactory // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts                // Call options to use throughout this session
+	TransactOpts bind.TransactOpts            // Transaction auth options to use throughout this session
+}
+
+// AuthorityHistoryPairFactoryCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type AuthorityHistoryPairFactoryCallerSession struct {
+	Contract *AuthorityHistoryPairFactoryCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts                      // Call options to use throughout this session
+}
+
+// AuthorityHistoryPairFactoryTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type AuthorityHistoryPairFactoryTransactorSession struct {
+	Contract     *AuthorityHistoryPairFactoryTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts                      // Transaction auth options to use throughout this session
+}
+
+// AuthorityHistoryPairFactoryRaw is an auto generated low-level Go binding around an Ethereum contract.
+type AuthorityHistoryPairFactoryRaw struct {
+	Contract *AuthorityHistoryPairFactory // Generic contract binding to access the raw methods on
+}
+
+// AuthorityHistoryPairFactoryCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type AuthorityHistoryPairFactoryCallerRaw struct {
+	Contract *AuthorityHistoryPairFactoryCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// AuthorityHistoryPairFactoryTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type AuthorityHistoryPairFactoryTransactorRaw struct {
+	Contract *AuthorityHistoryPairFactoryTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewAuthorityHistoryPairFactory creates a new instance of AuthorityHistoryPairFactory, bound to a specific deployed contract.
+func NewAuthorityHistoryPairFactory(address common.Address, backend bind.ContractBackend) (*AuthorityHistoryPairFactory, error) {
+	contract, err := bindAuthorityHistoryPairFactory(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &AuthorityHistoryPairFactory{AuthorityHistoryPairFactoryCaller: AuthorityHistoryPairFactoryCaller{contract: contract}, AuthorityHistoryPairFactoryTransactor: AuthorityHistoryPairFactoryTransactor{contract: contract}, AuthorityHistoryPairFactoryFilterer: AuthorityHistoryPairFactoryFilterer{contract: contract}}, nil
+}
+
+// NewAuthorityHistoryPairFactoryCaller creates a new read-only instance of AuthorityHistoryPairFactory, bound to a specific deployed contract.
+func NewAuthorityHistoryPairFactoryCaller(address common.Address, caller bind.ContractCaller) (*AuthorityHistoryPairFactoryCaller, error) {
+	contract, err := bindAuthorityHistoryPairFactory(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AuthorityHistoryPairFactoryCaller{contract: contract}, nil
+}
+
+// NewAuthorityHistoryPairFactoryTransactor creates a new write-only instance of AuthorityHistoryPairFactory, bound to a specific deployed contract.
+func NewAuthorityHistoryPairFactoryTransactor(address common.Address, transactor bind.ContractTransactor) (*AuthorityHistoryPairFactoryTransactor, error) {
+	contract, err := bindAuthorityHistoryPairFactory(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &AuthorityHistoryPairFactoryTransactor{contract: contract}, nil
+}
+
+// NewAuthorityHistoryPairFactoryFilterer creates a new log filterer instance of AuthorityHistoryPairFactory, bound to a specific deployed contract.
+func NewAuthorityHistoryPairFactoryFilterer(address common.Address, filterer bind.ContractFilterer) (*AuthorityHistoryPairFactoryFilterer, error) {
+	contract, err := bindAuthorityHistoryPairFactory(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &AuthorityHistoryPairFactoryFilterer{contract: contract}, nil
+}
+
+// bindAuthorityHistoryPairFactory binds a generic wrapper to an already deployed contract.
+func bindAuthorityHistoryPairFactory(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := AuthorityHistoryPairFactoryMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactoryRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _AuthorityHistoryPairFactory.Contract.AuthorityHistoryPairFactoryCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactoryRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _AuthorityHistoryPairFactory.Contract.AuthorityHistoryPairFactoryTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactoryRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _AuthorityHistoryPairFactory.Contract.AuthorityHistoryPairFactoryTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactoryCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _AuthorityHistoryPairFactory.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactoryTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _AuthorityHistoryPairFactory.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactoryTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _AuthorityHistoryPairFactory.Contract.contract.Transact(opts, method, params...)
+}
+
+// CalculateAuthorityHistoryAddressPair is a free data retrieval call binding the contract method 0x39dcd457.
+//
+// Solidity: function calculateAuthorityHistoryAddressPair(address _authorityOwner, bytes32 _salt) view returns(address authorityAddress_, address historyAddress_)
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactoryCaller) CalculateAuthorityHistoryAddressPair(opts *bind.CallOpts, _authorityOwner common.Address, _salt [32]byte) (struct {
+	AuthorityAddress common.Address
+	HistoryAddress   common.Address
+}, error) {
+	var out []interface{}
+	err := _AuthorityHistoryPairFactory.contract.Call(opts, &out, "calculateAuthorityHistoryAddressPair", _authorityOwner, _salt)
+
+	outstruct := new(struct {
+		AuthorityAddress common.Address
+		HistoryAddress   common.Address
+	})
+	if err != nil {
+		return *outstruct, err
+	}
+
+	outstruct.AuthorityAddress = *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	outstruct.HistoryAddress = *abi.ConvertType(out[1], new(common.Address)).(*common.Address)
+
+	return *outstruct, err
+
+}
+
+// CalculateAuthorityHistoryAddressPair is a free data retrieval call binding the contract method 0x39dcd457.
+//
+// Solidity: function calculateAuthorityHistoryAddressPair(address _authorityOwner, bytes32 _salt) view returns(address authorityAddress_, address historyAddress_)
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactorySession) CalculateAuthorityHistoryAddressPair(_authorityOwner common.Address, _salt [32]byte) (struct {
+	AuthorityAddress common.Address
+	HistoryAddress   common.Address
+}, error) {
+	return _AuthorityHistoryPairFactory.Contract.CalculateAuthorityHistoryAddressPair(&_AuthorityHistoryPairFactory.CallOpts, _authorityOwner, _salt)
+}
+
+// CalculateAuthorityHistoryAddressPair is a free data retrieval call binding the contract method 0x39dcd457.
+//
+// Solidity: function calculateAuthorityHistoryAddressPair(address _authorityOwner, bytes32 _salt) view returns(address authorityAddress_, address historyAddress_)
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactoryCallerSession) CalculateAuthorityHistoryAddressPair(_authorityOwner common.Address, _salt [32]byte) (struct {
+	AuthorityAddress common.Address
+	HistoryAddress   common.Address
+}, error) {
+	return _AuthorityHistoryPairFactory.Contract.CalculateAuthorityHistoryAddressPair(&_AuthorityHistoryPairFactory.CallOpts, _authorityOwner, _salt)
+}
+
+// GetAuthorityFactory is a free data retrieval call binding the contract method 0x75689f83.
+//
+// Solidity: function getAuthorityFactory() view returns(address)
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactoryCaller) GetAuthorityFactory(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _AuthorityHistoryPairFactory.contract.Call(opts, &out, "getAuthorityFactory")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// GetAuthorityFactory is a free data retrieval call binding the contract method 0x75689f83.
+//
+// Solidity: function getAuthorityFactory() view returns(address)
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactorySession) GetAuthorityFactory() (common.Address, error) {
+	return _AuthorityHistoryPairFactory.Contract.GetAuthorityFactory(&_AuthorityHistoryPairFactory.CallOpts)
+}
+
+// GetAuthorityFactory is a free data retrieval call binding the contract method 0x75689f83.
+//
+// Solidity: function getAuthorityFactory() view returns(address)
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactoryCallerSession) GetAuthorityFactory() (common.Address, error) {
+	return _AuthorityHistoryPairFactory.Contract.GetAuthorityFactory(&_AuthorityHistoryPairFactory.CallOpts)
+}
+
+// GetHistoryFactory is a free data retrieval call binding the contract method 0xf16b1a5c.
+//
+// Solidity: function getHistoryFactory() view returns(address)
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactoryCaller) GetHistoryFactory(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _AuthorityHistoryPairFactory.contract.Call(opts, &out, "getHistoryFactory")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// GetHistoryFactory is a free data retrieval call binding the contract method 0xf16b1a5c.
+//
+// Solidity: function getHistoryFactory() view returns(address)
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactorySession) GetHistoryFactory() (common.Address, error) {
+	return _AuthorityHistoryPairFactory.Contract.GetHistoryFactory(&_AuthorityHistoryPairFactory.CallOpts)
+}
+
+// GetHistoryFactory is a free data retrieval call binding the contract method 0xf16b1a5c.
+//
+// Solidity: function getHistoryFactory() view returns(address)
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactoryCallerSession) GetHistoryFactory() (common.Address, error) {
+	return _AuthorityHistoryPairFactory.Contract.GetHistoryFactory(&_AuthorityHistoryPairFactory.CallOpts)
+}
+
+// NewAuthorityHistoryPair is a paid mutator transaction binding the contract method 0xb05cd1dc.
+//
+// Solidity: function newAuthorityHistoryPair(address _authorityOwner) returns(address authority_, address history_)
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactoryTransactor) NewAuthorityHistoryPair(opts *bind.TransactOpts, _authorityOwner common.Address) (*types.Transaction, error) {
+	return _AuthorityHistoryPairFactory.contract.Transact(opts, "newAuthorityHistoryPair", _authorityOwner)
+}
+
+// NewAuthorityHistoryPair is a paid mutator transaction binding the contract method 0xb05cd1dc.
+//
+// Solidity: function newAuthorityHistoryPair(address _authorityOwner) returns(address authority_, address history_)
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactorySession) NewAuthorityHistoryPair(_authorityOwner common.Address) (*types.Transaction, error) {
+	return _AuthorityHistoryPairFactory.Contract.NewAuthorityHistoryPair(&_AuthorityHistoryPairFactory.TransactOpts, _authorityOwner)
+}
+
+// NewAuthorityHistoryPair is a paid mutator transaction binding the contract method 0xb05cd1dc.
+//
+// Solidity: function newAuthorityHistoryPair(address _authorityOwner) returns(address authority_, address history_)
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactoryTransactorSession) NewAuthorityHistoryPair(_authorityOwner common.Address) (*types.Transaction, error) {
+	return _AuthorityHistoryPairFactory.Contract.NewAuthorityHistoryPair(&_AuthorityHistoryPairFactory.TransactOpts, _authorityOwner)
+}
+
+// NewAuthorityHistoryPair0 is a paid mutator transaction binding the contract method 0xd73f347a.
+//
+// Solidity: function newAuthorityHistoryPair(address _authorityOwner, bytes32 _salt) returns(address authority_, address history_)
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactoryTransactor) NewAuthorityHistoryPair0(opts *bind.TransactOpts, _authorityOwner common.Address, _salt [32]byte) (*types.Transaction, error) {
+	return _AuthorityHistoryPairFactory.contract.Transact(opts, "newAuthorityHistoryPair0", _authorityOwner, _salt)
+}
+
+// NewAuthorityHistoryPair0 is a paid mutator transaction binding the contract method 0xd73f347a.
+//
+// Solidity: function newAuthorityHistoryPair(address _authorityOwner, bytes32 _salt) returns(address authority_, address history_)
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactorySession) NewAuthorityHistoryPair0(_authorityOwner common.Address, _salt [32]byte) (*types.Transaction, error) {
+	return _AuthorityHistoryPairFactory.Contract.NewAuthorityHistoryPair0(&_AuthorityHistoryPairFactory.TransactOpts, _authorityOwner, _salt)
+}
+
+// NewAuthorityHistoryPair0 is a paid mutator transaction binding the contract method 0xd73f347a.
+//
+// Solidity: function newAuthorityHistoryPair(address _authorityOwner, bytes32 _salt) returns(address authority_, address history_)
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactoryTransactorSession) NewAuthorityHistoryPair0(_authorityOwner common.Address, _salt [32]byte) (*types.Transaction, error) {
+	return _AuthorityHistoryPairFactory.Contract.NewAuthorityHistoryPair0(&_AuthorityHistoryPairFactory.TransactOpts, _authorityOwner, _salt)
+}
+
+// AuthorityHistoryPairFactoryAuthorityHistoryPairFactoryCreatedIterator is returned from FilterAuthorityHistoryPairFactoryCreated and is used to iterate over the raw logs and unpacked data for AuthorityHistoryPairFactoryCreated events raised by the AuthorityHistoryPairFactory contract.
+type AuthorityHistoryPairFactoryAuthorityHistoryPairFactoryCreatedIterator struct {
+	Event *AuthorityHistoryPairFactoryAuthorityHistoryPairFactoryCreated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *AuthorityHistoryPairFactoryAuthorityHistoryPairFactoryCreatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(AuthorityHistoryPairFactoryAuthorityHistoryPairFactoryCreated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(AuthorityHistoryPairFactoryAuthorityHistoryPairFactoryCreated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *AuthorityHistoryPairFactoryAuthorityHistoryPairFactoryCreatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *AuthorityHistoryPairFactoryAuthorityHistoryPairFactoryCreatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// AuthorityHistoryPairFactoryAuthorityHistoryPairFactoryCreated represents a AuthorityHistoryPairFactoryCreated event raised by the AuthorityHistoryPairFactory contract.
+type AuthorityHistoryPairFactoryAuthorityHistoryPairFactoryCreated struct {
+	AuthorityFactory common.Address
+	HistoryFactory   common.Address
+	Raw              types.Log // Blockchain specific contextual infos
+}
+
+// FilterAuthorityHistoryPairFactoryCreated is a free log retrieval operation binding the contract event 0x030bcdc35fc24f8a53829b016647c842a9d981d1fed3783a7e282b6e6c9ea4a9.
+//
+// Solidity: event AuthorityHistoryPairFactoryCreated(address authorityFactory, address historyFactory)
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactoryFilterer) FilterAuthorityHistoryPairFactoryCreated(opts *bind.FilterOpts) (*AuthorityHistoryPairFactoryAuthorityHistoryPairFactoryCreatedIterator, error) {
+
+	logs, sub, err := _AuthorityHistoryPairFactory.contract.FilterLogs(opts, "AuthorityHistoryPairFactoryCreated")
+	if err != nil {
+		return nil, err
+	}
+	return &AuthorityHistoryPairFactoryAuthorityHistoryPairFactoryCreatedIterator{contract: _AuthorityHistoryPairFactory.contract, event: "AuthorityHistoryPairFactoryCreated", logs: logs, sub: sub}, nil
+}
+
+// WatchAuthorityHistoryPairFactoryCreated is a free log subscription operation binding the contract event 0x030bcdc35fc24f8a53829b016647c842a9d981d1fed3783a7e282b6e6c9ea4a9.
+//
+// Solidity: event AuthorityHistoryPairFactoryCreated(address authorityFactory, address historyFactory)
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactoryFilterer) WatchAuthorityHistoryPairFactoryCreated(opts *bind.WatchOpts, sink chan<- *AuthorityHistoryPairFactoryAuthorityHistoryPairFactoryCreated) (event.Subscription, error) {
+
+	logs, sub, err := _AuthorityHistoryPairFactory.contract.WatchLogs(opts, "AuthorityHistoryPairFactoryCreated")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(AuthorityHistoryPairFactoryAuthorityHistoryPairFactoryCreated)
+				if err := _AuthorityHistoryPairFactory.contract.UnpackLog(event, "AuthorityHistoryPairFactoryCreated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseAuthorityHistoryPairFactoryCreated is a log parse operation binding the contract event 0x030bcdc35fc24f8a53829b016647c842a9d981d1fed3783a7e282b6e6c9ea4a9.
+//
+// Solidity: event AuthorityHistoryPairFactoryCreated(address authorityFactory, address historyFactory)
+func (_AuthorityHistoryPairFactory *AuthorityHistoryPairFactoryFilterer) ParseAuthorityHistoryPairFactoryCreated(log types.Log) (*AuthorityHistoryPairFactoryAuthorityHistoryPairFactoryCreated, error) {
+	event := new(AuthorityHistoryPairFactoryAuthorityHistoryPairFactoryCreated)
+	if err := _AuthorityHistoryPairFactory.contract.UnpackLog(event, "AuthorityHistoryPairFactoryCreated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,233 @@
+// Code generated - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package contracts
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	ethereum "github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/event"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = errors.New
+	_ = big.NewInt
+	_ = strings.NewReader
+	_ = ethereum.NotFound
+	_ = bind.Bind
+	_ = common.Big1
+	_ = types.BloomLookup
+	_ = event.NewSubscription
+	_ = abi.ConvertType
+)
+
+// DAppAddressRelayMetaData contains all meta data concerning the DAppAddressRelay contract.
+var DAppAddressRelayMetaData = &bind.MetaData{
+	ABI: "[{\"inputs\":[{\"internalType\":\"contractIInputBox\",\"name\":\"_inputBox\",\"type\":\"address\"}],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"inputs\":[],\"name\":\"getInputBox\",\"outputs\":[{\"internalType\":\"contractIInputBox\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_dapp\",\"type\":\"address\"}],\"name\":\"relayDAppAddress\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+}
+
+// DAppAddressRelayABI is the input ABI used to generate the binding from.
+// Deprecated: Use DAppAddressRelayMetaData.ABI instead.
+var DAppAddressRelayABI = DAppAddressRelayMetaData.ABI
+
+// DAppAddressRelay is an auto generated Go binding around an Ethereum contract.
+type DAppAddressRelay struct {
+	DAppAddressRelayCaller     // Read-only binding to the contract
+	DAppAddressRelayTransactor // Write-only binding to the contract
+	DAppAddressRelayFilterer   // Log filterer for contract events
+}
+
+// DAppAddressRelayCaller is an auto generated read-only Go binding around an Ethereum contract.
+type DAppAddressRelayCaller struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// DAppAddressRelayTransactor is an auto generated write-only Go binding around an Ethereum contract.
+type DAppAddressRelayTransactor struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// DAppAddressRelayFilterer is an auto generated log filtering Go binding around an Ethereum contract events.
+type DAppAddressRelayFilterer struct {
+	contract *bind.BoundContract // Generic contract wrapper for the low level calls
+}
+
+// DAppAddressRelaySession is an auto generated Go binding around an Ethereum contract,
+// with pre-set call and transact options.
+type DAppAddressRelaySession struct {
+	Contract     *DAppAddressRelay // Generic contract binding to set the session for
+	CallOpts     bind.CallOpts     // Call options to use throughout this session
+	TransactOpts bind.TransactOpts // Transaction auth options to use throughout this session
+}
+
+// DAppAddressRelayCallerSession is an auto generated read-only Go binding around an Ethereum contract,
+// with pre-set call options.
+type DAppAddressRelayCallerSession struct {
+	Contract *DAppAddressRelayCaller // Generic contract caller binding to set the session for
+	CallOpts bind.CallOpts           // Call options to use throughout this session
+}
+
+// DAppAddressRelayTransactorSession is an auto generated write-only Go binding around an Ethereum contract,
+// with pre-set transact options.
+type DAppAddressRelayTransactorSession struct {
+	Contract     *DAppAddressRelayTransactor // Generic contract transactor binding to set the session for
+	TransactOpts bind.TransactOpts           // Transaction auth options to use throughout this session
+}
+
+// DAppAddressRelayRaw is an auto generated low-level Go binding around an Ethereum contract.
+type DAppAddressRelayRaw struct {
+	Contract *DAppAddressRelay // Generic contract binding to access the raw methods on
+}
+
+// DAppAddressRelayCallerRaw is an auto generated low-level read-only Go binding around an Ethereum contract.
+type DAppAddressRelayCallerRaw struct {
+	Contract *DAppAddressRelayCaller // Generic read-only contract binding to access the raw methods on
+}
+
+// DAppAddressRelayTransactorRaw is an auto generated low-level write-only Go binding around an Ethereum contract.
+type DAppAddressRelayTransactorRaw struct {
+	Contract *DAppAddressRelayTransactor // Generic write-only contract binding to access the raw methods on
+}
+
+// NewDAppAddressRelay creates a new instance of DAppAddressRelay, bound to a specific deployed contract.
+func NewDAppAddressRelay(address common.Address, backend bind.ContractBackend) (*DAppAddressRelay, error) {
+	contract, err := bindDAppAddressRelay(address, backend, backend, backend)
+	if err != nil {
+		return nil, err
+	}
+	return &DAppAddressRelay{DAppAddressRelayCaller: DAppAddressRelayCaller{contract: contract}, DAppAddressRelayTransactor: DAppAddressRelayTransactor{contract: contract}, DAppAddressRelayFilterer: DAppAddressRelayFilterer{contract: contract}}, nil
+}
+
+// NewDAppAddressRelayCaller creates a new read-only instance of DAppAddressRelay, bound to a specific deployed contract.
+func NewDAppAddressRelayCaller(address common.Address, caller bind.ContractCaller) (*DAppAddressRelayCaller, error) {
+	contract, err := bindDAppAddressRelay(address, caller, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &DAppAddressRelayCaller{contract: contract}, nil
+}
+
+// NewDAppAddressRelayTransactor creates a new write-only instance of DAppAddressRelay, bound to a specific deployed contract.
+func NewDAppAddressRelayTransactor(address common.Address, transactor bind.ContractTransactor) (*DAppAddressRelayTransactor, error) {
+	contract, err := bindDAppAddressRelay(address, nil, transactor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return &DAppAddressRelayTransactor{contract: contract}, nil
+}
+
+// NewDAppAddressRelayFilterer creates a new log filterer instance of DAppAddressRelay, bound to a specific deployed contract.
+func NewDAppAddressRelayFilterer(address common.Address, filterer bind.ContractFilterer) (*DAppAddressRelayFilterer, error) {
+	contract, err := bindDAppAddressRelay(address, nil, nil, filterer)
+	if err != nil {
+		return nil, err
+	}
+	return &DAppAddressRelayFilterer{contract: contract}, nil
+}
+
+// bindDAppAddressRelay binds a generic wrapper to an already deployed contract.
+func bindDAppAddressRelay(address common.Address, caller bind.ContractCaller, transactor bind.ContractTransactor, filterer bind.ContractFilterer) (*bind.BoundContract, error) {
+	parsed, err := DAppAddressRelayMetaData.GetAbi()
+	if err != nil {
+		return nil, err
+	}
+	return bind.NewBoundContract(address, *parsed, caller, transactor, filterer), nil
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_DAppAddressRelay *DAppAddressRelayRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _DAppAddressRelay.Contract.DAppAddressRelayCaller.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_DAppAddressRelay *DAppAddressRelayRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _DAppAddressRelay.Contract.DAppAddressRelayTransactor.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_DAppAddressRelay *DAppAddressRelayRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _DAppAddressRelay.Contract.DAppAddressRelayTransactor.contract.Transact(opts, method, params...)
+}
+
+// Call invokes the (constant) contract method with params as input values and
+// sets the output to result. The result type might be a single field for simple
+// returns, a slice of interfaces for anonymous returns and a struct for named
+// returns.
+func (_DAppAddressRelay *DAppAddressRelayCallerRaw) Call(opts *bind.CallOpts, result *[]interface{}, method string, params ...interface{}) error {
+	return _DAppAddressRelay.Contract.contract.Call(opts, result, method, params...)
+}
+
+// Transfer initiates a plain transaction to move funds to the contract, calling
+// its default method if one is available.
+func (_DAppAddressRelay *DAppAddressRelayTransactorRaw) Transfer(opts *bind.TransactOpts) (*types.Transaction, error) {
+	return _DAppAddressRelay.Contract.contract.Transfer(opts)
+}
+
+// Transact invokes the (paid) contract method with params as input values.
+func (_DAppAddressRelay *DAppAddressRelayTransactorRaw) Transact(opts *bind.TransactOpts, method string, params ...interface{}) (*types.Transaction, error) {
+	return _DAppAddressRelay.Contract.contract.Transact(opts, method, params...)
+}
+
+// GetInputBox is a free data retrieval call binding the contract method 0x00aace9a.
+//
+// Solidity: function getInputBox() view returns(address)
+func (_DAppAddressRelay *DAppAddressRelayCaller) GetInputBox(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _DAppAddressRelay.contract.Call(opts, &out, "getInputBox")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// GetInputBox is a free data retrieval call binding the contract method 0x00aace9a.
+//
+// Solidity: function getInputBox() view returns(address)
+func (_DAppAddressRelay *DAppAddressRelaySession) GetInputBox() (common.Address, error) {
+	return _DAppAddressRelay.Contract.GetInputBox(&_DAppAddressRelay.CallOpts)
+}
+
+// GetInputBox is a free data retrieval call binding the contract method 0x00aace9a.
+//
+// Solidity: function getInputBox() view returns(address)
+func (_DAppAddressRelay *DAppAddressRelayCallerSession) GetInputBox() (common.Address, error) {
+	return _DAppAddressRelay.Contract.GetInputBox(&_DAppAddressRelay.CallOpts)
+}
+
+// RelayDAppAddress is a paid mutator transaction binding the contract method 0x3016f49e.
+//
+// Solidity: function relayDAppAddress(address _dapp) returns()
+func (_DAppAddressRelay *DAppAddressRelayTransactor) RelayDAppAddress(opts *bind.TransactOpts, _dapp common.Address) (*types.Transaction, error) {
+	return _DAppAddressRelay.contract.Transact(opts, "relayDAppAddress", _dapp)
+}
+
+// RelayDAppAddress is a paid mutator transaction binding the contract method 0x3016f49e.
+//
+// Solidity: function relayDAppAddress(address _dapp) returns()
+func (_DAppAddressRelay *DAppAddressRelaySession) RelayDAppAddress(_dapp common.Address) (*types.Transaction, error) {
+	return _DAppAddressRelay.Contract.RelayDAppAddress(&_DAppAddressRelay.TransactOpts, _dapp)
+}
+
+// RelayDAppAddress is a paid mutator transaction binding the contract method 0x3016f49e.
+//
+// Solidity: function relayDAppAddress(address _dapp) returns()
+func (_DAppAddressRelay *DAppAddressRelayTransactorSession) RelayDAppAddress(_dapp common.Address) (*types.Transaction, error) {
+	return _DAppAddressRelay.Contract.RelayDAppAddress(&_DAppAddressRelay.TransactOpts, _dapp)
+}
@@ -3,43 +3,82 @@
 
 // This binary generates the Go bindings for the Cartesi Rollups contracts.
 // This binary should be called with `go generate` in the parent dir.
-// First, it downloads the Cartesi Rollups npm package containing the contracts.
-// Then, it generates the bindings using abi-gen.
-// Finally, it stores the bindings in the current directory.
+// First, it reads the contract artifacts from the Cartesi Rollups npm package, which it downloads
+// by default, or from a local source given by the -artifacts flag or by the
+// ROLLUPS_CONTRACTS_ARTIFACTS environment variable.
+// Then, it checks the ABI of the artifacts against the checksums pinned in checksums.txt.
+// The checksums are computed over the canonical form of the ABI, which is the only part of the
+// artifact used by the bindings, so they are the same for every source, and so are the bindings.
+// Finally, it generates the bindings using abi-gen and stores them in the current directory.
+//
+// The local source may be a tarball of the npm package or the directory of the hardhat project
+// in the rollups-contracts submodule (rollups-contracts/onchain/rollups), after building the
+// contracts with hardhat and forge.
+// The test tokens are only available in the directory, so they are skipped for the other sources.
+//
+// Flags:
+//
+//	-artifacts path      read the artifacts from a tarball or directory instead of downloading them
+//	-check               fail if a binding is not up to date instead of writing it
+//	-update-checksums    pin the checksums of the ABI of the artifacts read instead of checking them
 package main
 
 import (
 	"archive/tar"
+	"bufio"
+	"bytes"
 	"compress/gzip"
+	"crypto/sha256"
+	_ "embed"
+	"encoding/hex"
 	"encoding/json"
+	"flag"
+	"fmt"
 	"io"
 	"log"
 	"net/http"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 )
 
 const rollupsContractsUrl = "https://registry.npmjs.org/@cartesi/rollups/-/rollups-1.2.0.tgz"
-const baseContractsPath = "package/export/artifacts/contracts/"
+const artifactsEnvVar = "ROLLUPS_CONTRACTS_ARTIFACTS"
+const packagePrefix = "package/"
+const baseContractsPath = "export/artifacts/contracts/"
+const baseOpenZeppelinPath = "export/artifacts/@openzeppelin/contracts/"
+const baseForgePath = "out/"
+const checksumsFile = "generate/checksums.txt"
 const bindingPkg = "contracts"
 
+const checksumsHeader = `# SHA-256 checksums of the canonical ABI of the contract artifacts.
+# Update them with ` + "`go run ./generate -update-checksums -artifacts <path>`" + `.
+`
+
 type contractBinding struct {
 	jsonPath string
 	typeName string
 	outFile  string
+
+	// The artifact is only available in the hardhat project directory.
+	localOnly bool
 }
 
+// Bindings sorted by output file.
 var bindings = []contractBinding{
 	{
-		jsonPath: baseContractsPath + "inputs/InputBox.sol/InputBox.json",
-		typeName: "InputBox",
-		outFile:  "input_box.go",
+		jsonPath: baseContractsPath + "consensus/authority/Authority.sol/Authority.json",
+		typeName: "Authority",
+		outFile:  "authority.go",
 	},
 	{
-		jsonPath: baseContractsPath + "dapp/CartesiDAppFactory.sol/CartesiDAppFactory.json",
-		typeName: "CartesiDAppFactory",
-		outFile:  "cartesi_dapp_factory.go",
+		jsonPath: baseContractsPath +
+			"consensus/authority/AuthorityHistoryPairFactory.sol/AuthorityHistoryPairFactory.json",
+		typeName: "AuthorityHistoryPairFactory",
+		outFile:  "authority_history_pair_factory.go",
 	},
 	{
 		jsonPath: baseContractsPath + "dapp/CartesiDApp.sol/CartesiDApp.json",
@@ -47,35 +86,169 @@ var bindings = []contractBinding{
 		outFile:  "cartesi_dapp.go",
 	},
 	{
-		jsonPath: baseContractsPath + "consensus/authority/Authority.sol/Authority.json",
-		typeName: "Authority",
-		outFile:  "authority.go",
+		jsonPath: baseContractsPath + "dapp/CartesiDAppFactory.sol/CartesiDAppFactory.json",
+		typeName: "CartesiDAppFactory",
+		outFile:  "cartesi_dapp_factory.go",
+	},
+	{
+		jsonPath: baseContractsPath + "relays/DAppAddressRelay.sol/DAppAddressRelay.json",
+		typeName: "DAppAddressRelay",
+		outFile:  "dapp_address_relay.go",
+	},
+	{
+		jsonPath: baseContractsPath + "portals/ERC1155BatchPortal.sol/ERC1155BatchPortal.json",
+		typeName: "ERC1155BatchPortal",
+		outFile:  "erc1155_batch_portal.go",
+	},
+	{
+		jsonPath: baseContractsPath + "portals/ERC1155SinglePortal.sol/ERC1155SinglePortal.json",
+		typeName: "ERC1155SinglePortal",
+		outFile:  "erc1155_single_portal.go",
+	},
+	{
+		jsonPath: baseContractsPath + "portals/ERC20Portal.sol/ERC20Portal.json",
+		typeName: "ERC20Portal",
+		outFile:  "erc20_portal.go",
+	},
+	{
+		jsonPath: baseContractsPath + "portals/ERC721Portal.sol/ERC721Portal.json",
+		typeName: "ERC721Portal",
+		outFile:  "erc721_portal.go",
+	},
+	{
+		jsonPath: baseContractsPath + "portals/EtherPortal.sol/EtherPortal.json",
+		typeName: "EtherPortal",
+		outFile:  "ether_portal.go",
 	},
 	{
 		jsonPath: baseContractsPath + "history/History.sol/History.json",
 		typeName: "History",
 		outFile:  "history.go",
 	},
+	{
+		jsonPath: baseOpenZeppelinPath + "token/ERC1155/IERC1155.sol/IERC1155.json",
+		typeName: "IERC1155",
+		outFile:  "ierc1155.go",
+	},
+	{
+		jsonPath: baseOpenZeppelinPath + "token/ERC20/IERC20.sol/IERC20.json",
+		typeName: "IERC20",
+		outFile:  "ierc20.go",
+	},
+	{
+		jsonPath: baseOpenZeppelinPath + "token/ERC721/IERC721.sol/IERC721.json",
+		typeName: "IERC721",
+		outFile:  "ierc721.go",
+	},
+	{
+		jsonPath: baseContractsPath + "inputs/InputBox.sol/InputBox.json",
+		typeName: "InputBox",
+		outFile:  "input_box.go",
+	},
+	{
+		jsonPath:  baseForgePath + "SimpleBatchERC1155.sol/SimpleBatchERC1155.json",
+		typeName:  "SimpleBatchERC1155",
+		outFile:   "simple_batch_erc1155.go",
+		localOnly: true,
+	},
+	{
+		jsonPath:  baseForgePath + "SimpleERC20.sol/SimpleERC20.json",
+		typeName:  "SimpleERC20",
+		outFile:   "simple_erc20.go",
+		localOnly: true,
+	},
+	{
+		jsonPath:  baseForgePath + "SimpleERC721.sol/SimpleERC721.json",
+		typeName:  "SimpleERC721",
+		outFile:   "simple_erc721.go",
+		localOnly: true,
+	},
+	{
+		jsonPath:  baseForgePath + "SimpleSingleERC1155.sol/SimpleSingleERC1155.json",
+		typeName:  "SimpleSingleERC1155",
+		outFile:   "simple_single_erc1155.go",
+		localOnly: true,
+	},
 }
 
+//go:embed checksums.txt
+var pinnedChecksums []byte
+
 func main() {
-	contractsZip := downloadContracts(rollupsContractsUrl)
-	defer contractsZip.Close()
-	contractsTar := unzip(contractsZip)
-	defer contractsTar.Close()
+	artifacts := flag.String("artifacts", os.Getenv(artifactsEnvVar),
+		"tarball or directory with the contract artifacts")
+	check := flag.Bool("check", false, "fail if a binding is not up to date")
+	updateChecksums := flag.Bool("update-checksums", false,
+		"pin the checksums of the ABI of the artifacts")
+	flag.Parse()
 
 	files := make(map[string]bool)
 	for _, b := range bindings {
 		files[b.jsonPath] = true
 	}
-	contents := readFilesFromTar(contractsTar, files)
+	var contents map[string][]byte
+	isDir := false
+	if *artifacts == "" {
+		contractsZip := downloadContracts(rollupsContractsUrl)
+		defer contractsZip.Close()
+		contents = readFilesFromTarball(contractsZip, files)
+	} else {
+		info, err := os.Stat(*artifacts)
+		checkErr("read artifacts", err)
+		isDir = info.IsDir()
+		if isDir {
+			contents = readFilesFromDir(*artifacts, files)
+		} else {
+			contractsZip, err := os.Open(*artifacts)
+			checkErr("read artifacts", err)
+			defer contractsZip.Close()
+			contents = readFilesFromTarball(contractsZip, files)
+		}
+	}
 
+	checksums := parseChecksums(pinnedChecksums)
+	if *updateChecksums {
+		for path, content := range contents {
+			checksums[path] = abiChecksum(content)
+		}
+		writeChecksums(checksums)
+		return
+	}
+
+	outdated := false
 	for _, b := range bindings {
 		content := contents[b.jsonPath]
 		if content == nil {
+			if b.localOnly && !isDir {
+				log.Print("skipped binding ", b.outFile, ": ", b.jsonPath, " is not in the package")
+				continue
+			}
 			log.Fatal("missing contents for ", b.jsonPath)
 		}
-		generateBinding(b, content)
+		pinned, ok := checksums[b.jsonPath]
+		if !ok {
+			log.Fatalf("no checksum pinned for %v: check the artifact and pin it with "+
+				"-update-checksums", b.jsonPath)
+		}
+		if sum := abiChecksum(content); sum != pinned {
+			log.Fatalf("checksum mismatch for %v: got %v, expected %v", b.jsonPath, sum, pinned)
+		}
+		code := generateBinding(b, content)
+		if *check {
+			current, err := os.ReadFile(b.outFile)
+			if err != nil || !bytes.Equal(current, code) {
+				log.Print("binding ", b.outFile, " is not up to date")
+				outdated = true
+			}
+			continue
+		}
+		const fileMode = 0600
+		err := os.WriteFile(b.outFile, code, fileMode)
+		checkErr("write binding file", err)
+		log.Print("generated binding ", b.outFile)
+	}
+	if outdated {
+		os.Exit(1)
 	}
 }
 
@@ -99,6 +272,13 @@ func downloadContracts(url string) io.ReadCloser {
 	return response.Body
 }
 
+// Read the required files from the compressed tarball of the npm package.
+func readFilesFromTarball(r io.Reader, files map[string]bool) map[string][]byte {
+	contractsTar := unzip(r)
+	defer contractsTar.Close()
+	return readFilesFromTar(contractsTar, files)
+}
+
 // Decompress the buffer with the contracts.
 func unzip(r io.Reader) io.ReadCloser {
 	log.Print("unziping contracts")
@@ -108,7 +288,7 @@ func unzip(r io.Reader) io.ReadCloser {
 }
 
 // Read the required files from the tar.
-// Return a map with the file contents.
+// Return a map with the file contents, indexed by their path in the package.
 func readFilesFromTar(r io.Reader, files map[string]bool) map[string][]byte {
 	contents := make(map[string][]byte)
 	tarReader := tar.NewReader(r)
@@ -118,14 +298,89 @@ func readFilesFromTar(r io.Reader, files map[string]bool) map[string][]byte {
 			break // End of archive
 		}
 		checkErr("read tar", err)
-		if files[header.Name] {
-			contents[header.Name], err = io.ReadAll(tarReader)
+		name := strings.TrimPrefix(header.Name, packagePrefix)
+		if files[name] {
+			contents[name], err = io.ReadAll(tarReader)
 			checkErr("read tar", err)
 		}
 	}
 	return contents
 }
 
+// Read the required files that exist in the directory.
+// Return a map with the file contents, indexed by their path in the directory.
+func readFilesFromDir(dir string, files map[string]bool) map[string][]byte {
+	log.Print("reading contracts from ", dir)
+	contents := make(map[string][]byte)
+	for name := range files {
+		content, err := os.ReadFile(filepath.Join(dir, filepath.FromSlash(name)))
+		if os.IsNotExist(err) {
+			continue
+		}
+		checkErr("read artifact", err)
+		contents[name] = content
+	}
+	return contents
+}
+
+// Compute the SHA-256 checksum of the canonical ABI of the artifact.
+func abiChecksum(content []byte) string {
+	sum := sha256.Sum256(canonicalAbi(getAbi(content)))
+	return hex.EncodeToString(sum[:])
+}
+
+// Encode the ABI in a canonical form, which doesn't depend on the tool that built the artifact.
+// The entries are encoded without spaces and with sorted keys. Their order is kept because the
+// names of the overloaded methods in the bindings depend on it.
+func canonicalAbi(rawAbi []byte) []byte {
+	var entries []any
+	err := json.Unmarshal(rawAbi, &entries)
+	checkErr("decode abi", err)
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	err = encoder.Encode(entries)
+	checkErr("encode abi", err)
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
+
+// Parse the checksums in the format of sha256sum.
+// Return a map with the checksums, indexed by path.
+func parseChecksums(data []byte) map[string]string {
+	checksums := make(map[string]string)
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		sum, path, found := strings.Cut(line, "  ")
+		if !found {
+			log.Fatal("invalid checksum line: ", line)
+		}
+		checksums[path] = sum
+	}
+	return checksums
+}
+
+// Write the checksums in the format of sha256sum, sorted by path.
+func writeChecksums(checksums map[string]string) {
+	paths := make([]string, 0, len(checksums))
+	for path := range checksums {
+		paths = append(paths, path)
+	}
+	slices.Sort(paths)
+	var buf bytes.Buffer
+	buf.WriteString(checksumsHeader)
+	for _, path := range paths {
+		fmt.Fprintf(&buf, "%v  %v\n", checksums[path], path)
+	}
+	const fileMode = 0644
+	err := os.WriteFile(checksumsFile, buf.Bytes(), fileMode)
+	checkErr("write checksums file", err)
+	log.Print("updated ", checksumsFile)
+}
+
 // Get the .abi key from the json
 func getAbi(rawJson []byte) []byte {
 	var contents struct {
@@ -137,10 +392,12 @@ func getAbi(rawJson []byte) []byte {
 }
 
 // Generate the Go bindings for the contracts.
-func generateBinding(b contractBinding, content []byte) {
+// The output only depends on the canonical ABI of the artifact, so the artifacts with the same
+// checksum generate the same binding, whatever their source.
+func generateBinding(b contractBinding, content []byte) []byte {
 	var (
 		sigs    []map[string]string
-		abis    = []string{string(getAbi(content))}
+		abis    = []string{string(canonicalAbi(getAbi(content)))}
 		bins    = []string{""}
 		types   = []string{b.typeName}
 		libs    = make(map[string]string)
@@ -148,8 +405,5 @@ func generateBinding(b contractBinding, content []byte) {
 	)
 	code, err := bind.Bind(types, abis, bins, sigs, bindingPkg, bind.LangGo, libs, aliases)
 	checkErr("generate binding", err)
-	const fileMode = 0600
-	err = os.WriteFile(b.outFile, []byte(code), fileMode)
-	checkErr("write binding file", err)
-	log.Print("generated binding ", b.outFile)
+	return []byte(code)
 }
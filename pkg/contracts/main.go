@@ -4,6 +4,8 @@
 // This package contains the Go bindings for the Cartesi Rollups contracts.
 // These bindings are generated automatically by the `generate` binary.
 // To regenerate the bindings, run `go generate` from this directory.
+// To regenerate them offline, set ROLLUPS_CONTRACTS_ARTIFACTS to a tarball of the npm package or
+// to the built hardhat project in the rollups-contracts submodule.
 // To modify the list of contracts, edit the `generate` binary.
 package contracts
 
@@ -0,0 +1,286 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package ethutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/cartesi/rollups-node/pkg/addresses"
+	"github.com/cartesi/rollups-node/pkg/contracts"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Change made by an administrative operation to the state of a contract.
+type StateChange struct {
+	// Description of the state, such as "consensus of the CartesiDApp".
+	Name    string
+	Current string
+	New     string
+}
+
+// Administrative operation on the contracts of an application, such as moving it to a new
+// consensus. It is created with the current state of the contracts, so it can be shown before
+// it is checked with DryRun and sent with Send.
+type AdminOperation struct {
+	// Contract and method called, such as "CartesiDApp.migrateToConsensus".
+	Method string
+
+	// Arguments of the method.
+	Args []any
+
+	// Address of the contract called.
+	Contract common.Address
+
+	// Changes made to the state of the contracts.
+	Changes []StateChange
+
+	data []byte
+}
+
+func (op *AdminOperation) String() string {
+	args := make([]string, len(op.Args))
+	for i, arg := range op.Args {
+		args[i] = fmt.Sprint(arg)
+	}
+	return fmt.Sprintf("%v(%v)", op.Method, strings.Join(args, ", "))
+}
+
+// Create the operation that calls the method of the contract with the given ABI.
+func newAdminOperation(
+	contract string,
+	address common.Address,
+	metadata *bind.MetaData,
+	method string,
+	args ...any,
+) (*AdminOperation, error) {
+	parsed, err := metadata.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %v ABI: %v", contract, err)
+	}
+	data, err := parsed.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %v.%v call: %v", contract, method, err)
+	}
+	return &AdminOperation{
+		Method:   contract + "." + method,
+		Args:     args,
+		Contract: address,
+		data:     data,
+	}, nil
+}
+
+// Call the method with eth_call from the given account, without sending a transaction.
+// It returns a RevertError if the transaction would revert.
+func (op *AdminOperation) DryRun(
+	ctx context.Context,
+	client *ethclient.Client,
+	from common.Address,
+) error {
+	_, err := client.CallContract(ctx, ethereum.CallMsg{
+		From: from,
+		To:   &op.Contract,
+		Data: op.data,
+	}, nil)
+	if err != nil {
+		return asRevertError(err)
+	}
+	return nil
+}
+
+// Send the transaction that calls the method and wait for the receipt.
+// If opts is nil, it uses the default transaction options.
+func (op *AdminOperation) Send(
+	ctx context.Context,
+	client *ethclient.Client,
+	signer Signer,
+	opts *TxOptions,
+) (*types.Receipt, error) {
+	contract := bind.NewBoundContract(op.Contract, abi.ABI{}, client, client, client)
+	return sendTransaction(ctx, client, signer, big.NewInt(0), opts,
+		func(txOpts *bind.TransactOpts) (*types.Transaction, error) {
+			return contract.RawTransact(txOpts, op.data)
+		},
+	)
+}
+
+// Create the operation that moves the application in the book to a new consensus with
+// CartesiDApp.migrateToConsensus.
+func MigrateApplicationToConsensus(
+	ctx context.Context,
+	client *ethclient.Client,
+	book *addresses.Book,
+	consensus common.Address,
+) (*AdminOperation, error) {
+	dapp, err := contracts.NewCartesiDApp(book.CartesiDApp, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to CartesiDapp contract: %v", err)
+	}
+	current, err := dapp.GetConsensus(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get consensus of CartesiDApp: %v", err)
+	}
+	op, err := newAdminOperation("CartesiDApp", book.CartesiDApp, contracts.CartesiDAppMetaData,
+		"migrateToConsensus", consensus)
+	if err != nil {
+		return nil, err
+	}
+	op.Changes = []StateChange{{"consensus of the CartesiDApp", current.Hex(), consensus.Hex()}}
+	return op, nil
+}
+
+// Create the operation that sets the History of the Authority in the book with
+// Authority.setHistory.
+func SetAuthorityHistory(
+	ctx context.Context,
+	client *ethclient.Client,
+	book *addresses.Book,
+	history common.Address,
+) (*AdminOperation, error) {
+	authority, err := contracts.NewAuthority(book.AuthorityAddress, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to Authority contract: %v", err)
+	}
+	current, err := authority.GetHistory(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get History of Authority: %v", err)
+	}
+	op, err := newAdminOperation("Authority", book.AuthorityAddress, contracts.AuthorityMetaData,
+		"setHistory", history)
+	if err != nil {
+		return nil, err
+	}
+	op.Changes = []StateChange{{"History of the Authority", current.Hex(), history.Hex()}}
+	return op, nil
+}
+
+// Create the operation that moves the History in the book to a new consensus, which becomes
+// its owner and may submit claims.
+// If the History is owned by the Authority in the book, the operation calls
+// Authority.migrateHistoryToConsensus; else, it calls History.migrateToConsensus.
+func MigrateHistoryToConsensus(
+	ctx context.Context,
+	client *ethclient.Client,
+	book *addresses.Book,
+	consensus common.Address,
+) (*AdminOperation, error) {
+	history, err := contracts.NewHistory(book.HistoryAddress, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to History contract: %v", err)
+	}
+	current, err := history.Owner(&bind.CallOpts{Context: ctx})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owner of History: %v", err)
+	}
+	var op *AdminOperation
+	if current == book.AuthorityAddress {
+		op, err = newAdminOperation("Authority", book.AuthorityAddress,
+			contracts.AuthorityMetaData, "migrateHistoryToConsensus", consensus)
+	} else {
+		op, err = newAdminOperation("History", book.HistoryAddress, contracts.HistoryMetaData,
+			"migrateToConsensus", consensus)
+	}
+	if err != nil {
+		return nil, err
+	}
+	op.Changes = []StateChange{{"consensus of the History", current.Hex(), consensus.Hex()}}
+	return op, nil
+}
+
+// Contracts of an application that have an owner.
+var ownedContracts = map[string]*bind.MetaData{
+	"CartesiDApp": contracts.CartesiDAppMetaData,
+	"Authority":   contracts.AuthorityMetaData,
+	"History":     contracts.HistoryMetaData,
+}
+
+// Create the operation that transfers the ownership of the contract with transferOwnership.
+// The contract is CartesiDApp, Authority or History.
+func TransferOwnership(
+	ctx context.Context,
+	client *ethclient.Client,
+	contract string,
+	address common.Address,
+	newOwner common.Address,
+) (*AdminOperation, error) {
+	metadata, ok := ownedContracts[contract]
+	if !ok {
+		return nil, fmt.Errorf("unknown contract %q: expected CartesiDApp, Authority or History",
+			contract)
+	}
+	if address == (common.Address{}) {
+		return nil, fmt.Errorf("%v address is not set", contract)
+	}
+	parsed, err := metadata.GetAbi()
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %v ABI: %v", contract, err)
+	}
+	bound := bind.NewBoundContract(address, *parsed, client, client, client)
+	var out []any
+	err = bound.Call(&bind.CallOpts{Context: ctx}, &out, "owner")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get owner of %v: %v", contract, err)
+	}
+	current := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+	op, err := newAdminOperation(contract, address, metadata, "transferOwnership", newOwner)
+	if err != nil {
+		return nil, err
+	}
+	op.Changes = []StateChange{{"owner of the " + contract, current.Hex(), newOwner.Hex()}}
+	return op, nil
+}
+
+// Create the operation that withdraws ether from the application in the book with
+// CartesiDApp.withdrawEther.
+// The CartesiDApp only accepts this call from itself, so it usually comes from a voucher;
+// the dry run reports the revert otherwise.
+func WithdrawEther(
+	ctx context.Context,
+	client *ethclient.Client,
+	book *addresses.Book,
+	receiver common.Address,
+	value *big.Int,
+) (*AdminOperation, error) {
+	if value.Sign() < 0 {
+		return nil, errors.New("the withdrawn value must not be negative")
+	}
+	balance, err := client.BalanceAt(ctx, book.CartesiDApp, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance of CartesiDApp: %v", err)
+	}
+	if balance.Cmp(value) < 0 {
+		return nil, fmt.Errorf("the CartesiDApp balance of %v wei is less than %v wei",
+			balance, value)
+	}
+	receiverBalance, err := client.BalanceAt(ctx, receiver, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get balance of receiver: %v", err)
+	}
+	op, err := newAdminOperation("CartesiDApp", book.CartesiDApp, contracts.CartesiDAppMetaData,
+		"withdrawEther", receiver, value)
+	if err != nil {
+		return nil, err
+	}
+	op.Changes = []StateChange{
+		{
+			"balance of the CartesiDApp in wei",
+			balance.String(),
+			new(big.Int).Sub(balance, value).String(),
+		},
+		{
+			"balance of " + receiver.Hex() + " in wei",
+			receiverBalance.String(),
+			new(big.Int).Add(receiverBalance, value).String(),
+		},
+	}
+	return op, nil
+}
@@ -0,0 +1,160 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package ethutil
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/cartesi/rollups-node/pkg/addresses"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testNewConsensus = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	testReceiver     = common.HexToAddress("0x2222222222222222222222222222222222222222")
+)
+
+// Stand-in for a blockchain node with the contracts of an application.
+// Every getter of a contract returns the same address, and calls with the revert data revert.
+type fakeAdminAPI struct {
+	returns  map[common.Address]common.Address
+	balances map[common.Address]int64
+	revert   []byte
+	calls    []map[string]any
+}
+
+func (api *fakeAdminAPI) Call(args map[string]any, _ string) (hexutil.Bytes, error) {
+	api.calls = append(api.calls, args)
+	if api.revert != nil {
+		return nil, &fakeRevert{api.revert}
+	}
+	to := common.HexToAddress(args["to"].(string))
+	return common.LeftPadBytes(api.returns[to].Bytes(), 32), nil
+}
+
+func (api *fakeAdminAPI) GetBalance(address common.Address, _ string) *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(api.balances[address]))
+}
+
+func newFakeAdminAPI(book *addresses.Book) *fakeAdminAPI {
+	return &fakeAdminAPI{
+		returns: map[common.Address]common.Address{
+			book.CartesiDApp:      book.AuthorityAddress,
+			book.AuthorityAddress: book.HistoryAddress,
+			book.HistoryAddress:   book.AuthorityAddress,
+		},
+		balances: map[common.Address]int64{
+			book.CartesiDApp: 100,
+			testReceiver:     5,
+		},
+	}
+}
+
+func selector(signature string) string {
+	return hexutil.Encode(crypto.Keccak256([]byte(signature))[:4])
+}
+
+func TestMigrateApplicationToConsensus(t *testing.T) {
+	book := addresses.GetTestBook()
+	api := newFakeAdminAPI(book)
+	client := newFakeClient(t, api)
+	ctx := context.Background()
+
+	op, err := MigrateApplicationToConsensus(ctx, client, book, testNewConsensus)
+	require.Nil(t, err)
+	require.Equal(t, "CartesiDApp.migrateToConsensus("+testNewConsensus.Hex()+")", op.String())
+	require.Equal(t, book.CartesiDApp, op.Contract)
+	require.Equal(t, []StateChange{{
+		Name:    "consensus of the CartesiDApp",
+		Current: book.AuthorityAddress.Hex(),
+		New:     testNewConsensus.Hex(),
+	}}, op.Changes)
+
+	from := common.HexToAddress("0x3333333333333333333333333333333333333333")
+	require.Nil(t, op.DryRun(ctx, client, from))
+	call := api.calls[len(api.calls)-1]
+	require.Equal(t, from, common.HexToAddress(call["from"].(string)))
+	require.Equal(t, selector("migrateToConsensus(address)")+
+		common.Bytes2Hex(common.LeftPadBytes(testNewConsensus.Bytes(), 32)), call["input"])
+
+	api.revert = encodeRevert(t, "Error(string)", "Ownable: caller is not the owner")
+	err = op.DryRun(ctx, client, from)
+	var revertErr *RevertError
+	require.ErrorAs(t, err, &revertErr)
+	require.Equal(t, "execution reverted: Ownable: caller is not the owner", err.Error())
+}
+
+func TestMigrateHistoryToConsensus(t *testing.T) {
+	book := addresses.GetTestBook()
+	api := newFakeAdminAPI(book)
+	client := newFakeClient(t, api)
+	ctx := context.Background()
+
+	// the History is owned by the Authority, so the Authority migrates it
+	op, err := MigrateHistoryToConsensus(ctx, client, book, testNewConsensus)
+	require.Nil(t, err)
+	require.Equal(t, "Authority.migrateHistoryToConsensus", op.Method)
+	require.Equal(t, book.AuthorityAddress, op.Contract)
+	require.Equal(t, book.AuthorityAddress.Hex(), op.Changes[0].Current)
+
+	api.returns[book.HistoryAddress] = testReceiver
+	op, err = MigrateHistoryToConsensus(ctx, client, book, testNewConsensus)
+	require.Nil(t, err)
+	require.Equal(t, "History.migrateToConsensus", op.Method)
+	require.Equal(t, book.HistoryAddress, op.Contract)
+}
+
+func TestSetAuthorityHistory(t *testing.T) {
+	book := addresses.GetTestBook()
+	client := newFakeClient(t, newFakeAdminAPI(book))
+
+	op, err := SetAuthorityHistory(context.Background(), client, book, testNewConsensus)
+	require.Nil(t, err)
+	require.Equal(t, "Authority.setHistory", op.Method)
+	require.Equal(t, []StateChange{{
+		Name:    "History of the Authority",
+		Current: book.HistoryAddress.Hex(),
+		New:     testNewConsensus.Hex(),
+	}}, op.Changes)
+}
+
+func TestTransferOwnership(t *testing.T) {
+	book := addresses.GetTestBook()
+	client := newFakeClient(t, newFakeAdminAPI(book))
+	ctx := context.Background()
+
+	op, err := TransferOwnership(ctx, client, "History", book.HistoryAddress, testNewConsensus)
+	require.Nil(t, err)
+	require.Equal(t, "History.transferOwnership", op.Method)
+	require.Equal(t, "owner of the History", op.Changes[0].Name)
+	require.Equal(t, book.AuthorityAddress.Hex(), op.Changes[0].Current)
+
+	_, err = TransferOwnership(ctx, client, "InputBox", book.InputBox, testNewConsensus)
+	require.ErrorContains(t, err, `unknown contract "InputBox"`)
+
+	_, err = TransferOwnership(ctx, client, "Authority", common.Address{}, testNewConsensus)
+	require.ErrorContains(t, err, "Authority address is not set")
+}
+
+func TestWithdrawEther(t *testing.T) {
+	book := addresses.GetTestBook()
+	client := newFakeClient(t, newFakeAdminAPI(book))
+	ctx := context.Background()
+
+	op, err := WithdrawEther(ctx, client, book, testReceiver, big.NewInt(30))
+	require.Nil(t, err)
+	require.Equal(t, "CartesiDApp.withdrawEther("+testReceiver.Hex()+", 30)", op.String())
+	require.Equal(t, []StateChange{
+		{Name: "balance of the CartesiDApp in wei", Current: "100", New: "70"},
+		{Name: "balance of " + testReceiver.Hex() + " in wei", Current: "5", New: "35"},
+	}, op.Changes)
+
+	_, err = WithdrawEther(ctx, client, book, testReceiver, big.NewInt(101))
+	require.ErrorContains(t, err, "the CartesiDApp balance of 100 wei is less than 101 wei")
+}
@@ -0,0 +1,207 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package ethutil
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+
+	"github.com/cartesi/rollups-node/pkg/addresses"
+	"github.com/cartesi/rollups-node/pkg/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Options of DeployApplication.
+type DeployOptions struct {
+	// Owner of the new Authority, which may submit claims and change its History.
+	AuthorityOwner common.Address
+
+	// Owner of the application, which may move it to another consensus.
+	ApplicationOwner common.Address
+
+	// Hash of the template machine of the application.
+	TemplateHash common.Hash
+
+	// Salt of the deterministic deployments, which must differ between deployments with the
+	// same owners and template hash.
+	Salt common.Hash
+
+	// If set, the application uses this consensus instead of a new Authority/History pair.
+	Consensus *common.Address
+}
+
+// Contracts of a deployment.
+type Deployment struct {
+	Authority   common.Address
+	History     common.Address
+	Application common.Address
+
+	// Whether the contracts were deployed by DeployApplication, or were already deployed with
+	// the same options.
+	NewAuthority   bool
+	NewApplication bool
+
+	// Block where the application was deployed, if it was deployed by DeployApplication.
+	BlockNumber uint64
+}
+
+// Book with the contracts of the deployment, given the book with the factories and portals.
+func (d *Deployment) Book(base *addresses.Book) *addresses.Book {
+	book := *base
+	book.AuthorityAddress = d.Authority
+	book.HistoryAddress = d.History
+	book.CartesiDApp = d.Application
+	return &book
+}
+
+// Calculate the addresses of the contracts DeployApplication deploys with the factories in the
+// book, without deploying them.
+func PredictDeployment(
+	ctx context.Context,
+	client *ethclient.Client,
+	book *addresses.Book,
+	opts *DeployOptions,
+) (*Deployment, error) {
+	callOpts := &bind.CallOpts{Context: ctx}
+	var deployment Deployment
+	if opts.Consensus != nil {
+		authority, err := contracts.NewAuthority(*opts.Consensus, client)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to Authority contract: %v", err)
+		}
+		deployment.Authority = *opts.Consensus
+		deployment.History, err = authority.GetHistory(callOpts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get History of Authority: %v", err)
+		}
+	} else {
+		pairFactory, err := contracts.NewAuthorityHistoryPairFactory(
+			book.AuthorityHistoryPairFactory, client)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to connect to AuthorityHistoryPairFactory contract: %v", err)
+		}
+		pair, err := pairFactory.CalculateAuthorityHistoryAddressPair(
+			callOpts, opts.AuthorityOwner, opts.Salt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate Authority and History addresses: %v", err)
+		}
+		deployment.Authority = pair.AuthorityAddress
+		deployment.History = pair.HistoryAddress
+	}
+	dappFactory, err := contracts.NewCartesiDAppFactory(book.CartesiDAppFactory, client)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to CartesiDAppFactory contract: %v", err)
+	}
+	deployment.Application, err = dappFactory.CalculateApplicationAddress(callOpts,
+		deployment.Authority, opts.ApplicationOwner, opts.TemplateHash, opts.Salt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to calculate application address: %v", err)
+	}
+	return &deployment, nil
+}
+
+// Deploy a new Authority/History pair, unless opts.Consensus is set, and a CartesiDApp with the
+// factories in the book.
+// The contracts are deployed deterministically, so the ones that are already deployed with the
+// same options are kept and the deployment may be resumed.
+// This function waits until each transaction is added to a block.
+// If txOpts is nil, it uses the default transaction options.
+func DeployApplication(
+	ctx context.Context,
+	client *ethclient.Client,
+	book *addresses.Book,
+	signer Signer,
+	opts *DeployOptions,
+	txOpts *TxOptions,
+) (*Deployment, error) {
+	deployment, err := PredictDeployment(ctx, client, book, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	if opts.Consensus == nil {
+		deployed, err := hasCode(ctx, client, deployment.Authority)
+		if err != nil {
+			return nil, err
+		}
+		if !deployed {
+			pairFactory, err := contracts.NewAuthorityHistoryPairFactory(
+				book.AuthorityHistoryPairFactory, client)
+			if err != nil {
+				return nil, fmt.Errorf(
+					"failed to connect to AuthorityHistoryPairFactory contract: %v", err)
+			}
+			_, err = sendTransaction(ctx, client, signer, big.NewInt(0), txOpts,
+				func(txOpts *bind.TransactOpts) (*types.Transaction, error) {
+					return pairFactory.NewAuthorityHistoryPair0(
+						txOpts, opts.AuthorityOwner, opts.Salt)
+				},
+			)
+			if err != nil {
+				return nil, err
+			}
+			deployment.NewAuthority = true
+		}
+	}
+
+	deployed, err := hasCode(ctx, client, deployment.Application)
+	if err != nil {
+		return nil, err
+	}
+	if !deployed {
+		dappFactory, err := contracts.NewCartesiDAppFactory(book.CartesiDAppFactory, client)
+		if err != nil {
+			return nil, fmt.Errorf("failed to connect to CartesiDAppFactory contract: %v", err)
+		}
+		receipt, err := sendTransaction(ctx, client, signer, big.NewInt(0), txOpts,
+			func(txOpts *bind.TransactOpts) (*types.Transaction, error) {
+				return dappFactory.NewApplication(txOpts, deployment.Authority,
+					opts.ApplicationOwner, opts.TemplateHash, opts.Salt)
+			},
+		)
+		if err != nil {
+			return nil, err
+		}
+		deployment.NewApplication = true
+		deployment.BlockNumber = receipt.BlockNumber.Uint64()
+	}
+	return deployment, nil
+}
+
+// Check whether there is a contract at the address.
+func hasCode(ctx context.Context, client *ethclient.Client, address common.Address) (bool, error) {
+	code, err := client.CodeAt(ctx, address, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to get code at %v: %v", address, err)
+	}
+	return len(code) > 0, nil
+}
+
+// Read the template hash of a machine snapshot, given the snapshot directory or its hash file.
+func ReadTemplateHash(path string) (common.Hash, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to read template hash: %v", err)
+	}
+	if info.IsDir() {
+		path = filepath.Join(path, "hash")
+	}
+	hash, err := os.ReadFile(path)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("failed to read template hash: %v", err)
+	}
+	if len(hash) != common.HashLength {
+		return common.Hash{}, fmt.Errorf(
+			"failed to read template hash: expected %v bytes in %v, but read %v",
+			common.HashLength, path, len(hash))
+	}
+	return common.BytesToHash(hash), nil
+}
@@ -0,0 +1,155 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package ethutil
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cartesi/rollups-node/pkg/addresses"
+	"github.com/cartesi/rollups-node/pkg/contracts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testOwner       = common.HexToAddress("0x4444444444444444444444444444444444444444")
+	testTemplate    = common.HexToHash("0x1234")
+	testSalt        = common.HexToHash("0x01")
+	testAuthority   = common.HexToAddress("0x5555555555555555555555555555555555555555")
+	testHistory     = common.HexToAddress("0x6666666666666666666666666666666666666666")
+	testApplication = common.HexToAddress("0x7777777777777777777777777777777777777777")
+)
+
+// Stand-in for a blockchain node with the factories of the test book.
+type fakeDeployAPI struct {
+	t     *testing.T
+	book  *addresses.Book
+	code  map[common.Address]bool
+	calls []string
+}
+
+func (api *fakeDeployAPI) GetCode(address common.Address, _ string) hexutil.Bytes {
+	if api.code[address] {
+		return hexutil.Bytes{0x60, 0x80}
+	}
+	return hexutil.Bytes{}
+}
+
+func (api *fakeDeployAPI) Call(args map[string]any, _ string) hexutil.Bytes {
+	to := common.HexToAddress(args["to"].(string))
+	input := hexutil.MustDecode(args["input"].(string))
+	switch to {
+	case api.book.AuthorityHistoryPairFactory:
+		parsed, err := contracts.AuthorityHistoryPairFactoryMetaData.GetAbi()
+		require.Nil(api.t, err)
+		method := parsed.Methods["calculateAuthorityHistoryAddressPair"]
+		values, err := method.Inputs.Unpack(input[4:])
+		require.Nil(api.t, err)
+		require.Equal(api.t, testOwner, values[0])
+		require.Equal(api.t, [32]byte(testSalt), values[1])
+		api.calls = append(api.calls, method.Name)
+		output, err := method.Outputs.Pack(testAuthority, testHistory)
+		require.Nil(api.t, err)
+		return output
+	case api.book.CartesiDAppFactory:
+		parsed, err := contracts.CartesiDAppFactoryMetaData.GetAbi()
+		require.Nil(api.t, err)
+		method := parsed.Methods["calculateApplicationAddress"]
+		values, err := method.Inputs.Unpack(input[4:])
+		require.Nil(api.t, err)
+		require.Equal(api.t, []any{testAuthority, testOwner, [32]byte(testTemplate),
+			[32]byte(testSalt)}, values)
+		api.calls = append(api.calls, method.Name)
+		return common.LeftPadBytes(testApplication.Bytes(), 32)
+	default:
+		// Authority.getHistory
+		api.calls = append(api.calls, "getHistory")
+		return common.LeftPadBytes(testHistory.Bytes(), 32)
+	}
+}
+
+func testDeployOptions() *DeployOptions {
+	return &DeployOptions{
+		AuthorityOwner:   testOwner,
+		ApplicationOwner: testOwner,
+		TemplateHash:     testTemplate,
+		Salt:             testSalt,
+	}
+}
+
+func TestPredictDeployment(t *testing.T) {
+	book := addresses.GetTestBook()
+	api := &fakeDeployAPI{t: t, book: book}
+	client := newFakeClient(t, api)
+
+	deployment, err := PredictDeployment(context.Background(), client, book, testDeployOptions())
+	require.Nil(t, err)
+	require.Equal(t, &Deployment{
+		Authority:   testAuthority,
+		History:     testHistory,
+		Application: testApplication,
+	}, deployment)
+	require.Equal(t,
+		[]string{"calculateAuthorityHistoryAddressPair", "calculateApplicationAddress"},
+		api.calls)
+
+	expected := *book
+	expected.AuthorityAddress = testAuthority
+	expected.HistoryAddress = testHistory
+	expected.CartesiDApp = testApplication
+	require.Equal(t, &expected, deployment.Book(book))
+}
+
+func TestPredictDeploymentWithConsensus(t *testing.T) {
+	book := addresses.GetTestBook()
+	api := &fakeDeployAPI{t: t, book: book}
+	client := newFakeClient(t, api)
+	opts := testDeployOptions()
+	opts.Consensus = &testAuthority
+
+	deployment, err := PredictDeployment(context.Background(), client, book, opts)
+	require.Nil(t, err)
+	require.Equal(t, testHistory, deployment.History)
+	require.Equal(t, testApplication, deployment.Application)
+	require.Equal(t, []string{"getHistory", "calculateApplicationAddress"}, api.calls)
+}
+
+func TestDeployApplicationKeepsDeployedContracts(t *testing.T) {
+	book := addresses.GetTestBook()
+	api := &fakeDeployAPI{t: t, book: book, code: map[common.Address]bool{
+		testAuthority:   true,
+		testApplication: true,
+	}}
+	client := newFakeClient(t, api)
+
+	// no transaction is sent, so no signer is needed
+	deployment, err := DeployApplication(
+		context.Background(), client, book, nil, testDeployOptions(), nil)
+	require.Nil(t, err)
+	require.False(t, deployment.NewAuthority)
+	require.False(t, deployment.NewApplication)
+	require.Equal(t, testApplication, deployment.Application)
+}
+
+func TestReadTemplateHash(t *testing.T) {
+	dir := t.TempDir()
+	hash := common.HexToHash("0xabcd")
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "hash"), hash.Bytes(), 0600))
+
+	read, err := ReadTemplateHash(dir)
+	require.Nil(t, err)
+	require.Equal(t, hash, read)
+
+	read, err = ReadTemplateHash(filepath.Join(dir, "hash"))
+	require.Nil(t, err)
+	require.Equal(t, hash, read)
+
+	require.Nil(t, os.WriteFile(filepath.Join(dir, "hash"), []byte("abcd"), 0600))
+	_, err = ReadTemplateHash(dir)
+	require.ErrorContains(t, err, "expected 32 bytes")
+}
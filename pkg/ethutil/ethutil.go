@@ -20,9 +20,6 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 )
 
-// Gas limit when sending transactions.
-const GasLimit = 30_000_000
-
 // Dev mnemonic used by Foundry/Anvil.
 const FoundryMnemonic = "test test test test test test test test test test test junk"
 
@@ -44,13 +41,26 @@ func AddInput(
 	book *addresses.Book,
 	signer Signer,
 	input []byte,
+) (int, error) {
+	return AddInputWithOptions(ctx, client, book, signer, input, nil)
+}
+
+// Same as AddInput, but sends the transaction with the given options.
+// If opts is nil, it uses the default transaction options.
+func AddInputWithOptions(
+	ctx context.Context,
+	client *ethclient.Client,
+	book *addresses.Book,
+	signer Signer,
+	input []byte,
+	opts *TxOptions,
 ) (int, error) {
 	inputBox, err := contracts.NewInputBox(book.InputBox, client)
 	if err != nil {
 		return 0, fmt.Errorf("failed to connect to InputBox contract: %v", err)
 	}
 	receipt, err := sendTransaction(
-		ctx, client, signer, big.NewInt(0), GasLimit,
+		ctx, client, signer, big.NewInt(0), opts,
 		func(txOpts *bind.TransactOpts) (*types.Transaction, error) {
 			return inputBox.AddInput(txOpts, book.CartesiDApp, input)
 		},
@@ -61,6 +71,28 @@ func AddInput(
 	return getInputIndex(ctx, client, book, inputBox, receipt)
 }
 
+// Relay the DApp address to the DApp through the DAppAddressRelay, so the DApp backend learns its
+// own address.
+// This function waits until the transaction is added to a block and return the input index.
+// If opts is nil, it uses the default transaction options.
+func RelayDAppAddress(
+	ctx context.Context,
+	client *ethclient.Client,
+	book *addresses.Book,
+	signer Signer,
+	opts *TxOptions,
+) (int, error) {
+	relay, err := contracts.NewDAppAddressRelay(book.DAppAddressRelay, client)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to DAppAddressRelay contract: %v", err)
+	}
+	return sendInputTransaction(ctx, client, book, signer, big.NewInt(0), opts,
+		func(txOpts *bind.TransactOpts) (*types.Transaction, error) {
+			return relay.RelayDAppAddress(txOpts, book.CartesiDApp)
+		},
+	)
+}
+
 // Convenience function to add an input using Foundry Mnemonic
 // This function waits until the transaction is added to a block and return the input index.
 func AddInputUsingFoundryMnemonic(
@@ -112,6 +144,27 @@ func getInputIndex(
 	return 0, fmt.Errorf("input index not found")
 }
 
+// Send a transaction that adds an input through a contract and get the input index.
+func sendInputTransaction(
+	ctx context.Context,
+	client *ethclient.Client,
+	book *addresses.Book,
+	signer Signer,
+	value *big.Int,
+	opts *TxOptions,
+	doSend func(txOpts *bind.TransactOpts) (*types.Transaction, error),
+) (int, error) {
+	inputBox, err := contracts.NewInputBox(book.InputBox, client)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to InputBox contract: %v", err)
+	}
+	receipt, err := sendTransaction(ctx, client, signer, value, opts, doSend)
+	if err != nil {
+		return 0, err
+	}
+	return getInputIndex(ctx, client, book, inputBox, receipt)
+}
+
 // Get the given input of the given DApp from the input box.
 // Return the event with the input sender and payload.
 func GetInputFromInputBox(
@@ -139,7 +192,7 @@ func GetInputFromInputBox(
 }
 
 // ValidateNotice validates the given notice for the specified Dapp.
-// It returns nil if the notice is valid and an execution-reverted error otherwise.
+// It returns nil if the notice is valid and a RevertError otherwise.
 func ValidateNotice(
 	ctx context.Context,
 	client *ethclient.Client,
@@ -156,7 +209,7 @@ func ValidateNotice(
 	response, err := dapp.ValidateNotice(&bind.CallOpts{Context: ctx}, notice, *proof)
 	_ = response
 	if err != nil {
-		return err
+		return asRevertError(err)
 	}
 
 	return nil
@@ -172,13 +225,28 @@ func ExecuteVoucher(
 	voucher []byte,
 	destination *common.Address,
 	proof *contracts.Proof,
+) (*common.Hash, error) {
+	return ExecuteVoucherWithOptions(ctx, client, book, signer, voucher, destination, proof, nil)
+}
+
+// Same as ExecuteVoucher, but sends the transaction with the given options.
+// If opts is nil, it uses the default transaction options.
+func ExecuteVoucherWithOptions(
+	ctx context.Context,
+	client *ethclient.Client,
+	book *addresses.Book,
+	signer Signer,
+	voucher []byte,
+	destination *common.Address,
+	proof *contracts.Proof,
+	opts *TxOptions,
 ) (*common.Hash, error) {
 	dapp, err := contracts.NewCartesiDApp(book.CartesiDApp, client)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to CartesiDapp contract: %v", err)
 	}
 	receipt, err := sendTransaction(
-		ctx, client, signer, big.NewInt(0), GasLimit,
+		ctx, client, signer, big.NewInt(0), opts,
 		func(txOpts *bind.TransactOpts) (*types.Transaction, error) {
 			return dapp.ExecuteVoucher(txOpts, *destination, voucher, *proof)
 		},
@@ -190,6 +258,51 @@ func ExecuteVoucher(
 	return &receipt.TxHash, nil
 }
 
+// Estimate the gas limit ExecuteVoucher would use to execute the voucher, including the margin in
+// the options, without sending the transaction.
+// If opts is nil, it uses the default transaction options.
+func EstimateVoucherExecution(
+	ctx context.Context,
+	client *ethclient.Client,
+	book *addresses.Book,
+	signer Signer,
+	voucher []byte,
+	destination *common.Address,
+	proof *contracts.Proof,
+	opts *TxOptions,
+) (uint64, error) {
+	dapp, err := contracts.NewCartesiDApp(book.CartesiDApp, client)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to CartesiDapp contract: %v", err)
+	}
+	return estimateTransaction(
+		ctx, client, signer, big.NewInt(0), opts,
+		func(txOpts *bind.TransactOpts) (*types.Transaction, error) {
+			return dapp.ExecuteVoucher(txOpts, *destination, voucher, *proof)
+		},
+	)
+}
+
+// Check whether the voucher given by the input index and its index within the input was executed.
+func WasVoucherExecuted(
+	ctx context.Context,
+	client *ethclient.Client,
+	book *addresses.Book,
+	inputIndex int,
+	voucherIndex int,
+) (bool, error) {
+	dapp, err := contracts.NewCartesiDApp(book.CartesiDApp, client)
+	if err != nil {
+		return false, fmt.Errorf("failed to connect to CartesiDapp contract: %v", err)
+	}
+	executed, err := dapp.WasVoucherExecuted(&bind.CallOpts{Context: ctx},
+		big.NewInt(int64(inputIndex)), big.NewInt(int64(voucherIndex)))
+	if err != nil {
+		return false, fmt.Errorf("failed to check voucher execution: %v", err)
+	}
+	return executed, nil
+}
+
 // Advances the Devnet timestamp
 func AdvanceDevnetTime(ctx context.Context,
 	blockchainHttpEnpoint string,
@@ -6,13 +6,18 @@ package ethutil
 import (
 	"context"
 	"io"
+	"math/big"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/cartesi/rollups-node/internal/deps"
 	"github.com/cartesi/rollups-node/pkg/addresses"
 	"github.com/cartesi/rollups-node/pkg/testutil"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/stretchr/testify/suite"
 )
@@ -75,6 +80,141 @@ func (s *EthUtilSuite) TestAddInput() {
 	s.Require().Equal(payload, event.Input)
 }
 
+func (s *EthUtilSuite) TestDepositEther() {
+	sender := common.HexToAddress("f39fd6e51aad88f6f4ce6ab8827279cfffb92266")
+	value := big.NewInt(1_000_000)
+	execLayerData := common.Hex2Bytes("deadbeef")
+
+	inputIndex, err := DepositEther(
+		s.ctx, s.client, s.book, s.signer, value, execLayerData, nil)
+	if !s.Nil(err) {
+		s.logDevnetOutput()
+		s.T().FailNow()
+	}
+
+	event, err := GetInputFromInputBox(s.client, s.book, inputIndex)
+	s.Require().Nil(err)
+	s.Require().Equal(s.book.EtherPortal, event.Sender)
+	// the portal encodes the depositor, the value and the data
+	expected := append(sender.Bytes(), common.LeftPadBytes(value.Bytes(), 32)...)
+	expected = append(expected, execLayerData...)
+	s.Require().Equal(expected, event.Input)
+}
+
+func (s *EthUtilSuite) TestDepositERC20Tokens() {
+	sender := common.HexToAddress("f39fd6e51aad88f6f4ce6ab8827279cfffb92266")
+	token := s.deployToken()
+	amount := big.NewInt(1_000_000)
+	execLayerData := common.Hex2Bytes("deadbeef")
+
+	inputIndex, err := DepositERC20Tokens(
+		s.ctx, s.client, s.book, s.signer, token, amount, execLayerData, nil)
+	if !s.Nil(err) {
+		s.logDevnetOutput()
+		s.T().FailNow()
+	}
+
+	event, err := GetInputFromInputBox(s.client, s.book, inputIndex)
+	s.Require().Nil(err)
+	s.Require().Equal(s.book.ERC20Portal, event.Sender)
+	// the portal encodes whether the transfer succeeded, the token, the depositor, the amount
+	// and the data
+	expected := []byte{1}
+	expected = append(expected, token.Bytes()...)
+	expected = append(expected, sender.Bytes()...)
+	expected = append(expected, common.LeftPadBytes(amount.Bytes(), 32)...)
+	expected = append(expected, execLayerData...)
+	s.Require().Equal(expected, event.Input)
+}
+
+func (s *EthUtilSuite) TestDepositERC721Token() {
+	sender := common.HexToAddress("f39fd6e51aad88f6f4ce6ab8827279cfffb92266")
+	token := s.deployToken()
+	tokenId := big.NewInt(7)
+	baseLayerData := common.Hex2Bytes("beef")
+	execLayerData := common.Hex2Bytes("deadbeef")
+
+	inputIndex, err := DepositERC721Token(s.ctx, s.client, s.book, s.signer, token, tokenId,
+		baseLayerData, execLayerData, nil)
+	if !s.Nil(err) {
+		s.logDevnetOutput()
+		s.T().FailNow()
+	}
+
+	event, err := GetInputFromInputBox(s.client, s.book, inputIndex)
+	s.Require().Nil(err)
+	s.Require().Equal(s.book.ERC721Portal, event.Sender)
+	// the portal encodes the token, the depositor, the token id and both data
+	expected := append(token.Bytes(), sender.Bytes()...)
+	expected = append(expected, common.LeftPadBytes(tokenId.Bytes(), 32)...)
+	expected = append(expected, s.abiEncode("bytes,bytes", baseLayerData, execLayerData)...)
+	s.Require().Equal(expected, event.Input)
+}
+
+func (s *EthUtilSuite) TestDepositSingleERC1155Token() {
+	sender := common.HexToAddress("f39fd6e51aad88f6f4ce6ab8827279cfffb92266")
+	token := s.deployToken()
+	tokenId := big.NewInt(7)
+	value := big.NewInt(100)
+	baseLayerData := common.Hex2Bytes("beef")
+	execLayerData := common.Hex2Bytes("deadbeef")
+
+	inputIndex, err := DepositSingleERC1155Token(s.ctx, s.client, s.book, s.signer, token,
+		tokenId, value, baseLayerData, execLayerData, nil)
+	if !s.Nil(err) {
+		s.logDevnetOutput()
+		s.T().FailNow()
+	}
+
+	event, err := GetInputFromInputBox(s.client, s.book, inputIndex)
+	s.Require().Nil(err)
+	s.Require().Equal(s.book.ERC1155SinglePortal, event.Sender)
+	// the portal encodes the token, the depositor, the token id, the value and both data
+	expected := append(token.Bytes(), sender.Bytes()...)
+	expected = append(expected, common.LeftPadBytes(tokenId.Bytes(), 32)...)
+	expected = append(expected, common.LeftPadBytes(value.Bytes(), 32)...)
+	expected = append(expected, s.abiEncode("bytes,bytes", baseLayerData, execLayerData)...)
+	s.Require().Equal(expected, event.Input)
+}
+
+func (s *EthUtilSuite) TestDepositBatchERC1155Token() {
+	sender := common.HexToAddress("f39fd6e51aad88f6f4ce6ab8827279cfffb92266")
+	token := s.deployToken()
+	tokenIds := []*big.Int{big.NewInt(7), big.NewInt(8)}
+	values := []*big.Int{big.NewInt(100), big.NewInt(200)}
+	baseLayerData := common.Hex2Bytes("beef")
+	execLayerData := common.Hex2Bytes("deadbeef")
+
+	inputIndex, err := DepositBatchERC1155Token(s.ctx, s.client, s.book, s.signer, token,
+		tokenIds, values, baseLayerData, execLayerData, nil)
+	if !s.Nil(err) {
+		s.logDevnetOutput()
+		s.T().FailNow()
+	}
+
+	event, err := GetInputFromInputBox(s.client, s.book, inputIndex)
+	s.Require().Nil(err)
+	s.Require().Equal(s.book.ERC1155BatchPortal, event.Sender)
+	// the portal encodes the token, the depositor, and the token ids, values and both data
+	expected := append(token.Bytes(), sender.Bytes()...)
+	expected = append(expected, s.abiEncode("uint256[],uint256[],bytes,bytes",
+		tokenIds, values, baseLayerData, execLayerData)...)
+	s.Require().Equal(expected, event.Input)
+}
+
+func (s *EthUtilSuite) TestRelayDAppAddress() {
+	inputIndex, err := RelayDAppAddress(s.ctx, s.client, s.book, s.signer, nil)
+	if !s.Nil(err) {
+		s.logDevnetOutput()
+		s.T().FailNow()
+	}
+
+	event, err := GetInputFromInputBox(s.client, s.book, inputIndex)
+	s.Require().Nil(err)
+	s.Require().Equal(s.book.DAppAddressRelay, event.Sender)
+	s.Require().Equal(s.book.CartesiDApp.Bytes(), event.Input)
+}
+
 func (s *EthUtilSuite) TestMineOneBlock() {
 	s.mineBlocks(1)
 }
@@ -93,6 +233,39 @@ func (s *EthUtilSuite) mineBlocks(numBlocks uint64) {
 	s.Require().Equal(expectedBlockNumber, blockNumber)
 }
 
+// Deploy a token that accepts every call and returns true, so the portals' transfers and the
+// approvals succeed without minting tokens. The test only checks the inputs of the portals,
+// which don't depend on the token balances.
+func (s *EthUtilSuite) deployToken() common.Address {
+	// mstore(0, 1) return(0, 32)
+	runtime := common.Hex2Bytes("600160005260206000f3")
+	// codecopy(0, 12, 10) return(0, 10), followed by the runtime code
+	code := append(common.Hex2Bytes("600a600c600039600a6000f3"), runtime...)
+
+	txOpts, err := s.signer.MakeTransactor()
+	s.Require().Nil(err)
+	txOpts.Context = s.ctx
+	address, tx, _, err := bind.DeployContract(txOpts, abi.ABI{}, code, s.client)
+	s.Require().Nil(err)
+	receipt, err := bind.WaitMined(s.ctx, s.client, tx)
+	s.Require().Nil(err)
+	s.Require().Equal(types.ReceiptStatusSuccessful, receipt.Status)
+	return address
+}
+
+// Encode the values with the comma-separated ABI types, as abi.encode does.
+func (s *EthUtilSuite) abiEncode(typeList string, values ...any) []byte {
+	var arguments abi.Arguments
+	for _, name := range strings.Split(typeList, ",") {
+		typ, err := abi.NewType(name, "", nil)
+		s.Require().Nil(err)
+		arguments = append(arguments, abi.Argument{Type: typ})
+	}
+	encoded, err := arguments.Pack(values...)
+	s.Require().Nil(err)
+	return encoded
+}
+
 // Log the output of the given container
 func (s *EthUtilSuite) logDevnetOutput() {
 	reader, err := s.deps.DevnetLogs(s.ctx)
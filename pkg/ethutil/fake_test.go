@@ -0,0 +1,23 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package ethutil
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// Return a client connected to an in-process RPC server that answers the eth_ methods with the
+// given fakes.
+func newFakeClient(t *testing.T, apis ...any) *ethclient.Client {
+	server := rpc.NewServer()
+	for _, api := range apis {
+		require.Nil(t, server.RegisterName("eth", api))
+	}
+	t.Cleanup(server.Stop)
+	return ethclient.NewClient(rpc.DialInProc(server))
+}
@@ -0,0 +1,199 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package ethutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"slices"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Percentage added to the estimated gas limit by default.
+const DefaultGasMargin = 20
+
+// Percentage the fees are raised by when replacing a transaction by default.
+// Nodes usually require at least 10%.
+const DefaultFeeBump = 20
+
+// Number of times a pending transaction is replaced by default.
+const DefaultMaxReplacements = 5
+
+const (
+	// Number of blocks in the fee history used to suggest the fees.
+	feeHistoryBlocks = 10
+
+	// Percentile of the priority fees paid in the fee history used as the tip suggestion.
+	feeHistoryPercentile = 50
+)
+
+// Set the fees of the transaction.
+func setFees(
+	ctx context.Context,
+	client *ethclient.Client,
+	opts *TxOptions,
+	txOpts *bind.TransactOpts,
+) error {
+	if opts.Legacy {
+		gasPrice, err := client.SuggestGasPrice(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get gas price: %v", err)
+		}
+		txOpts.GasPrice = minFee(gasPrice, opts.MaxFeePerGas)
+		return nil
+	}
+	gasFeeCap, gasTipCap, err := suggestFees(ctx, client, opts)
+	if err != nil {
+		return err
+	}
+	txOpts.GasFeeCap = gasFeeCap
+	txOpts.GasTipCap = gasTipCap
+	return nil
+}
+
+// Suggest the max fee and priority fee per gas of dynamic-fee transactions.
+// The max fee covers twice the base fee of the next block, so the transaction stays valid for a
+// few blocks of rising base fees.
+func suggestFees(
+	ctx context.Context,
+	client *ethclient.Client,
+	opts *TxOptions,
+) (gasFeeCap *big.Int, gasTipCap *big.Int, err error) {
+	history, err := client.FeeHistory(ctx, feeHistoryBlocks, nil,
+		[]float64{feeHistoryPercentile})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get fee history: %v", err)
+	}
+	if len(history.BaseFee) == 0 || history.BaseFee[len(history.BaseFee)-1] == nil {
+		return nil, nil, errors.New("chain does not support EIP-1559; use legacy transactions")
+	}
+	// the base fee list includes the base fee of the next block
+	baseFee := history.BaseFee[len(history.BaseFee)-1]
+
+	gasTipCap, err = client.SuggestGasTipCap(ctx)
+	if err != nil {
+		// not every provider implements eth_maxPriorityFeePerGas
+		gasTipCap = medianReward(history.Reward)
+	}
+	gasTipCap = minFee(gasTipCap, opts.MaxPriorityFeePerGas)
+
+	gasFeeCap = new(big.Int).Mul(baseFee, big.NewInt(2))
+	gasFeeCap.Add(gasFeeCap, gasTipCap)
+	if opts.MaxFeePerGas != nil {
+		if opts.MaxFeePerGas.Cmp(baseFee) < 0 {
+			return nil, nil, fmt.Errorf("base fee %v is above the max fee per gas %v",
+				baseFee, opts.MaxFeePerGas)
+		}
+		gasFeeCap = minFee(gasFeeCap, opts.MaxFeePerGas)
+		gasTipCap = minFee(gasTipCap, gasFeeCap)
+	}
+	return gasFeeCap, gasTipCap, nil
+}
+
+// Get the median of the rewards in the fee history, which has one percentile per block.
+func medianReward(rewards [][]*big.Int) *big.Int {
+	var values []*big.Int
+	for _, blockRewards := range rewards {
+		if len(blockRewards) > 0 && blockRewards[0] != nil {
+			values = append(values, blockRewards[0])
+		}
+	}
+	if len(values) == 0 {
+		return new(big.Int)
+	}
+	slices.SortFunc(values, func(a, b *big.Int) int { return a.Cmp(b) })
+	return values[len(values)/2]
+}
+
+// Get the smallest fee, ignoring a nil ceiling.
+func minFee(fee *big.Int, ceiling *big.Int) *big.Int {
+	if ceiling != nil && fee.Cmp(ceiling) > 0 {
+		return new(big.Int).Set(ceiling)
+	}
+	return fee
+}
+
+// Raise the fees of the transaction to replace it.
+// Returns false if the new fees would exceed the max fee per gas or the max priority fee per gas.
+// Nodes only accept a replacement that raises both fees, so the tip isn't clamped to its
+// ceiling, which would leave the transaction as it is.
+func bumpFees(opts *TxOptions, txOpts *bind.TransactOpts) bool {
+	bump := opts.FeeBump
+	if bump == 0 {
+		bump = DefaultFeeBump
+	}
+	raise := func(fee *big.Int) *big.Int {
+		raised := new(big.Int).Mul(fee, big.NewInt(int64(100+bump)))
+		raised.Div(raised, big.NewInt(100))
+		// small fees would not change due to the rounding
+		return raised.Add(raised, big.NewInt(1))
+	}
+	exceeds := func(fee *big.Int, ceiling *big.Int) bool {
+		return ceiling != nil && fee.Cmp(ceiling) > 0
+	}
+	if txOpts.GasPrice != nil {
+		gasPrice := raise(txOpts.GasPrice)
+		if exceeds(gasPrice, opts.MaxFeePerGas) {
+			return false
+		}
+		txOpts.GasPrice = gasPrice
+		return true
+	}
+	gasFeeCap := raise(txOpts.GasFeeCap)
+	gasTipCap := raise(txOpts.GasTipCap)
+	if exceeds(gasFeeCap, opts.MaxFeePerGas) || exceeds(gasTipCap, opts.MaxPriorityFeePerGas) {
+		return false
+	}
+	txOpts.GasFeeCap = gasFeeCap
+	txOpts.GasTipCap = minFee(gasTipCap, gasFeeCap)
+	return true
+}
+
+// Estimate the gas limit of the transaction built by doSend, adding the safety margin.
+// It builds the transaction without signing or sending it.
+func estimateGas(
+	ctx context.Context,
+	client *ethclient.Client,
+	opts *TxOptions,
+	txOpts *bind.TransactOpts,
+	doSend func(txOpts *bind.TransactOpts) (*types.Transaction, error),
+) (uint64, error) {
+	probe := *txOpts
+	probe.NoSend = true
+	probe.GasLimit = 1 // prevents the binding from estimating the gas itself
+	if probe.Nonce == nil {
+		probe.Nonce = new(big.Int) // prevents the binding from getting the nonce itself
+	}
+	probe.Signer = func(_ common.Address, tx *types.Transaction) (*types.Transaction, error) {
+		return tx, nil
+	}
+	tx, err := doSend(&probe)
+	if err != nil {
+		return 0, err
+	}
+	gas, err := client.EstimateGas(ctx, ethereum.CallMsg{
+		From:      txOpts.From,
+		To:        tx.To(),
+		GasPrice:  txOpts.GasPrice,
+		GasFeeCap: txOpts.GasFeeCap,
+		GasTipCap: txOpts.GasTipCap,
+		Value:     tx.Value(),
+		Data:      tx.Data(),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to estimate gas: %w", asRevertError(err))
+	}
+	margin := opts.GasMargin
+	if margin == 0 {
+		margin = DefaultGasMargin
+	}
+	return gas + gas*margin/100, nil
+}
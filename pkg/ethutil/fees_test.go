@@ -0,0 +1,141 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package ethutil
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/cartesi/rollups-node/pkg/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+// Stand-in for the fee and gas methods of a blockchain node.
+type fakeFeeAPI struct {
+	baseFee     int64
+	tip         int64
+	tipFails    bool
+	gasEstimate uint64
+	estimated   map[string]any
+}
+
+type feeHistoryResult struct {
+	OldestBlock  *hexutil.Big     `json:"oldestBlock"`
+	Reward       [][]*hexutil.Big `json:"reward"`
+	BaseFee      []*hexutil.Big   `json:"baseFeePerGas"`
+	GasUsedRatio []float64        `json:"gasUsedRatio"`
+}
+
+func (api *fakeFeeAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(remoteChainId)
+}
+
+func (api *fakeFeeAPI) GetTransactionCount(common.Address, string) hexutil.Uint64 {
+	return 5
+}
+
+func (api *fakeFeeAPI) GasPrice() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(api.baseFee + api.tip))
+}
+
+func (api *fakeFeeAPI) MaxPriorityFeePerGas() (*hexutil.Big, error) {
+	if api.tipFails {
+		return nil, errors.New("the method eth_maxPriorityFeePerGas does not exist")
+	}
+	return (*hexutil.Big)(big.NewInt(api.tip)), nil
+}
+
+func (api *fakeFeeAPI) FeeHistory(blocks hexutil.Uint64, _ string, _ []float64) feeHistoryResult {
+	result := feeHistoryResult{OldestBlock: (*hexutil.Big)(big.NewInt(1))}
+	for i := uint64(0); i <= uint64(blocks); i++ {
+		result.BaseFee = append(result.BaseFee, (*hexutil.Big)(big.NewInt(api.baseFee)))
+		if i < uint64(blocks) {
+			reward := (*hexutil.Big)(big.NewInt(int64(i) * api.tip))
+			result.Reward = append(result.Reward, []*hexutil.Big{reward})
+			result.GasUsedRatio = append(result.GasUsedRatio, 0.5)
+		}
+	}
+	return result
+}
+
+func (api *fakeFeeAPI) EstimateGas(args map[string]any) hexutil.Uint64 {
+	api.estimated = args
+	return hexutil.Uint64(api.gasEstimate)
+}
+
+func TestSuggestFees(t *testing.T) {
+	client := newFakeClient(t, &fakeFeeAPI{baseFee: 100, tip: 2})
+
+	feeCap, tip, err := suggestFees(context.Background(), client, &TxOptions{})
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(202), feeCap)
+	require.Equal(t, big.NewInt(2), tip)
+}
+
+func TestSuggestFeesFallsBackToFeeHistoryTips(t *testing.T) {
+	client := newFakeClient(t, &fakeFeeAPI{baseFee: 100, tip: 3, tipFails: true})
+
+	// the rewards are 0, 3, ..., 27, so the median is 15
+	feeCap, tip, err := suggestFees(context.Background(), client, &TxOptions{})
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(215), feeCap)
+	require.Equal(t, big.NewInt(15), tip)
+}
+
+func TestSuggestFeesRespectsCeilings(t *testing.T) {
+	client := newFakeClient(t, &fakeFeeAPI{baseFee: 100, tip: 20})
+
+	feeCap, tip, err := suggestFees(context.Background(), client, &TxOptions{
+		MaxFeePerGas:         big.NewInt(150),
+		MaxPriorityFeePerGas: big.NewInt(10),
+	})
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(150), feeCap)
+	require.Equal(t, big.NewInt(10), tip)
+
+	_, _, err = suggestFees(context.Background(), client, &TxOptions{
+		MaxFeePerGas: big.NewInt(99),
+	})
+	require.ErrorContains(t, err, "above the max fee per gas")
+}
+
+func TestPrepareTransaction(t *testing.T) {
+	api := &fakeFeeAPI{baseFee: 100, tip: 2, gasEstimate: 50_000}
+	client := newFakeClient(t, api)
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	signer := &KeystoreSigner{privateKey: key, chainId: remoteChainId}
+	inputBoxAddress := common.HexToAddress("0x59b22D57D4f067708AB0c00552767405926dc768")
+	inputBox, err := contracts.NewInputBox(inputBoxAddress, client)
+	require.Nil(t, err)
+	doSend := func(txOpts *bind.TransactOpts) (*types.Transaction, error) {
+		return inputBox.AddInput(txOpts, common.Address{}, []byte{0xde, 0xad})
+	}
+
+	txOpts, err := _prepareTransaction(
+		context.Background(), client, signer, big.NewInt(0), &TxOptions{}, doSend)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(5), txOpts.Nonce)
+	require.Equal(t, big.NewInt(202), txOpts.GasFeeCap)
+	require.Equal(t, big.NewInt(2), txOpts.GasTipCap)
+	require.Nil(t, txOpts.GasPrice)
+	require.Equal(t, uint64(60_000), txOpts.GasLimit)
+	require.Equal(t, inputBoxAddress.Hex(),
+		common.HexToAddress(api.estimated["to"].(string)).Hex())
+
+	txOpts, err = _prepareTransaction(
+		context.Background(), client, signer, big.NewInt(0),
+		&TxOptions{Legacy: true, GasMargin: 50}, doSend)
+	require.Nil(t, err)
+	require.Equal(t, big.NewInt(102), txOpts.GasPrice)
+	require.Nil(t, txOpts.GasFeeCap)
+	require.Equal(t, uint64(75_000), txOpts.GasLimit)
+}
@@ -0,0 +1,344 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package ethutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/cartesi/rollups-node/pkg/addresses"
+	"github.com/cartesi/rollups-node/pkg/contracts"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Max number of blocks in each request for past inputs by default.
+// Many providers limit the block range of eth_getLogs to this value.
+const DefaultInputsChunkSize = 10_000
+
+// Number of inputs sent by WatchInputs that are checked when the chain reorganizes.
+const watchedInputs = 128
+
+// Options of WatchInputs.
+// The zero value watches all the inputs, searching from the genesis block.
+type WatchInputsOptions struct {
+	// Index of the first input to send.
+	FromIndex uint64
+
+	// Block where the search for inputs starts. It must not be after the block of the input at
+	// FromIndex. Set it to the deployment block of the InputBox, or to the block of the last
+	// input received when resuming, to avoid searching the whole chain.
+	FromBlock uint64
+
+	// Max number of blocks in each request for past inputs.
+	// If zero, it uses DefaultInputsChunkSize.
+	ChunkSize uint64
+}
+
+// Error returned by WatchInputs when inputs it sent were removed from the chain by a reorg.
+// Resume watching with FromIndex set to Index and FromBlock set to BlockNumber.
+type InputsReorgError struct {
+	// Index of the first input removed from the chain.
+	Index uint64
+
+	// Block where the search for the removed inputs should restart.
+	BlockNumber uint64
+}
+
+func (e *InputsReorgError) Error() string {
+	return fmt.Sprintf("input %v was removed from the chain by a reorg; resume from block %v",
+		e.Index, e.BlockNumber)
+}
+
+// Watch the inputs of the DApp added to the InputBox.
+// It sends the past inputs, searching the chain in chunks of blocks, and then the new ones as they
+// are added, in order of index and without gaps. New inputs come from a WebSocket subscription
+// when available, or from polling otherwise.
+// It stops when the context is done or when an error occurs, such as an InputsReorgError; then
+// it sends the reason to the error channel and closes both channels.
+// If opts is nil, it uses the default options.
+func WatchInputs(
+	ctx context.Context,
+	client *ethclient.Client,
+	book *addresses.Book,
+	opts *WatchInputsOptions,
+) (<-chan *contracts.InputBoxInputAdded, <-chan error) {
+	if opts == nil {
+		opts = &WatchInputsOptions{}
+	}
+	inputs := make(chan *contracts.InputBoxInputAdded)
+	errs := make(chan error, 1)
+	go func() {
+		defer close(errs)
+		defer close(inputs)
+		inputBox, err := contracts.NewInputBox(book.InputBox, client)
+		if err != nil {
+			errs <- fmt.Errorf("failed to connect to InputBox contract: %v", err)
+			return
+		}
+		chunkSize := opts.ChunkSize
+		if chunkSize == 0 {
+			chunkSize = DefaultInputsChunkSize
+		}
+		w := &inputWatcher{
+			client:    client,
+			inputBox:  inputBox,
+			dapp:      book.CartesiDApp,
+			chunkSize: chunkSize,
+			fromBlock: opts.FromBlock,
+			next:      opts.FromIndex,
+			start:     opts.FromBlock,
+			inputs:    inputs,
+		}
+		errs <- w.run(ctx)
+	}()
+	return inputs, errs
+}
+
+// State of WatchInputs.
+type inputWatcher struct {
+	client    *ethclient.Client
+	inputBox  *contracts.InputBox
+	dapp      common.Address
+	chunkSize uint64
+	fromBlock uint64
+
+	// Index of the next input to send.
+	next uint64
+
+	// Next block to search for inputs.
+	start uint64
+
+	// Hash of the block before start, if known.
+	lastHash common.Hash
+
+	// Last inputs sent, which are checked when the chain reorganizes.
+	recent []*contracts.InputBoxInputAdded
+
+	inputs chan<- *contracts.InputBoxInputAdded
+}
+
+func (w *inputWatcher) run(ctx context.Context) error {
+	// it subscribes before searching the past inputs, so no input is missed in between
+	logs := make(chan *contracts.InputBoxInputAdded)
+	sub, err := w.inputBox.WatchInputAdded(
+		&bind.WatchOpts{Context: ctx}, logs, []common.Address{w.dapp}, nil)
+	if err == nil {
+		defer sub.Unsubscribe()
+	}
+	err = w.searchToHead(ctx)
+	if err != nil {
+		return err
+	}
+	if sub != nil {
+		err = w.follow(ctx, sub, logs)
+		if err != nil {
+			return err
+		}
+		// the subscription was dropped, so it falls back to polling
+		w.start = w.resumeBlock()
+		w.lastHash = common.Hash{}
+	}
+	return w.poll(ctx)
+}
+
+// Send the inputs from the subscription.
+// Returns nil when the subscription is dropped.
+func (w *inputWatcher) follow(
+	ctx context.Context,
+	sub ethereum.Subscription,
+	logs <-chan *contracts.InputBoxInputAdded,
+) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case <-sub.Err():
+			if cause := context.Cause(ctx); cause != nil {
+				return cause
+			}
+			return nil
+		case input := <-logs:
+			if input.Raw.Removed {
+				err := w.checkReorg(ctx)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			if input.InputIndex.Uint64() > w.next {
+				// an input is missing, such as one added by a reorg, so it searches again
+				w.start = w.resumeBlock()
+				err := w.search(ctx, input.Raw.BlockNumber)
+				if err != nil {
+					return err
+				}
+				continue
+			}
+			err := w.send(ctx, input)
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// Poll the blockchain node for new inputs.
+func (w *inputWatcher) poll(ctx context.Context) error {
+	for {
+		select {
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		case <-time.After(PollInterval):
+		}
+		err := w.checkChain(ctx)
+		if err != nil {
+			return err
+		}
+		err = w.searchToHead(ctx)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// Search the inputs up to the latest block.
+func (w *inputWatcher) searchToHead(ctx context.Context) error {
+	header, err := w.client.HeaderByNumber(ctx, nil)
+	if err != nil {
+		return w.failure(ctx, "failed to get latest block", err)
+	}
+	head := header.Number.Uint64()
+	if head < w.start {
+		return nil
+	}
+	err = w.search(ctx, head)
+	if err != nil {
+		return err
+	}
+	w.lastHash = header.Hash()
+	return nil
+}
+
+// Search the inputs from w.start to the given block, in chunks.
+func (w *inputWatcher) search(ctx context.Context, to uint64) error {
+	for w.start <= to {
+		end := min(w.start+w.chunkSize-1, to)
+		it, err := w.inputBox.FilterInputAdded(
+			&bind.FilterOpts{Start: w.start, End: &end, Context: ctx},
+			[]common.Address{w.dapp},
+			nil,
+		)
+		if err != nil {
+			return w.failure(ctx, "failed to filter inputs", err)
+		}
+		for it.Next() {
+			if it.Event.InputIndex.Uint64() > w.next {
+				it.Close()
+				return fmt.Errorf("input %v not found after block %v", w.next, w.resumeBlock())
+			}
+			err = w.send(ctx, it.Event)
+			if err != nil {
+				it.Close()
+				return err
+			}
+		}
+		err = it.Error()
+		it.Close()
+		if err != nil {
+			return w.failure(ctx, "failed to filter inputs", err)
+		}
+		w.start = end + 1
+	}
+	return nil
+}
+
+// Send the input if it is the next one. Inputs already sent are ignored.
+func (w *inputWatcher) send(ctx context.Context, input *contracts.InputBoxInputAdded) error {
+	if input.InputIndex.Uint64() < w.next {
+		return nil
+	}
+	select {
+	case w.inputs <- input:
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+	w.next++
+	w.recent = append(w.recent, input)
+	if len(w.recent) > watchedInputs {
+		w.recent = w.recent[1:]
+	}
+	return nil
+}
+
+// Check whether the last block searched is still part of the chain.
+// If not, it checks whether inputs were removed and searches again from the last input sent.
+func (w *inputWatcher) checkChain(ctx context.Context) error {
+	if w.lastHash == (common.Hash{}) || w.start == 0 {
+		return nil
+	}
+	header, err := w.client.HeaderByNumber(ctx, new(big.Int).SetUint64(w.start-1))
+	if err != nil && !errors.Is(err, ethereum.NotFound) {
+		return w.failure(ctx, "failed to get block", err)
+	}
+	if err == nil && header.Hash() == w.lastHash {
+		return nil
+	}
+	err = w.checkReorg(ctx)
+	if err != nil {
+		return err
+	}
+	w.start = w.resumeBlock()
+	w.lastHash = common.Hash{}
+	return nil
+}
+
+// Return an InputsReorgError if inputs already sent are no longer part of the chain.
+func (w *inputWatcher) checkReorg(ctx context.Context) error {
+	for i := len(w.recent) - 1; i >= 0; i-- {
+		input := w.recent[i]
+		header, err := w.client.HeaderByNumber(ctx, new(big.Int).SetUint64(input.Raw.BlockNumber))
+		if errors.Is(err, ethereum.NotFound) {
+			continue
+		}
+		if err != nil {
+			return w.failure(ctx, "failed to get block", err)
+		}
+		if header.Hash() != input.Raw.BlockHash {
+			continue
+		}
+		if i == len(w.recent)-1 {
+			return nil
+		}
+		return &InputsReorgError{
+			Index:       w.recent[i+1].InputIndex.Uint64(),
+			BlockNumber: input.Raw.BlockNumber,
+		}
+	}
+	if len(w.recent) == 0 {
+		return nil
+	}
+	return &InputsReorgError{Index: w.recent[0].InputIndex.Uint64(), BlockNumber: w.fromBlock}
+}
+
+// Block where the search for the next input should restart.
+func (w *inputWatcher) resumeBlock() uint64 {
+	if len(w.recent) == 0 {
+		return w.fromBlock
+	}
+	return w.recent[len(w.recent)-1].Raw.BlockNumber
+}
+
+// Wrap the error, unless it comes from the context being done.
+func (w *inputWatcher) failure(ctx context.Context, message string, err error) error {
+	if cause := context.Cause(ctx); cause != nil {
+		return cause
+	}
+	return fmt.Errorf("%v: %v", message, err)
+}
@@ -0,0 +1,201 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package ethutil
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cartesi/rollups-node/pkg/addresses"
+	"github.com/cartesi/rollups-node/pkg/contracts"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// Stand-in for a blockchain node with inputs in the InputBox.
+type fakeInputChain struct {
+	t      *testing.T
+	book   *addresses.Book
+	mutex  sync.Mutex
+	head   uint64
+	forks  map[uint64]byte
+	logs   []types.Log
+	ranges [][2]uint64
+}
+
+func newFakeInputChain(t *testing.T, head uint64) *fakeInputChain {
+	return &fakeInputChain{
+		t:     t,
+		book:  addresses.GetTestBook(),
+		head:  head,
+		forks: make(map[uint64]byte),
+	}
+}
+
+func (c *fakeInputChain) header(number uint64) *types.Header {
+	return fakeHeader(number, c.forks[number])
+}
+
+func (c *fakeInputChain) BlockNumber() hexutil.Uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return hexutil.Uint64(c.head)
+}
+
+func (c *fakeInputChain) GetBlockByNumber(number rpc.BlockNumber, _ bool) *types.Header {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if number < 0 {
+		return c.header(c.head)
+	}
+	if uint64(number) > c.head {
+		return nil
+	}
+	return c.header(uint64(number))
+}
+
+func (c *fakeInputChain) GetLogs(query map[string]any) []types.Log {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	from := hexutil.MustDecodeUint64(query["fromBlock"].(string))
+	to := hexutil.MustDecodeUint64(query["toBlock"].(string))
+	c.ranges = append(c.ranges, [2]uint64{from, to})
+	logs := []types.Log{}
+	for _, log := range c.logs {
+		if log.BlockNumber >= from && log.BlockNumber <= to {
+			logs = append(logs, log)
+		}
+	}
+	return logs
+}
+
+// Add an input in the given block.
+func (c *fakeInputChain) addInput(blockNumber uint64, payload []byte) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	parsed, err := contracts.InputBoxMetaData.GetAbi()
+	require.Nil(c.t, err)
+	event := parsed.Events["InputAdded"]
+	sender := common.HexToAddress("0xf39fd6e51aad88f6f4ce6ab8827279cfffb92266")
+	data, err := event.Inputs.NonIndexed().Pack(sender, payload)
+	require.Nil(c.t, err)
+	index := big.NewInt(int64(len(c.logs)))
+	c.logs = append(c.logs, types.Log{
+		Address: c.book.InputBox,
+		Topics: []common.Hash{
+			event.ID,
+			common.BytesToHash(c.book.CartesiDApp.Bytes()),
+			common.BigToHash(index),
+		},
+		Data:        data,
+		BlockNumber: blockNumber,
+		BlockHash:   c.header(blockNumber).Hash(),
+		Index:       uint(len(c.logs)),
+	})
+	c.head = max(c.head, blockNumber)
+}
+
+// Replace the blocks from the given one, removing their inputs.
+func (c *fakeInputChain) reorg(blockNumber uint64) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for number := blockNumber; number <= c.head; number++ {
+		c.forks[number]++
+	}
+	logs := c.logs[:0]
+	for _, log := range c.logs {
+		if log.BlockNumber < blockNumber {
+			logs = append(logs, log)
+		}
+	}
+	c.logs = logs
+}
+
+func receiveInput(
+	t *testing.T,
+	inputs <-chan *contracts.InputBoxInputAdded,
+) *contracts.InputBoxInputAdded {
+	select {
+	case input := <-inputs:
+		return input
+	case <-time.After(10 * PollInterval):
+		require.FailNow(t, "input not received")
+		return nil
+	}
+}
+
+func TestWatchInputs(t *testing.T) {
+	chain := newFakeInputChain(t, 25)
+	chain.addInput(3, []byte{0})
+	chain.addInput(12, []byte{1})
+	chain.addInput(12, []byte{2})
+	chain.addInput(20, []byte{3})
+	client := newFakeClient(t, chain)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	inputs, errs := WatchInputs(ctx, client, chain.book,
+		&WatchInputsOptions{FromIndex: 1, FromBlock: 2, ChunkSize: 5})
+	for index := 1; index <= 3; index++ {
+		input := receiveInput(t, inputs)
+		require.Equal(t, uint64(index), input.InputIndex.Uint64())
+		require.Equal(t, []byte{byte(index)}, input.Input)
+	}
+	chain.mutex.Lock()
+	for _, blocks := range chain.ranges {
+		require.LessOrEqual(t, blocks[1]-blocks[0], uint64(4))
+	}
+	require.Equal(t, uint64(2), chain.ranges[0][0])
+	chain.mutex.Unlock()
+
+	chain.addInput(27, []byte{4})
+	input := receiveInput(t, inputs)
+	require.Equal(t, uint64(4), input.InputIndex.Uint64())
+
+	cancel()
+	require.ErrorIs(t, <-errs, context.Canceled)
+}
+
+func TestWatchInputsDetectsReorg(t *testing.T) {
+	chain := newFakeInputChain(t, 10)
+	chain.addInput(11, []byte{0})
+	chain.addInput(12, []byte{1})
+	client := newFakeClient(t, chain)
+
+	inputs, errs := WatchInputs(context.Background(), client, chain.book,
+		&WatchInputsOptions{FromBlock: 10})
+	receiveInput(t, inputs)
+	receiveInput(t, inputs)
+	chain.reorg(12)
+
+	var reorgErr *InputsReorgError
+	require.ErrorAs(t, <-errs, &reorgErr)
+	require.Equal(t, uint64(1), reorgErr.Index)
+	require.Equal(t, uint64(11), reorgErr.BlockNumber)
+}
+
+func TestWatchInputsFindsInputsAddedByReorg(t *testing.T) {
+	chain := newFakeInputChain(t, 12)
+	chain.addInput(11, []byte{0})
+	client := newFakeClient(t, chain)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	inputs, _ := WatchInputs(ctx, client, chain.book, &WatchInputsOptions{FromBlock: 10})
+	require.Equal(t, uint64(0), receiveInput(t, inputs).InputIndex.Uint64())
+	time.Sleep(2 * PollInterval)
+	// block 12 was searched already, and the block that replaces it has an input
+	chain.reorg(12)
+	chain.addInput(12, []byte{1})
+
+	input := receiveInput(t, inputs)
+	require.Equal(t, uint64(1), input.InputIndex.Uint64())
+	require.Equal(t, uint64(12), input.Raw.BlockNumber)
+}
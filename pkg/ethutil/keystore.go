@@ -0,0 +1,85 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package ethutil
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Sign transactions using an encrypted keystore file, in the go-ethereum v3 format.
+type KeystoreSigner struct {
+	privateKey *ecdsa.PrivateKey
+	chainId    *big.Int
+}
+
+// Create a new keystore signer.
+// Decrypts the keystore with the password in the password file.
+// Uses the client to get the chain ID.
+func NewKeystoreSigner(
+	ctx context.Context,
+	client *ethclient.Client,
+	keystorePath string,
+	passwordPath string,
+) (*KeystoreSigner, error) {
+	chainId, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get chain id: %v", err)
+	}
+	password, err := ReadPasswordFile(passwordPath)
+	if err != nil {
+		return nil, err
+	}
+	privateKey, err := DecryptKeystore(keystorePath, password)
+	if err != nil {
+		return nil, err
+	}
+	signer := &KeystoreSigner{
+		privateKey: privateKey,
+		chainId:    chainId,
+	}
+	return signer, nil
+}
+
+func (s *KeystoreSigner) MakeTransactor() (*bind.TransactOpts, error) {
+	return bind.NewKeyedTransactorWithChainID(s.privateKey, s.chainId)
+}
+
+func (s *KeystoreSigner) Account() common.Address {
+	return crypto.PubkeyToAddress(s.privateKey.PublicKey)
+}
+
+// Decrypt the private key from the keystore file with the password.
+func DecryptKeystore(keystorePath string, password string) (*ecdsa.PrivateKey, error) {
+	data, err := os.ReadFile(keystorePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read keystore: %v", err)
+	}
+	key, err := keystore.DecryptKey(data, password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt keystore: %v", err)
+	}
+	return key.PrivateKey, nil
+}
+
+// Read the password from the first line of the file, as go-ethereum does.
+// The authority claimer reads CARTESI_AUTH_KEYSTORE_PASSWORD_FILE with the same rule.
+func ReadPasswordFile(path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read password file: %v", err)
+	}
+	password, _, _ := strings.Cut(string(data), "\n")
+	return strings.TrimRight(password, "\r"), nil
+}
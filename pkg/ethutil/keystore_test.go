@@ -0,0 +1,50 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package ethutil
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/keystore"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+)
+
+func writeKeystore(t *testing.T, password string) (string, *keystore.Key) {
+	privateKey, err := crypto.HexToECDSA(
+		"ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80")
+	require.Nil(t, err)
+	key := &keystore.Key{
+		Id:         uuid.New(),
+		Address:    crypto.PubkeyToAddress(privateKey.PublicKey),
+		PrivateKey: privateKey,
+	}
+	data, err := keystore.EncryptKey(key, password, keystore.LightScryptN, keystore.LightScryptP)
+	require.Nil(t, err)
+	path := filepath.Join(t.TempDir(), "keystore.json")
+	require.Nil(t, os.WriteFile(path, data, 0600))
+	return path, key
+}
+
+func TestDecryptKeystore(t *testing.T) {
+	path, key := writeKeystore(t, "p@ssw0rd")
+
+	privateKey, err := DecryptKeystore(path, "p@ssw0rd")
+	require.Nil(t, err)
+	require.Equal(t, key.PrivateKey, privateKey)
+
+	_, err = DecryptKeystore(path, "wrong")
+	require.ErrorContains(t, err, "failed to decrypt keystore")
+}
+
+func TestReadPasswordFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "password")
+	require.Nil(t, os.WriteFile(path, []byte("p@ss w0rd\r\nignored\n"), 0600))
+	password, err := ReadPasswordFile(path)
+	require.Nil(t, err)
+	require.Equal(t, "p@ss w0rd", password)
+}
@@ -0,0 +1,55 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package ethutil
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Assigns the nonces of the transactions locally, so the transactions of a signer can be sent
+// without waiting for the previous ones to be included in a block.
+// The first nonce of each account comes from the pending nonce of the blockchain node.
+// It is safe for concurrent use by multiple goroutines.
+type NonceManager struct {
+	client *ethclient.Client
+	mutex  sync.Mutex
+	nonces map[common.Address]uint64
+}
+
+// Create a new nonce manager.
+func NewNonceManager(client *ethclient.Client) *NonceManager {
+	return &NonceManager{
+		client: client,
+		nonces: make(map[common.Address]uint64),
+	}
+}
+
+// Get the next nonce of the account.
+func (m *NonceManager) Next(ctx context.Context, account common.Address) (uint64, error) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	nonce, ok := m.nonces[account]
+	if !ok {
+		var err error
+		nonce, err = m.client.PendingNonceAt(ctx, account)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get nonce: %v", err)
+		}
+	}
+	m.nonces[account] = nonce + 1
+	return nonce, nil
+}
+
+// Forget the nonce of the account, so the next one comes from the blockchain node again.
+// Call it after failing to send a transaction, so its nonce is reused.
+func (m *NonceManager) Reset(account common.Address) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	delete(m.nonces, account)
+}
@@ -0,0 +1,216 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package ethutil
+
+import (
+	"context"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cartesi/rollups-node/pkg/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// Stand-in for a blockchain node that mines a block for each transaction it receives and includes
+// the n-th one.
+type fakeTxAPI struct {
+	*fakeFeeAPI
+	includeNth int
+	mutex      sync.Mutex
+	sent       []*types.Transaction
+}
+
+func (api *fakeTxAPI) SendRawTransaction(raw hexutil.Bytes) (common.Hash, error) {
+	tx := new(types.Transaction)
+	if err := tx.UnmarshalBinary(raw); err != nil {
+		return common.Hash{}, err
+	}
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+	api.sent = append(api.sent, tx)
+	return tx.Hash(), nil
+}
+
+func (api *fakeTxAPI) BlockNumber() hexutil.Uint64 {
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+	return hexutil.Uint64(len(api.sent))
+}
+
+func (api *fakeTxAPI) GetBlockByNumber(number rpc.BlockNumber, _ bool) *types.Header {
+	return fakeHeader(uint64(number), 0)
+}
+
+func (api *fakeTxAPI) GetTransactionReceipt(hash common.Hash) *types.Receipt {
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+	if len(api.sent) < api.includeNth || api.sent[api.includeNth-1].Hash() != hash {
+		return nil
+	}
+	return fakeReceipt(hash, uint64(api.includeNth), 0)
+}
+
+func (api *fakeTxAPI) transactions() []*types.Transaction {
+	api.mutex.Lock()
+	defer api.mutex.Unlock()
+	return api.sent
+}
+
+func newTestSigner(t *testing.T) Signer {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	return &KeystoreSigner{privateKey: key, chainId: remoteChainId}
+}
+
+func TestNonceManager(t *testing.T) {
+	client := newFakeClient(t, &fakeFeeAPI{})
+	nonces := NewNonceManager(client)
+	account := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	seen := make(map[uint64]bool)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			nonce, err := nonces.Next(context.Background(), account)
+			require.Nil(t, err)
+			mutex.Lock()
+			seen[nonce] = true
+			mutex.Unlock()
+		}()
+	}
+	wg.Wait()
+	for nonce := uint64(5); nonce < 15; nonce++ {
+		require.True(t, seen[nonce], "nonce %v", nonce)
+	}
+
+	nonces.Reset(account)
+	nonce, err := nonces.Next(context.Background(), account)
+	require.Nil(t, err)
+	require.Equal(t, uint64(5), nonce)
+}
+
+func TestStuckTransactionIsReplaced(t *testing.T) {
+	api := &fakeTxAPI{
+		fakeFeeAPI: &fakeFeeAPI{baseFee: 100, tip: 10, gasEstimate: 50_000},
+		includeNth: 3,
+	}
+	client := newFakeClient(t, api)
+	inputBox, err := contracts.NewInputBox(common.Address{}, client)
+	require.Nil(t, err)
+
+	receipt, err := sendTransaction(context.Background(), client, newTestSigner(t),
+		big.NewInt(0), &TxOptions{ReplaceAfter: time.Millisecond},
+		func(txOpts *bind.TransactOpts) (*types.Transaction, error) {
+			return inputBox.AddInput(txOpts, common.Address{}, []byte{0xde, 0xad})
+		})
+	require.Nil(t, err)
+
+	sent := api.transactions()
+	require.Len(t, sent, 3)
+	require.Equal(t, sent[2].Hash(), receipt.TxHash)
+	for _, tx := range sent {
+		require.Equal(t, uint64(5), tx.Nonce())
+	}
+	require.Equal(t, big.NewInt(210), sent[0].GasFeeCap())
+	require.Equal(t, big.NewInt(253), sent[1].GasFeeCap())
+	require.Equal(t, big.NewInt(13), sent[1].GasTipCap())
+}
+
+func TestReplacementStopsAtTheMaxFee(t *testing.T) {
+	api := &fakeTxAPI{
+		fakeFeeAPI: &fakeFeeAPI{baseFee: 100, tip: 10, gasEstimate: 50_000},
+		includeNth: 1,
+	}
+	client := newFakeClient(t, api)
+	opts := &TxOptions{MaxFeePerGas: big.NewInt(220), ReplaceAfter: time.Millisecond}
+	txOpts := &bind.TransactOpts{GasFeeCap: big.NewInt(210), GasTipCap: big.NewInt(10)}
+	require.False(t, bumpFees(opts, txOpts))
+	require.Equal(t, big.NewInt(210), txOpts.GasFeeCap)
+
+	inputBox, err := contracts.NewInputBox(common.Address{}, client)
+	require.Nil(t, err)
+	_, err = sendTransaction(context.Background(), client, newTestSigner(t),
+		big.NewInt(0), opts,
+		func(txOpts *bind.TransactOpts) (*types.Transaction, error) {
+			return inputBox.AddInput(txOpts, common.Address{}, nil)
+		})
+	require.Nil(t, err)
+	require.Len(t, api.transactions(), 1)
+}
+
+func TestReplacementStopsAtTheMaxPriorityFee(t *testing.T) {
+	opts := &TxOptions{MaxPriorityFeePerGas: big.NewInt(12), ReplaceAfter: time.Millisecond}
+	txOpts := &bind.TransactOpts{GasFeeCap: big.NewInt(210), GasTipCap: big.NewInt(10)}
+	require.False(t, bumpFees(opts, txOpts))
+	require.Equal(t, big.NewInt(210), txOpts.GasFeeCap)
+	require.Equal(t, big.NewInt(10), txOpts.GasTipCap)
+
+	opts.MaxPriorityFeePerGas = big.NewInt(13)
+	require.True(t, bumpFees(opts, txOpts))
+	require.Equal(t, big.NewInt(253), txOpts.GasFeeCap)
+	require.Equal(t, big.NewInt(13), txOpts.GasTipCap)
+}
+
+func TestReplacementStopsAfterTheMaxReplacements(t *testing.T) {
+	api := &fakeTxAPI{
+		fakeFeeAPI: &fakeFeeAPI{baseFee: 100, tip: 10, gasEstimate: 50_000},
+		includeNth: 10,
+	}
+	client := newFakeClient(t, api)
+	inputBox, err := contracts.NewInputBox(common.Address{}, client)
+	require.Nil(t, err)
+
+	opts := &TxOptions{
+		ReplaceAfter:    time.Millisecond,
+		MaxReplacements: 2,
+		Timeout:         200 * time.Millisecond,
+	}
+	_, err = sendTransaction(context.Background(), client, newTestSigner(t),
+		big.NewInt(0), opts,
+		func(txOpts *bind.TransactOpts) (*types.Transaction, error) {
+			return inputBox.AddInput(txOpts, common.Address{}, nil)
+		})
+	require.ErrorIs(t, err, ErrTimeout)
+	require.Len(t, api.transactions(), 3)
+}
+
+func TestCancelTransaction(t *testing.T) {
+	api := &fakeTxAPI{fakeFeeAPI: &fakeFeeAPI{}, includeNth: 1}
+	client := newFakeClient(t, api)
+	signer := newTestSigner(t)
+	to := common.HexToAddress("0x59b22D57D4f067708AB0c00552767405926dc768")
+	stuck := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   remoteChainId,
+		Nonce:     7,
+		GasTipCap: big.NewInt(10),
+		GasFeeCap: big.NewInt(200),
+		Gas:       100_000,
+		To:        &to,
+		Data:      []byte{0xde, 0xad},
+	})
+
+	receipt, err := CancelTransaction(context.Background(), client, signer, stuck, nil)
+	require.Nil(t, err)
+
+	sent := api.transactions()
+	require.Len(t, sent, 1)
+	cancel := sent[0]
+	require.Equal(t, cancel.Hash(), receipt.TxHash)
+	require.Equal(t, uint64(7), cancel.Nonce())
+	require.Equal(t, signer.Account(), *cancel.To())
+	require.Equal(t, big.NewInt(241), cancel.GasFeeCap())
+	require.Equal(t, big.NewInt(13), cancel.GasTipCap())
+	require.Empty(t, cancel.Data())
+}
@@ -0,0 +1,235 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package ethutil
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/cartesi/rollups-node/pkg/addresses"
+	"github.com/cartesi/rollups-node/pkg/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Deposit ether in the DApp through the EtherPortal.
+// This function waits until the transaction is added to a block and returns the input index.
+// If opts is nil, it uses the default transaction options.
+func DepositEther(
+	ctx context.Context,
+	client *ethclient.Client,
+	book *addresses.Book,
+	signer Signer,
+	value *big.Int,
+	execLayerData []byte,
+	opts *TxOptions,
+) (int, error) {
+	portal, err := contracts.NewEtherPortal(book.EtherPortal, client)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to EtherPortal contract: %v", err)
+	}
+	return sendInputTransaction(ctx, client, book, signer, value, opts,
+		func(txOpts *bind.TransactOpts) (*types.Transaction, error) {
+			return portal.DepositEther(txOpts, book.CartesiDApp, execLayerData)
+		},
+	)
+}
+
+// Deposit ERC-20 tokens in the DApp through the ERC20Portal.
+// If the allowance of the portal is not enough, it first approves the portal to transfer the
+// amount.
+// This function waits until the transactions are added to a block and returns the input index.
+// If opts is nil, it uses the default transaction options.
+func DepositERC20Tokens(
+	ctx context.Context,
+	client *ethclient.Client,
+	book *addresses.Book,
+	signer Signer,
+	token common.Address,
+	amount *big.Int,
+	execLayerData []byte,
+	opts *TxOptions,
+) (int, error) {
+	erc20, err := contracts.NewIERC20(token, client)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to ERC-20 contract: %v", err)
+	}
+	allowance, err := erc20.Allowance(
+		&bind.CallOpts{Context: ctx}, signer.Account(), book.ERC20Portal)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get allowance: %v", err)
+	}
+	if allowance.Cmp(amount) < 0 {
+		_, err = sendTransaction(ctx, client, signer, big.NewInt(0), opts,
+			func(txOpts *bind.TransactOpts) (*types.Transaction, error) {
+				return erc20.Approve(txOpts, book.ERC20Portal, amount)
+			},
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to approve ERC20Portal: %w", err)
+		}
+	}
+	portal, err := contracts.NewERC20Portal(book.ERC20Portal, client)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to ERC20Portal contract: %v", err)
+	}
+	return sendInputTransaction(ctx, client, book, signer, big.NewInt(0), opts,
+		func(txOpts *bind.TransactOpts) (*types.Transaction, error) {
+			return portal.DepositERC20Tokens(
+				txOpts, token, book.CartesiDApp, amount, execLayerData)
+		},
+	)
+}
+
+// Deposit an ERC-721 token in the DApp through the ERC721Portal.
+// If the portal is not approved to transfer the token, it first approves it.
+// This function waits until the transactions are added to a block and returns the input index.
+// If opts is nil, it uses the default transaction options.
+func DepositERC721Token(
+	ctx context.Context,
+	client *ethclient.Client,
+	book *addresses.Book,
+	signer Signer,
+	token common.Address,
+	tokenId *big.Int,
+	baseLayerData []byte,
+	execLayerData []byte,
+	opts *TxOptions,
+) (int, error) {
+	erc721, err := contracts.NewIERC721(token, client)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to ERC-721 contract: %v", err)
+	}
+	callOpts := &bind.CallOpts{Context: ctx}
+	approved, err := erc721.GetApproved(callOpts, tokenId)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get approved address: %v", err)
+	}
+	approvedForAll, err := erc721.IsApprovedForAll(callOpts, signer.Account(), book.ERC721Portal)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get approval for all: %v", err)
+	}
+	if approved != book.ERC721Portal && !approvedForAll {
+		_, err = sendTransaction(ctx, client, signer, big.NewInt(0), opts,
+			func(txOpts *bind.TransactOpts) (*types.Transaction, error) {
+				return erc721.Approve(txOpts, book.ERC721Portal, tokenId)
+			},
+		)
+		if err != nil {
+			return 0, fmt.Errorf("failed to approve ERC721Portal: %w", err)
+		}
+	}
+	portal, err := contracts.NewERC721Portal(book.ERC721Portal, client)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to ERC721Portal contract: %v", err)
+	}
+	return sendInputTransaction(ctx, client, book, signer, big.NewInt(0), opts,
+		func(txOpts *bind.TransactOpts) (*types.Transaction, error) {
+			return portal.DepositERC721Token(
+				txOpts, token, book.CartesiDApp, tokenId, baseLayerData, execLayerData)
+		},
+	)
+}
+
+// Deposit an amount of a single ERC-1155 token in the DApp through the ERC1155SinglePortal.
+// If the portal is not approved to transfer the tokens of the signer, it first approves it.
+// This function waits until the transactions are added to a block and returns the input index.
+// If opts is nil, it uses the default transaction options.
+func DepositSingleERC1155Token(
+	ctx context.Context,
+	client *ethclient.Client,
+	book *addresses.Book,
+	signer Signer,
+	token common.Address,
+	tokenId *big.Int,
+	value *big.Int,
+	baseLayerData []byte,
+	execLayerData []byte,
+	opts *TxOptions,
+) (int, error) {
+	err := approveERC1155(ctx, client, signer, token, book.ERC1155SinglePortal, opts)
+	if err != nil {
+		return 0, err
+	}
+	portal, err := contracts.NewERC1155SinglePortal(book.ERC1155SinglePortal, client)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to ERC1155SinglePortal contract: %v", err)
+	}
+	return sendInputTransaction(ctx, client, book, signer, big.NewInt(0), opts,
+		func(txOpts *bind.TransactOpts) (*types.Transaction, error) {
+			return portal.DepositSingleERC1155Token(txOpts, token, book.CartesiDApp,
+				tokenId, value, baseLayerData, execLayerData)
+		},
+	)
+}
+
+// Deposit amounts of multiple ERC-1155 tokens in the DApp through the ERC1155BatchPortal.
+// If the portal is not approved to transfer the tokens of the signer, it first approves it.
+// This function waits until the transactions are added to a block and returns the input index.
+// If opts is nil, it uses the default transaction options.
+func DepositBatchERC1155Token(
+	ctx context.Context,
+	client *ethclient.Client,
+	book *addresses.Book,
+	signer Signer,
+	token common.Address,
+	tokenIds []*big.Int,
+	values []*big.Int,
+	baseLayerData []byte,
+	execLayerData []byte,
+	opts *TxOptions,
+) (int, error) {
+	if len(tokenIds) != len(values) {
+		return 0, fmt.Errorf("got %v token ids and %v values", len(tokenIds), len(values))
+	}
+	err := approveERC1155(ctx, client, signer, token, book.ERC1155BatchPortal, opts)
+	if err != nil {
+		return 0, err
+	}
+	portal, err := contracts.NewERC1155BatchPortal(book.ERC1155BatchPortal, client)
+	if err != nil {
+		return 0, fmt.Errorf("failed to connect to ERC1155BatchPortal contract: %v", err)
+	}
+	return sendInputTransaction(ctx, client, book, signer, big.NewInt(0), opts,
+		func(txOpts *bind.TransactOpts) (*types.Transaction, error) {
+			return portal.DepositBatchERC1155Token(txOpts, token, book.CartesiDApp,
+				tokenIds, values, baseLayerData, execLayerData)
+		},
+	)
+}
+
+// Approve the portal to transfer the ERC-1155 tokens of the signer, unless it already is.
+func approveERC1155(
+	ctx context.Context,
+	client *ethclient.Client,
+	signer Signer,
+	token common.Address,
+	portal common.Address,
+	opts *TxOptions,
+) error {
+	erc1155, err := contracts.NewIERC1155(token, client)
+	if err != nil {
+		return fmt.Errorf("failed to connect to ERC-1155 contract: %v", err)
+	}
+	approved, err := erc1155.IsApprovedForAll(
+		&bind.CallOpts{Context: ctx}, signer.Account(), portal)
+	if err != nil {
+		return fmt.Errorf("failed to get approval for all: %v", err)
+	}
+	if approved {
+		return nil
+	}
+	_, err = sendTransaction(ctx, client, signer, big.NewInt(0), opts,
+		func(txOpts *bind.TransactOpts) (*types.Transaction, error) {
+			return erc1155.SetApprovalForAll(txOpts, portal, true)
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to approve portal: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package ethutil
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+// JSON-RPC error code for methods the server doesn't implement.
+const methodNotFoundCode = -32601
+
+// Sign transactions with an external signer, such as Clef or Web3Signer, through JSON-RPC.
+// The private key never leaves the signer process.
+type RemoteSigner struct {
+	client  *rpc.Client
+	account common.Address
+	chainId *big.Int
+
+	// eth_signTransaction or, for Clef, account_signTransaction
+	signMethod string
+}
+
+// Arguments of the eth_signTransaction and account_signTransaction methods.
+type signTxArgs struct {
+	From                 common.Address    `json:"from"`
+	To                   *common.Address   `json:"to,omitempty"`
+	Gas                  hexutil.Uint64    `json:"gas"`
+	GasPrice             *hexutil.Big      `json:"gasPrice,omitempty"`
+	MaxFeePerGas         *hexutil.Big      `json:"maxFeePerGas,omitempty"`
+	MaxPriorityFeePerGas *hexutil.Big      `json:"maxPriorityFeePerGas,omitempty"`
+	Value                *hexutil.Big      `json:"value"`
+	Nonce                hexutil.Uint64    `json:"nonce"`
+	Data                 hexutil.Bytes     `json:"data"`
+	Input                hexutil.Bytes     `json:"input"`
+	ChainID              *hexutil.Big      `json:"chainId"`
+	AccessList           *types.AccessList `json:"accessList,omitempty"`
+}
+
+// Create a new remote signer.
+// The signer URL may be an HTTP or WebSocket URL, or the path to a Unix socket, optionally
+// prefixed by unix://.
+// If the account is nil, it uses the first account of the signer.
+// Uses the client to get the chain ID.
+func NewRemoteSigner(
+	ctx context.Context,
+	client *ethclient.Client,
+	signerURL string,
+	account *common.Address,
+) (*RemoteSigner, error) {
+	chainId, err := client.ChainID(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("get chain id: %v", err)
+	}
+	rpcClient, err := rpc.DialContext(ctx, strings.TrimPrefix(signerURL, "unix://"))
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to remote signer: %v", err)
+	}
+	signer := &RemoteSigner{
+		client:     rpcClient,
+		chainId:    chainId,
+		signMethod: "eth_signTransaction",
+	}
+
+	// Clef only implements the account namespace
+	var accounts []common.Address
+	err = rpcClient.CallContext(ctx, &accounts, "eth_accounts")
+	if isMethodNotFound(err) {
+		signer.signMethod = "account_signTransaction"
+		err = rpcClient.CallContext(ctx, &accounts, "account_list")
+	}
+	if err != nil {
+		rpcClient.Close()
+		return nil, fmt.Errorf("failed to list remote signer accounts: %v", err)
+	}
+
+	switch {
+	case account == nil && len(accounts) == 0:
+		rpcClient.Close()
+		return nil, errors.New("remote signer has no accounts")
+	case account == nil:
+		signer.account = accounts[0]
+	default:
+		signer.account = *account
+	}
+	return signer, nil
+}
+
+func (s *RemoteSigner) MakeTransactor() (*bind.TransactOpts, error) {
+	return &bind.TransactOpts{
+		From: s.account,
+		Signer: func(address common.Address, tx *types.Transaction) (*types.Transaction, error) {
+			if address != s.account {
+				return nil, bind.ErrNotAuthorized
+			}
+			// the signer may wait for a manual approval, so there is no timeout
+			return s.SignTransaction(context.Background(), tx)
+		},
+		Context: context.Background(),
+	}, nil
+}
+
+func (s *RemoteSigner) Account() common.Address {
+	return s.account
+}
+
+// Close the connection to the remote signer.
+func (s *RemoteSigner) Close() {
+	s.client.Close()
+}
+
+// Sign the transaction with the remote signer.
+// It checks whether the signed transaction matches the given one.
+func (s *RemoteSigner) SignTransaction(
+	ctx context.Context,
+	tx *types.Transaction,
+) (*types.Transaction, error) {
+	args := signTxArgs{
+		From:    s.account,
+		To:      tx.To(),
+		Gas:     hexutil.Uint64(tx.Gas()),
+		Value:   (*hexutil.Big)(tx.Value()),
+		Nonce:   hexutil.Uint64(tx.Nonce()),
+		Data:    tx.Data(),
+		Input:   tx.Data(),
+		ChainID: (*hexutil.Big)(s.chainId),
+	}
+	if tx.Type() == types.LegacyTxType {
+		args.GasPrice = (*hexutil.Big)(tx.GasPrice())
+	} else {
+		args.MaxFeePerGas = (*hexutil.Big)(tx.GasFeeCap())
+		args.MaxPriorityFeePerGas = (*hexutil.Big)(tx.GasTipCap())
+		accessList := tx.AccessList()
+		args.AccessList = &accessList
+	}
+
+	var result json.RawMessage
+	err := s.client.CallContext(ctx, &result, s.signMethod, args)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: %v", err)
+	}
+	raw, err := parseSignResult(result)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: %v", err)
+	}
+	signed := new(types.Transaction)
+	if err := signed.UnmarshalBinary(raw); err != nil {
+		return nil, fmt.Errorf("remote signer: invalid signed transaction: %v", err)
+	}
+
+	sender, err := types.Sender(types.LatestSignerForChainID(s.chainId), signed)
+	if err != nil {
+		return nil, fmt.Errorf("remote signer: invalid signature: %v", err)
+	}
+	if sender != s.account {
+		return nil, fmt.Errorf("remote signer: signed by %v instead of %v", sender, s.account)
+	}
+	if !sameTransaction(tx, signed) {
+		return nil, errors.New("remote signer: signed transaction differs from the request")
+	}
+	return signed, nil
+}
+
+// Web3Signer returns the raw transaction, while geth and Clef return it in an object.
+func parseSignResult(result json.RawMessage) (hexutil.Bytes, error) {
+	var raw hexutil.Bytes
+	if err := json.Unmarshal(result, &raw); err == nil {
+		return raw, nil
+	}
+	var object struct {
+		Raw hexutil.Bytes `json:"raw"`
+	}
+	if err := json.Unmarshal(result, &object); err != nil || object.Raw == nil {
+		return nil, errors.New("unexpected response to sign transaction")
+	}
+	return object.Raw, nil
+}
+
+// Reports whether the transactions have the same contents, ignoring the fee fields the signer
+// may fill in.
+func sameTransaction(a *types.Transaction, b *types.Transaction) bool {
+	equalTo := (a.To() == nil && b.To() == nil) ||
+		(a.To() != nil && b.To() != nil && *a.To() == *b.To())
+	return equalTo &&
+		a.Nonce() == b.Nonce() &&
+		a.Value().Cmp(b.Value()) == 0 &&
+		bytes.Equal(a.Data(), b.Data())
+}
+
+func isMethodNotFound(err error) bool {
+	var rpcErr rpc.Error
+	return errors.As(err, &rpcErr) && rpcErr.ErrorCode() == methodNotFoundCode
+}
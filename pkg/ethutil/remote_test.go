@@ -0,0 +1,156 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package ethutil
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"net"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+var remoteChainId = big.NewInt(31337)
+
+// Stand-in for an external signer, such as Web3Signer.
+type fakeSignerAPI struct {
+	key *ecdsa.PrivateKey
+}
+
+func (api *fakeSignerAPI) Accounts() []common.Address {
+	return []common.Address{crypto.PubkeyToAddress(api.key.PublicKey)}
+}
+
+func (api *fakeSignerAPI) SignTransaction(args signTxArgs) (hexutil.Bytes, error) {
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   (*big.Int)(args.ChainID),
+		Nonce:     uint64(args.Nonce),
+		GasTipCap: (*big.Int)(args.MaxPriorityFeePerGas),
+		GasFeeCap: (*big.Int)(args.MaxFeePerGas),
+		Gas:       uint64(args.Gas),
+		To:        args.To,
+		Value:     (*big.Int)(args.Value),
+		Data:      args.Data,
+	})
+	signed, err := types.SignTx(tx, types.LatestSignerForChainID(remoteChainId), api.key)
+	if err != nil {
+		return nil, err
+	}
+	return signed.MarshalBinary()
+}
+
+// Stand-in for Clef, which only implements the account namespace and returns an object.
+type fakeClefAPI struct {
+	fakeSignerAPI
+}
+
+func (api *fakeClefAPI) List() []common.Address {
+	return api.Accounts()
+}
+
+func (api *fakeClefAPI) SignTransaction(args signTxArgs) (map[string]hexutil.Bytes, error) {
+	raw, err := api.fakeSignerAPI.SignTransaction(args)
+	return map[string]hexutil.Bytes{"raw": raw}, err
+}
+
+func newFakeSigner(t *testing.T, clef bool) (*rpc.Server, *ecdsa.PrivateKey) {
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	server := rpc.NewServer()
+	if clef {
+		require.Nil(t, server.RegisterName("account", &fakeClefAPI{fakeSignerAPI{key}}))
+	} else {
+		require.Nil(t, server.RegisterName("eth", &fakeSignerAPI{key}))
+	}
+	t.Cleanup(server.Stop)
+	return server, key
+}
+
+// Answers eth_chainId on behalf of the blockchain node.
+type fakeChainIdAPI struct{}
+
+func (api *fakeChainIdAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(remoteChainId)
+}
+
+func signWithRemoteSigner(t *testing.T, signer *RemoteSigner) *types.Transaction {
+	to := common.HexToAddress("0x59b22D57D4f067708AB0c00552767405926dc768")
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   remoteChainId,
+		Nonce:     3,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(100),
+		Gas:       21000,
+		To:        &to,
+		Value:     big.NewInt(7),
+		Data:      []byte{0xde, 0xad},
+	})
+	opts, err := signer.MakeTransactor()
+	require.Nil(t, err)
+	signed, err := opts.Signer(signer.Account(), tx)
+	require.Nil(t, err)
+	require.Equal(t, tx.Nonce(), signed.Nonce())
+	require.Equal(t, tx.Data(), signed.Data())
+	return signed
+}
+
+func TestRemoteSignerOverHttp(t *testing.T) {
+	server, key := newFakeSigner(t, false)
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	signer, err := NewRemoteSigner(context.Background(), newFakeClient(t, &fakeChainIdAPI{}), httpServer.URL, nil)
+	require.Nil(t, err)
+	defer signer.Close()
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey), signer.Account())
+
+	signed := signWithRemoteSigner(t, signer)
+	sender, err := types.Sender(types.LatestSignerForChainID(remoteChainId), signed)
+	require.Nil(t, err)
+	require.Equal(t, signer.Account(), sender)
+}
+
+func TestRemoteSignerOverUnixSocket(t *testing.T) {
+	server, _ := newFakeSigner(t, true)
+	path := filepath.Join(t.TempDir(), "clef.ipc")
+	listener, err := net.Listen("unix", path)
+	require.Nil(t, err)
+	defer listener.Close()
+	go func() { _ = server.ServeListener(listener) }()
+
+	signer, err := NewRemoteSigner(context.Background(), newFakeClient(t, &fakeChainIdAPI{}), "unix://"+path, nil)
+	require.Nil(t, err)
+	defer signer.Close()
+	require.Equal(t, "account_signTransaction", signer.signMethod)
+	signWithRemoteSigner(t, signer)
+}
+
+func TestRemoteSignerRejectsOtherAccounts(t *testing.T) {
+	server, _ := newFakeSigner(t, false)
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	other := common.HexToAddress("0xf39Fd6e51aad88F6F4ce6aB8827279cffFb92266")
+	signer, err := NewRemoteSigner(context.Background(), newFakeClient(t, &fakeChainIdAPI{}), httpServer.URL, &other)
+	require.Nil(t, err)
+	defer signer.Close()
+
+	tx := types.NewTx(&types.DynamicFeeTx{
+		ChainID:   remoteChainId,
+		GasTipCap: big.NewInt(1),
+		GasFeeCap: big.NewInt(1),
+		Value:     big.NewInt(0),
+	})
+	_, err = signer.SignTransaction(context.Background(), tx)
+	require.ErrorContains(t, err, "instead of")
+}
@@ -0,0 +1,175 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package ethutil
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/cartesi/rollups-node/pkg/contracts"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	// Selector of the Error(string) revert reason.
+	errorSelector = []byte{0x08, 0xc3, 0x79, 0xa0}
+
+	// Selector of the Panic(uint256) revert reason.
+	panicSelector = []byte{0x4e, 0x48, 0x7b, 0x71}
+)
+
+// Contracts whose custom errors are decoded.
+var revertContracts = []struct {
+	name     string
+	metadata *bind.MetaData
+}{
+	{"Authority", contracts.AuthorityMetaData},
+	{"CartesiDApp", contracts.CartesiDAppMetaData},
+	{"CartesiDAppFactory", contracts.CartesiDAppFactoryMetaData},
+	{"EtherPortal", contracts.EtherPortalMetaData},
+	{"History", contracts.HistoryMetaData},
+	{"InputBox", contracts.InputBoxMetaData},
+}
+
+// Error returned when a call or transaction reverts.
+// The name is empty when the revert data does not match any known error.
+type RevertError struct {
+	// Name of the contract that declares the custom error.
+	// Empty for Error(string), Panic(uint256) and unknown errors.
+	Contract string
+
+	// Name of the error, such as VoucherReexecutionNotAllowed, Error or Panic.
+	Name string
+
+	// Decoded arguments of the error.
+	Args []any
+
+	// Raw revert data.
+	Data []byte
+}
+
+func (e *RevertError) Error() string {
+	switch {
+	case e.Name == "":
+		if len(e.Data) == 0 {
+			return "execution reverted"
+		}
+		return fmt.Sprintf("execution reverted: %v", hexutil.Encode(e.Data))
+	case e.Contract == "":
+		// Error(string) and Panic(uint256) have readable reasons
+		reason, err := abi.UnpackRevert(e.Data)
+		if err == nil {
+			return fmt.Sprintf("execution reverted: %v", reason)
+		}
+	}
+	args := make([]string, len(e.Args))
+	for i, arg := range e.Args {
+		args[i] = fmt.Sprint(arg)
+	}
+	name := e.Name
+	if e.Contract != "" {
+		name = e.Contract + "." + name
+	}
+	return fmt.Sprintf("execution reverted: %v(%v)", name, strings.Join(args, ", "))
+}
+
+// Decode the revert data against the errors of the contracts in pkg/contracts,
+// Error(string) and Panic(uint256).
+func DecodeRevert(data []byte) *RevertError {
+	revertErr := &RevertError{Data: data}
+	if len(data) < 4 {
+		return revertErr
+	}
+	selector := data[:4]
+	if bytes.Equal(selector, errorSelector) || bytes.Equal(selector, panicSelector) {
+		var arg any
+		reason, err := abi.UnpackRevert(data)
+		if err != nil {
+			return revertErr
+		}
+		if bytes.Equal(selector, errorSelector) {
+			revertErr.Name = "Error"
+			arg = reason
+		} else {
+			revertErr.Name = "Panic"
+			arg = new(big.Int).SetBytes(data[4:])
+		}
+		revertErr.Args = []any{arg}
+		return revertErr
+	}
+	for _, contract := range revertContracts {
+		parsed, err := contract.metadata.GetAbi()
+		if err != nil {
+			continue
+		}
+		for name, abiErr := range parsed.Errors {
+			if !bytes.Equal(abiErr.ID[:4], selector) {
+				continue
+			}
+			args, err := abiErr.Inputs.Unpack(data[4:])
+			if err != nil {
+				continue
+			}
+			revertErr.Contract = contract.name
+			revertErr.Name = name
+			revertErr.Args = args
+			return revertErr
+		}
+	}
+	return revertErr
+}
+
+// Get the decoded revert error if the JSON-RPC error carries revert data; else, return the error
+// unchanged.
+func asRevertError(err error) error {
+	var dataErr rpc.DataError
+	if !errors.As(err, &dataErr) {
+		return err
+	}
+	hex, ok := dataErr.ErrorData().(string)
+	if !ok {
+		return err
+	}
+	data, decodeErr := hexutil.Decode(hex)
+	if decodeErr != nil {
+		return err
+	}
+	return DecodeRevert(data)
+}
+
+// Replay the failed transaction with eth_call at the block that included it, to get the reason it
+// reverted.
+func replayTransaction(
+	ctx context.Context,
+	client *ethclient.Client,
+	tx *types.Transaction,
+	receipt *types.Receipt,
+) error {
+	from, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	if err != nil {
+		return fmt.Errorf("failed to get transaction sender: %v", err)
+	}
+	msg := ethereum.CallMsg{
+		From:  from,
+		To:    tx.To(),
+		Gas:   tx.Gas(),
+		Value: tx.Value(),
+		Data:  tx.Data(),
+	}
+	_, err = client.CallContract(ctx, msg, receipt.BlockNumber)
+	if err == nil {
+		return errors.New("replay did not revert")
+	}
+	return asRevertError(err)
+}
@@ -0,0 +1,110 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package ethutil
+
+import (
+	"context"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeRevert(t *testing.T, signature string, args ...any) []byte {
+	var arguments abi.Arguments
+	switch signature {
+	case "Error(string)":
+		arguments = abi.Arguments{{Type: abi.Type{T: abi.StringTy}}}
+	case "Panic(uint256)":
+		uint256, err := abi.NewType("uint256", "", nil)
+		require.Nil(t, err)
+		arguments = abi.Arguments{{Type: uint256}}
+	}
+	packed, err := arguments.Pack(args...)
+	require.Nil(t, err)
+	return append(crypto.Keccak256([]byte(signature))[:4], packed...)
+}
+
+func TestDecodeRevert(t *testing.T) {
+	revertErr := DecodeRevert(encodeRevert(t, "VoucherReexecutionNotAllowed()"))
+	require.Equal(t, "CartesiDApp", revertErr.Contract)
+	require.Equal(t, "VoucherReexecutionNotAllowed", revertErr.Name)
+	require.Empty(t, revertErr.Args)
+	require.Equal(t,
+		"execution reverted: CartesiDApp.VoucherReexecutionNotAllowed()", revertErr.Error())
+
+	revertErr = DecodeRevert(encodeRevert(t, "InputSizeExceedsLimit()"))
+	require.Equal(t, "InputBox", revertErr.Contract)
+	require.Equal(t, "InputSizeExceedsLimit", revertErr.Name)
+
+	revertErr = DecodeRevert(encodeRevert(t, "Error(string)", "not enough funds"))
+	require.Equal(t, "", revertErr.Contract)
+	require.Equal(t, "Error", revertErr.Name)
+	require.Equal(t, []any{"not enough funds"}, revertErr.Args)
+	require.Equal(t, "execution reverted: not enough funds", revertErr.Error())
+
+	revertErr = DecodeRevert(encodeRevert(t, "Panic(uint256)", big.NewInt(0x11)))
+	require.Equal(t, "Panic", revertErr.Name)
+	require.Equal(t, []any{big.NewInt(0x11)}, revertErr.Args)
+	require.Equal(t, "execution reverted: arithmetic underflow or overflow", revertErr.Error())
+
+	revertErr = DecodeRevert([]byte{0xde, 0xad, 0xbe, 0xef})
+	require.Equal(t, "", revertErr.Name)
+	require.Equal(t, "execution reverted: 0xdeadbeef", revertErr.Error())
+}
+
+// Error returned by a blockchain node when a call reverts.
+type fakeRevert struct {
+	data []byte
+}
+
+func (e *fakeRevert) Error() string          { return "execution reverted" }
+func (e *fakeRevert) ErrorCode() int         { return 3 }
+func (e *fakeRevert) ErrorData() interface{} { return hexutil.Encode(e.data) }
+
+// Stand-in for a blockchain node whose calls revert.
+type fakeCallAPI struct {
+	data  []byte
+	calls []map[string]any
+	block []string
+}
+
+func (api *fakeCallAPI) Call(args map[string]any, block string) (hexutil.Bytes, error) {
+	api.calls = append(api.calls, args)
+	api.block = append(api.block, block)
+	return nil, &fakeRevert{api.data}
+}
+
+func TestReplayTransaction(t *testing.T) {
+	api := &fakeCallAPI{data: encodeRevert(t, "VoucherReexecutionNotAllowed()")}
+	client := newFakeClient(t, api)
+	key, err := crypto.GenerateKey()
+	require.Nil(t, err)
+	to := common.HexToAddress("0xab7528bb862fB57E8A2BCd567a2e929a0Be56a5e")
+	tx, err := types.SignNewTx(key, types.LatestSignerForChainID(remoteChainId),
+		&types.DynamicFeeTx{
+			ChainID:   remoteChainId,
+			Gas:       100_000,
+			GasFeeCap: big.NewInt(1),
+			To:        &to,
+			Data:      []byte{0xde, 0xad},
+		})
+	require.Nil(t, err)
+	receipt := fakeReceipt(tx.Hash(), 42, 0)
+	receipt.Status = types.ReceiptStatusFailed
+
+	err = replayTransaction(context.Background(), client, tx, receipt)
+	var revertErr *RevertError
+	require.ErrorAs(t, err, &revertErr)
+	require.Equal(t, "VoucherReexecutionNotAllowed", revertErr.Name)
+	require.Equal(t, []string{"0x2a"}, api.block)
+	require.Equal(t, crypto.PubkeyToAddress(key.PublicKey).Hex(),
+		common.HexToAddress(api.calls[0]["from"].(string)).Hex())
+	require.Equal(t, "0xdead", api.calls[0]["input"])
+}
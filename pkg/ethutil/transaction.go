@@ -5,37 +5,104 @@ package ethutil
 
 import (
 	"context"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi/bind"
-	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/params"
 )
 
-const PollInterval = 100 * time.Millisecond
+// Options of the transactions sent by this package.
+// The zero value sends dynamic-fee (EIP-1559) transactions with the suggested fees.
+type TxOptions struct {
+	// Send legacy transactions with a gas price, for chains without EIP-1559.
+	// It should match the node's CARTESI_BLOCKCHAIN_IS_LEGACY.
+	Legacy bool
+
+	// If set, the max fee per gas, or the gas price of legacy transactions, never exceeds it.
+	MaxFeePerGas *big.Int
+
+	// If set, the priority fee per gas never exceeds it.
+	MaxPriorityFeePerGas *big.Int
+
+	// Percentage added to the estimated gas limit.
+	// If zero, it uses DefaultGasMargin.
+	GasMargin uint64
+
+	// If set, assigns the nonces locally instead of asking the blockchain node for each
+	// transaction, so multiple transactions of a signer can be sent concurrently.
+	Nonces *NonceManager
+
+	// If non-zero, a transaction still pending after this duration is replaced by one with
+	// higher fees. It is replaced again after the same duration until the fees would exceed the
+	// max fee per gas or the max priority fee per gas, or it was replaced MaxReplacements times.
+	ReplaceAfter time.Duration
+
+	// Number of times a pending transaction is replaced.
+	// If zero, it uses DefaultMaxReplacements.
+	MaxReplacements int
+
+	// Percentage the fees are raised by when replacing a transaction.
+	// If zero, it uses DefaultFeeBump.
+	FeeBump uint64
+
+	// Number of blocks, including the one with the transaction, to wait for before the
+	// transaction is considered confirmed.
+	// If zero, it waits only for the transaction to be included in a block.
+	Confirmations uint64
+
+	// If non-zero, waiting for the transaction fails with ErrTimeout after this duration.
+	Timeout time.Duration
+
+	// If set, waiting fails with a ReorgError when the block with the transaction is removed
+	// from the chain; else, it waits for the transaction to be included again.
+	FailOnReorg bool
+}
 
 // Prepare the transaction, send it, and wait for the receipt.
+// If opts is nil, it uses the default options.
 func sendTransaction(
 	ctx context.Context,
 	client *ethclient.Client,
 	signer Signer,
 	txValue *big.Int,
-	gasLimit uint64,
+	opts *TxOptions,
 	doSend func(txOpts *bind.TransactOpts) (*types.Transaction, error),
 ) (*types.Receipt, error) {
-	txOpts, err := _prepareTransaction(ctx, client, signer, txValue, gasLimit)
+	if opts == nil {
+		opts = &TxOptions{}
+	}
+	txOpts, err := _prepareTransaction(ctx, client, signer, txValue, opts, doSend)
 	if err != nil {
-		return nil, fmt.Errorf("failed to prepare transaction: %v", err)
+		return nil, fmt.Errorf("failed to prepare transaction: %w", err)
 	}
 	tx, err := doSend(txOpts)
 	if err != nil {
-		return nil, fmt.Errorf("failed to send dapp address: %v", err)
+		if opts.Nonces != nil {
+			opts.Nonces.Reset(signer.Account())
+		}
+		return nil, fmt.Errorf("failed to send transaction: %v", err)
 	}
-	receipt, err := _waitForTransaction(ctx, client, tx)
+	var replace func() (*types.Transaction, error)
+	if opts.ReplaceAfter > 0 {
+		maxReplacements := opts.MaxReplacements
+		if maxReplacements == 0 {
+			maxReplacements = DefaultMaxReplacements
+		}
+		replacements := 0
+		replace = func() (*types.Transaction, error) {
+			if replacements == maxReplacements || !bumpFees(opts, txOpts) {
+				return nil, nil
+			}
+			replacements++
+			return doSend(txOpts)
+		}
+	}
+	receipt, err := _waitForTransaction(ctx, client, tx, opts, replace)
 	if err != nil {
 		return nil, err
 	}
@@ -43,78 +110,127 @@ func sendTransaction(
 }
 
 // Prepare the blockchain transaction.
+// Sets the fees, the gas limit, which is estimated from the transaction built by doSend, and the
+// nonce.
 func _prepareTransaction(
 	ctx context.Context,
 	client *ethclient.Client,
 	signer Signer,
 	txValue *big.Int,
-	gasLimit uint64,
+	opts *TxOptions,
+	doSend func(txOpts *bind.TransactOpts) (*types.Transaction, error),
 ) (*bind.TransactOpts, error) {
-	nonce, err := client.PendingNonceAt(ctx, signer.Account())
+	tx, err := signer.MakeTransactor()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get nonce: %v", err)
+		return nil, fmt.Errorf("failed to create transactor: %v", err)
 	}
-	gasPrice, err := client.SuggestGasPrice(ctx)
+	tx.Value = txValue
+	tx.Context = ctx
+	err = setFees(ctx, client, opts, tx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get gas price: %v", err)
+		return nil, err
 	}
-	tx, err := signer.MakeTransactor()
+	tx.GasLimit, err = estimateGas(ctx, client, opts, tx, doSend)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create transactor: %v", err)
+		return nil, err
 	}
-	tx.Nonce = big.NewInt(int64(nonce))
-	tx.Value = txValue
-	tx.GasLimit = gasLimit
-	tx.GasPrice = gasPrice
+	// the nonce comes last, so a failure above does not skip a locally assigned nonce
+	var nonce uint64
+	if opts.Nonces != nil {
+		nonce, err = opts.Nonces.Next(ctx, signer.Account())
+	} else {
+		nonce, err = client.PendingNonceAt(ctx, signer.Account())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nonce: %v", err)
+	}
+	tx.Nonce = new(big.Int).SetUint64(nonce)
 	return tx, nil
 }
 
-// Wait for transaction to be included in a block. Return the transaction receipt.
-func _waitForTransaction(
+// Estimate the gas limit of the transaction built by doSend, including the margin, without
+// sending it.
+func estimateTransaction(
 	ctx context.Context,
 	client *ethclient.Client,
-	tx *types.Transaction,
-) (*types.Receipt, error) {
-	for {
-		_, isPending, err := client.TransactionByHash(ctx, tx.Hash())
-		if err != nil {
-			return nil, fmt.Errorf("fail to recover transaction: %v", err)
-		}
-		if !isPending {
-			break
-		}
-		select {
-		case <-ctx.Done():
-			return nil, ctx.Err()
-		case <-time.After(PollInterval):
-			continue
-		}
+	signer Signer,
+	txValue *big.Int,
+	opts *TxOptions,
+	doSend func(txOpts *bind.TransactOpts) (*types.Transaction, error),
+) (uint64, error) {
+	if opts == nil {
+		opts = &TxOptions{}
 	}
-	receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+	tx, err := signer.MakeTransactor()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get receipt: %v", err)
+		return 0, fmt.Errorf("failed to create transactor: %v", err)
 	}
-	if receipt.Status == 0 {
-		reason, err := _traceTransaction(ctx, client, tx.Hash())
-		if err != nil {
-			return nil, fmt.Errorf("transaction failed; failed to get reason: %v", err)
-		}
-		return nil, fmt.Errorf("transaction failed: %v", reason)
+	tx.Value = txValue
+	tx.Context = ctx
+	err = setFees(ctx, client, opts, tx)
+	if err != nil {
+		return 0, err
 	}
-	return receipt, err
+	return estimateGas(ctx, client, opts, tx, doSend)
 }
 
-// Call the Ethereum node using the RPC client directly because the ethclient struct doesn't have a
-// binding for the trace API. More details in: https://github.com/ethereum/go-ethereum/issues/17341
-func _traceTransaction(
+// Replace a pending transaction by a transfer of zero ether to the signer itself, with the same
+// nonce and higher fees, so the original transaction is never executed.
+// This function waits until one of them is added to a block and returns its receipt, which is the
+// receipt of the original transaction if it was added first.
+// If opts is nil, it uses the default options.
+func CancelTransaction(
 	ctx context.Context,
 	client *ethclient.Client,
-	hash common.Hash,
-) (string, error) {
-	var result json.RawMessage
-	err := client.Client().Call(&result, "debug_traceTransaction", hash)
+	signer Signer,
+	tx *types.Transaction,
+	opts *TxOptions,
+) (*types.Receipt, error) {
+	if opts == nil {
+		opts = &TxOptions{}
+	}
+	txOpts, err := signer.MakeTransactor()
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transactor: %v", err)
+	}
+	if tx.Type() == types.LegacyTxType {
+		txOpts.GasPrice = tx.GasPrice()
+	} else {
+		txOpts.GasFeeCap = tx.GasFeeCap()
+		txOpts.GasTipCap = tx.GasTipCap()
+	}
+	if !bumpFees(opts, txOpts) {
+		return nil, errors.New("failed to cancel transaction: fees exceed the max fees per gas")
+	}
+
+	var cancel types.TxData
+	account := signer.Account()
+	if txOpts.GasPrice != nil {
+		cancel = &types.LegacyTx{
+			Nonce:    tx.Nonce(),
+			GasPrice: txOpts.GasPrice,
+			Gas:      params.TxGas,
+			To:       &account,
+			Value:    new(big.Int),
+		}
+	} else {
+		cancel = &types.DynamicFeeTx{
+			ChainID:   tx.ChainId(),
+			Nonce:     tx.Nonce(),
+			GasTipCap: txOpts.GasTipCap,
+			GasFeeCap: txOpts.GasFeeCap,
+			Gas:       params.TxGas,
+			To:        &account,
+			Value:     new(big.Int),
+		}
+	}
+	signed, err := txOpts.Signer(account, types.NewTx(cancel))
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign cancel transaction: %v", err)
+	}
+	err = client.SendTransaction(ctx, signed)
 	if err != nil {
-		return "", err
+		return nil, fmt.Errorf("failed to send cancel transaction: %v", err)
 	}
-	return string(result), nil
+	return _waitForTransactions(ctx, client, []*types.Transaction{tx, signed}, opts, nil)
 }
@@ -0,0 +1,233 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package ethutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+)
+
+// Interval between requests when polling the blockchain node for new blocks.
+const PollInterval = 100 * time.Millisecond
+
+// Error returned when waiting for a transaction takes longer than the timeout.
+var ErrTimeout = errors.New("timed out waiting for transaction")
+
+// Error returned when the block that included a transaction is removed from the chain.
+type ReorgError struct {
+	TxHash      common.Hash
+	BlockNumber uint64
+	BlockHash   common.Hash
+}
+
+func (e *ReorgError) Error() string {
+	return fmt.Sprintf("transaction %v was removed from the chain by a reorg of block %v (%v)",
+		e.TxHash, e.BlockNumber, e.BlockHash)
+}
+
+// Wait for transaction to be included in a block and confirmed. Return the transaction receipt.
+// If replace is set and the transaction is still pending after opts.ReplaceAfter, it calls
+// replace to send a replacement, and waits for whichever transaction is included.
+// The replace function may return nil to stop replacing.
+func _waitForTransaction(
+	ctx context.Context,
+	client *ethclient.Client,
+	tx *types.Transaction,
+	opts *TxOptions,
+	replace func() (*types.Transaction, error),
+) (*types.Receipt, error) {
+	return _waitForTransactions(ctx, client, []*types.Transaction{tx}, opts, replace)
+}
+
+// Wait for one of the transactions with the same nonce to be included in a block and confirmed.
+// It checks the receipts whenever a new block arrives, so it notices when the block with the
+// transaction is removed from the chain.
+func _waitForTransactions(
+	ctx context.Context,
+	client *ethclient.Client,
+	txs []*types.Transaction,
+	opts *TxOptions,
+	replace func() (*types.Transaction, error),
+) (*types.Receipt, error) {
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeoutCause(ctx, opts.Timeout, ErrTimeout)
+		defer cancel()
+	}
+	ctx, stop := context.WithCancel(ctx)
+	defer stop()
+	heads := watchHeads(ctx, client)
+
+	var replaceTimer <-chan time.Time
+	if replace != nil && opts.ReplaceAfter > 0 {
+		replaceTimer = time.After(opts.ReplaceAfter)
+	}
+
+	var head uint64
+	var included *types.Receipt
+	for {
+		receipt, err := findReceipt(ctx, client, txs)
+		if err != nil {
+			return nil, err
+		}
+		if included != nil && (receipt == nil || receipt.BlockHash != included.BlockHash) {
+			if opts.FailOnReorg {
+				return nil, &ReorgError{
+					TxHash:      included.TxHash,
+					BlockNumber: included.BlockNumber.Uint64(),
+					BlockHash:   included.BlockHash,
+				}
+			}
+		}
+		included = receipt
+		if included != nil {
+			confirmed, err := isConfirmed(ctx, client, included, head, opts.Confirmations)
+			if err != nil {
+				return nil, err
+			}
+			if confirmed {
+				break
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, context.Cause(ctx)
+		case head = <-heads:
+		case <-replaceTimer:
+			replacement, err := replace()
+			if err != nil && !isNonceTooLow(err) {
+				return nil, fmt.Errorf("failed to replace transaction: %v", err)
+			}
+			// when the nonce is too low, one of the transactions was just included
+			if replacement != nil {
+				txs = append(txs, replacement)
+			}
+			if replacement != nil || err != nil {
+				replaceTimer = time.After(opts.ReplaceAfter)
+			} else {
+				replaceTimer = nil
+			}
+		}
+	}
+
+	if included.Status == types.ReceiptStatusFailed {
+		for _, tx := range txs {
+			if tx.Hash() == included.TxHash {
+				err := replayTransaction(ctx, client, tx, included)
+				return nil, fmt.Errorf("transaction %v failed: %w", included.TxHash, err)
+			}
+		}
+	}
+	return included, nil
+}
+
+// Get the receipt of the transaction that was included in a block, if any.
+func findReceipt(
+	ctx context.Context,
+	client *ethclient.Client,
+	txs []*types.Transaction,
+) (*types.Receipt, error) {
+	for _, tx := range txs {
+		receipt, err := client.TransactionReceipt(ctx, tx.Hash())
+		if err == nil {
+			return receipt, nil
+		}
+		if !errors.Is(err, ethereum.NotFound) {
+			if cause := context.Cause(ctx); cause != nil {
+				return nil, cause
+			}
+			return nil, fmt.Errorf("failed to get receipt: %v", err)
+		}
+	}
+	return nil, nil
+}
+
+// Report whether the block with the receipt is deep enough in the chain and still part of it.
+func isConfirmed(
+	ctx context.Context,
+	client *ethclient.Client,
+	receipt *types.Receipt,
+	head uint64,
+	confirmations uint64,
+) (bool, error) {
+	blockNumber := receipt.BlockNumber.Uint64()
+	if confirmations > 1 && head+1 < blockNumber+confirmations {
+		return false, nil
+	}
+	header, err := client.HeaderByNumber(ctx, receipt.BlockNumber)
+	if errors.Is(err, ethereum.NotFound) {
+		return false, nil
+	}
+	if err != nil {
+		if cause := context.Cause(ctx); cause != nil {
+			return false, cause
+		}
+		return false, fmt.Errorf("failed to get block %v: %v", blockNumber, err)
+	}
+	// the receipt may come from a block that is no longer in the chain
+	return header.Hash() == receipt.BlockHash, nil
+}
+
+// Send the number of every new block to the channel until the context is done.
+// It subscribes to new heads, which requires a WebSocket connection, or else polls the node.
+func watchHeads(ctx context.Context, client *ethclient.Client) <-chan uint64 {
+	heads := make(chan uint64)
+	go func() {
+		send := func(number uint64) {
+			select {
+			case heads <- number:
+			case <-ctx.Done():
+			}
+		}
+		headers := make(chan *types.Header)
+		sub, err := client.SubscribeNewHead(ctx, headers)
+		if err == nil {
+			defer sub.Unsubscribe()
+			for {
+				select {
+				case header := <-headers:
+					send(header.Number.Uint64())
+				case <-sub.Err():
+					// the subscription was dropped, so it falls back to polling
+					pollHeads(ctx, client, send)
+					return
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+		pollHeads(ctx, client, send)
+	}()
+	return heads
+}
+
+func pollHeads(ctx context.Context, client *ethclient.Client, send func(uint64)) {
+	var last uint64
+	for {
+		number, err := client.BlockNumber(ctx)
+		// errors are ignored because the waiting loop reports the ones that persist
+		if err == nil && number != last {
+			last = number
+			send(number)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(PollInterval):
+		}
+	}
+}
+
+func isNonceTooLow(err error) bool {
+	return strings.Contains(err.Error(), "nonce too low")
+}
@@ -0,0 +1,217 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package ethutil
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+// Header of the given block in the given fork of a fake chain.
+func fakeHeader(number uint64, fork byte) *types.Header {
+	return &types.Header{
+		Number:     new(big.Int).SetUint64(number),
+		Difficulty: new(big.Int),
+		Extra:      []byte{fork},
+	}
+}
+
+func fakeReceipt(txHash common.Hash, blockNumber uint64, fork byte) *types.Receipt {
+	return &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		TxHash:      txHash,
+		BlockNumber: new(big.Int).SetUint64(blockNumber),
+		BlockHash:   fakeHeader(blockNumber, fork).Hash(),
+		Logs:        []*types.Log{},
+	}
+}
+
+// Stand-in for a blockchain node whose chain the test controls.
+type fakeChain struct {
+	mutex       sync.Mutex
+	head        uint64
+	fork        byte
+	included    map[common.Hash]uint64
+	subscribers []*rpc.Notifier
+	subs        []*rpc.Subscription
+}
+
+func newFakeChain() *fakeChain {
+	return &fakeChain{head: 10, included: make(map[common.Hash]uint64)}
+}
+
+func (c *fakeChain) BlockNumber() hexutil.Uint64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	return hexutil.Uint64(c.head)
+}
+
+func (c *fakeChain) GetBlockByNumber(number rpc.BlockNumber, _ bool) *types.Header {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	if uint64(number) > c.head {
+		return nil
+	}
+	return fakeHeader(uint64(number), c.fork)
+}
+
+func (c *fakeChain) GetTransactionReceipt(hash common.Hash) *types.Receipt {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	blockNumber, ok := c.included[hash]
+	if !ok {
+		return nil
+	}
+	return fakeReceipt(hash, blockNumber, c.fork)
+}
+
+// Mine a block, including the transaction if it is set.
+func (c *fakeChain) mine(tx *common.Hash) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.head++
+	if tx != nil {
+		c.included[*tx] = c.head
+	}
+	for i, notifier := range c.subscribers {
+		_ = notifier.Notify(c.subs[i].ID, fakeHeader(c.head, c.fork))
+	}
+}
+
+// Replace the last block with an empty one.
+func (c *fakeChain) reorg() {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.fork++
+	for tx, blockNumber := range c.included {
+		if blockNumber == c.head {
+			delete(c.included, tx)
+		}
+	}
+}
+
+// Stand-in for the subscriptions of a blockchain node.
+type fakeSubscriptions struct {
+	chain *fakeChain
+}
+
+func (s *fakeSubscriptions) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, ok := rpc.NotifierFromContext(ctx)
+	if !ok {
+		return nil, rpc.ErrNotificationsUnsupported
+	}
+	sub := notifier.CreateSubscription()
+	s.chain.mutex.Lock()
+	defer s.chain.mutex.Unlock()
+	s.chain.subscribers = append(s.chain.subscribers, notifier)
+	s.chain.subs = append(s.chain.subs, sub)
+	return sub, nil
+}
+
+// Wait for the transaction in the background.
+func waitInBackground(
+	client *ethclient.Client,
+	tx *types.Transaction,
+	opts *TxOptions,
+) (<-chan *types.Receipt, <-chan error) {
+	receipts := make(chan *types.Receipt, 1)
+	errs := make(chan error, 1)
+	go func() {
+		receipt, err := _waitForTransaction(context.Background(), client, tx, opts, nil)
+		receipts <- receipt
+		errs <- err
+	}()
+	return receipts, errs
+}
+
+func testConfirmations(t *testing.T, subscriptions bool) {
+	chain := newFakeChain()
+	apis := []any{chain}
+	if subscriptions {
+		apis = append(apis, &fakeSubscriptions{chain})
+	}
+	client := newFakeClient(t, apis...)
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1})
+	hash := tx.Hash()
+
+	receipts, errs := waitInBackground(client, tx, &TxOptions{Confirmations: 3})
+	time.Sleep(2 * PollInterval)
+	chain.mine(&hash)
+	chain.mine(nil)
+	select {
+	case <-receipts:
+		require.FailNow(t, "returned before the confirmations")
+	case <-time.After(3 * PollInterval):
+	}
+	chain.mine(nil)
+
+	receipt := <-receipts
+	require.Nil(t, <-errs)
+	require.Equal(t, uint64(11), receipt.BlockNumber.Uint64())
+}
+
+func TestWaitForConfirmationsPolling(t *testing.T) {
+	testConfirmations(t, false)
+}
+
+func TestWaitForConfirmationsSubscribing(t *testing.T) {
+	testConfirmations(t, true)
+}
+
+func TestWaitFailsOnReorg(t *testing.T) {
+	chain := newFakeChain()
+	client := newFakeClient(t, chain)
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1})
+	hash := tx.Hash()
+
+	_, errs := waitInBackground(client, tx,
+		&TxOptions{Confirmations: 2, FailOnReorg: true})
+	chain.mine(&hash)
+	time.Sleep(3 * PollInterval)
+	chain.reorg()
+	chain.mine(nil)
+
+	var reorgErr *ReorgError
+	require.ErrorAs(t, <-errs, &reorgErr)
+	require.Equal(t, hash, reorgErr.TxHash)
+	require.Equal(t, uint64(11), reorgErr.BlockNumber)
+}
+
+func TestWaitSurvivesReorg(t *testing.T) {
+	chain := newFakeChain()
+	client := newFakeClient(t, chain)
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1})
+	hash := tx.Hash()
+
+	receipts, errs := waitInBackground(client, tx, &TxOptions{Confirmations: 2})
+	chain.mine(&hash)
+	time.Sleep(3 * PollInterval)
+	chain.reorg()
+	chain.mine(&hash)
+	chain.mine(nil)
+
+	receipt := <-receipts
+	require.Nil(t, <-errs)
+	require.Equal(t, uint64(12), receipt.BlockNumber.Uint64())
+}
+
+func TestWaitTimesOut(t *testing.T) {
+	chain := newFakeChain()
+	client := newFakeClient(t, chain)
+	tx := types.NewTx(&types.LegacyTx{Nonce: 1})
+
+	_, errs := waitInBackground(client, tx, &TxOptions{Timeout: 2 * PollInterval})
+	require.True(t, errors.Is(<-errs, ErrTimeout))
+}
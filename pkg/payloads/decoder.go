@@ -0,0 +1,239 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+// This package decodes and encodes the payloads of vouchers and notices.
+// Voucher payloads are calls to the voucher destination, so they are decoded against a set of
+// contract ABIs. Notice payloads have no selector, so they are decoded given their ABI types.
+package payloads
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/cartesi/rollups-node/pkg/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Error returned by Decoder.Decode when no ABI has the method of the payload.
+var ErrUnknownMethod = errors.New("unknown method")
+
+// Voucher methods decoded by default, by contract.
+var builtinMethods = []struct {
+	contract string
+	metadata *bind.MetaData
+	methods  []string
+}{
+	{"IERC20", contracts.IERC20MetaData, []string{"transfer", "transferFrom", "approve"}},
+	{"IERC721", contracts.IERC721MetaData, []string{"safeTransferFrom", "safeTransferFrom0",
+		"transferFrom", "approve", "setApprovalForAll"}},
+	{"IERC1155", contracts.IERC1155MetaData, []string{"safeTransferFrom",
+		"safeBatchTransferFrom", "setApprovalForAll"}},
+	{"CartesiDApp", contracts.CartesiDAppMetaData, []string{"withdrawEther"}},
+}
+
+// Method call decoded from a voucher payload.
+type Call struct {
+	// Contracts with the method. Some ERC-20 and ERC-721 methods have the same selector,
+	// so the payload alone doesn't tell them apart.
+	Contracts []string `json:"contracts"`
+
+	// Name and signature of the method.
+	Method    string `json:"method"`
+	Signature string `json:"signature"`
+
+	// Arguments of the call.
+	Args []Arg `json:"args"`
+}
+
+// Decoded argument of a call or value of a notice.
+type Arg struct {
+	// Name of the argument in the ABI, which may be empty.
+	Name string `json:"name,omitempty"`
+
+	// ABI type of the argument.
+	Type string `json:"type"`
+
+	// Value of the argument as decoded by go-ethereum, such as *big.Int for integers and
+	// common.Address for addresses.
+	Value any `json:"-"`
+}
+
+// MarshalJSON renders the value in a readable form: integers as decimal strings and bytes as
+// hex strings starting with 0x.
+func (a Arg) MarshalJSON() ([]byte, error) {
+	type arg Arg
+	return json.Marshal(struct {
+		arg
+		Value any `json:"value"`
+	}{arg(a), renderValue(reflect.ValueOf(a.Value))})
+}
+
+// String renders the call as Contract.method(name: value, ...).
+func (c *Call) String() string {
+	args := make([]string, len(c.Args))
+	for i, arg := range c.Args {
+		value := fmt.Sprint(renderValue(reflect.ValueOf(arg.Value)))
+		if arg.Name != "" {
+			value = arg.Name + ": " + value
+		}
+		args[i] = value
+	}
+	return fmt.Sprintf("%v.%v(%v)", strings.Join(c.Contracts, "|"), c.Method,
+		strings.Join(args, ", "))
+}
+
+type decoderMethod struct {
+	contracts []string
+	method    abi.Method
+}
+
+// Decoder of voucher payloads against a set of contract ABIs.
+type Decoder struct {
+	methods map[[4]byte]*decoderMethod
+}
+
+// Create a decoder with the built-in ABIs: the transfers and approvals of ERC-20, ERC-721 and
+// ERC-1155 tokens, and CartesiDApp.withdrawEther.
+func NewDecoder() (*Decoder, error) {
+	d := &Decoder{methods: make(map[[4]byte]*decoderMethod)}
+	for _, builtin := range builtinMethods {
+		parsed, err := builtin.metadata.GetAbi()
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %v ABI: %v", builtin.contract, err)
+		}
+		for _, name := range builtin.methods {
+			d.addMethod(builtin.contract, parsed.Methods[name])
+		}
+	}
+	return d, nil
+}
+
+// Add all the methods of the contract ABI to the decoder.
+// The methods that have the selector of a method already in the decoder with a different
+// signature are ignored.
+func (d *Decoder) AddABI(contract string, parsed *abi.ABI) {
+	for _, method := range parsed.Methods {
+		d.addMethod(contract, method)
+	}
+}
+
+// Add the methods of a contract ABI read from a JSON file, which may be the ABI itself or a
+// Hardhat or Foundry artifact with an "abi" field. The contract is named after the file.
+func (d *Decoder) AddABIFile(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read ABI: %v", err)
+	}
+	var artifact struct {
+		ABI json.RawMessage `json:"abi"`
+	}
+	if json.Unmarshal(data, &artifact) == nil && artifact.ABI != nil {
+		data = artifact.ABI
+	}
+	parsed, err := abi.JSON(strings.NewReader(string(data)))
+	if err != nil {
+		return fmt.Errorf("failed to parse ABI in %v: %v", path, err)
+	}
+	contract := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	d.AddABI(strings.TrimSuffix(contract, ".abi"), &parsed)
+	return nil
+}
+
+func (d *Decoder) addMethod(contract string, method abi.Method) {
+	selector := [4]byte(method.ID)
+	existing, ok := d.methods[selector]
+	if !ok {
+		d.methods[selector] = &decoderMethod{contracts: []string{contract}, method: method}
+		return
+	}
+	if existing.method.Sig == method.Sig && !slices.Contains(existing.contracts, contract) {
+		existing.contracts = append(existing.contracts, contract)
+	}
+}
+
+// Decode the voucher payload as a call to one of the methods of the decoder.
+// It returns an error wrapping ErrUnknownMethod if no ABI has the method of the payload.
+func (d *Decoder) Decode(payload []byte) (*Call, error) {
+	if len(payload) < 4 {
+		return nil, fmt.Errorf("failed to decode payload: expected a 4-byte selector, "+
+			"but got %v bytes", len(payload))
+	}
+	entry, ok := d.methods[[4]byte(payload[:4])]
+	if !ok {
+		return nil, fmt.Errorf("%w: %v", ErrUnknownMethod, hexutil.Encode(payload[:4]))
+	}
+	values, err := entry.method.Inputs.Unpack(payload[4:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode arguments of %v: %v", entry.method.Sig, err)
+	}
+	return &Call{
+		Contracts: slices.Clone(entry.contracts),
+		Method:    entry.method.RawName,
+		Signature: entry.method.Sig,
+		Args:      newArgs(entry.method.Inputs, values),
+	}, nil
+}
+
+func newArgs(arguments abi.Arguments, values []any) []Arg {
+	args := make([]Arg, len(values))
+	for i, value := range values {
+		args[i] = Arg{
+			Name:  arguments[i].Name,
+			Type:  arguments[i].Type.String(),
+			Value: value,
+		}
+	}
+	return args
+}
+
+// Render a decoded value with strings for integers and bytes, so it is readable as JSON.
+func renderValue(value reflect.Value) any {
+	if !value.IsValid() {
+		return nil
+	}
+	switch v := value.Interface().(type) {
+	case *big.Int:
+		return v.String()
+	case []byte:
+		return hexutil.Encode(v)
+	case fmt.Stringer:
+		// addresses and hashes
+		return v.String()
+	}
+	switch value.Kind() {
+	case reflect.Array:
+		if value.Type().Elem().Kind() == reflect.Uint8 {
+			bytes := make([]byte, value.Len())
+			reflect.Copy(reflect.ValueOf(bytes), value)
+			return hexutil.Encode(bytes)
+		}
+		fallthrough
+	case reflect.Slice:
+		list := make([]any, value.Len())
+		for i := range list {
+			list[i] = renderValue(value.Index(i))
+		}
+		return list
+	case reflect.Struct:
+		// tuples
+		fields := make(map[string]any, value.NumField())
+		for i := range value.NumField() {
+			fields[value.Type().Field(i).Name] = renderValue(value.Field(i))
+		}
+		return fields
+	case reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return fmt.Sprint(value.Interface())
+	default:
+		return value.Interface()
+	}
+}
@@ -0,0 +1,111 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package payloads
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	testFrom = common.HexToAddress("0x1111111111111111111111111111111111111111")
+	testTo   = common.HexToAddress("0x2222222222222222222222222222222222222222")
+)
+
+func TestDecodeBuiltinMethods(t *testing.T) {
+	decoder, err := NewDecoder()
+	require.Nil(t, err)
+
+	call, err := decoder.Decode(ERC20Transfer(testTo, big.NewInt(10)))
+	require.Nil(t, err)
+	require.Equal(t, []string{"IERC20"}, call.Contracts)
+	require.Equal(t, "transfer(address,uint256)", call.Signature)
+	require.Equal(t, "IERC20.transfer(to: "+testTo.Hex()+", amount: 10)", call.String())
+
+	call, err = decoder.Decode(ERC721SafeTransfer(testFrom, testTo, big.NewInt(3)))
+	require.Nil(t, err)
+	require.Equal(t, []string{"IERC721"}, call.Contracts)
+	require.Equal(t, "safeTransferFrom(address,address,uint256)", call.Signature)
+
+	call, err = decoder.Decode(
+		ERC1155SafeTransfer(testFrom, testTo, big.NewInt(3), big.NewInt(4), []byte{0xab}))
+	require.Nil(t, err)
+	require.Equal(t, []string{"IERC1155"}, call.Contracts)
+	require.Equal(t, []byte{0xab}, call.Args[4].Value)
+
+	call, err = decoder.Decode(WithdrawEther(testTo, big.NewInt(5)))
+	require.Nil(t, err)
+	require.Equal(t, "CartesiDApp.withdrawEther(_receiver: "+testTo.Hex()+", _value: 5)",
+		call.String())
+
+	// ERC-20 and ERC-721 have the same transferFrom
+	erc20, err := builtinMethods[0].metadata.GetAbi()
+	require.Nil(t, err)
+	payload, err := EncodeCall(erc20, "transferFrom", testFrom, testTo, big.NewInt(1))
+	require.Nil(t, err)
+	call, err = decoder.Decode(payload)
+	require.Nil(t, err)
+	require.Equal(t, []string{"IERC20", "IERC721"}, call.Contracts)
+}
+
+func TestDecodeUnknownMethod(t *testing.T) {
+	decoder, err := NewDecoder()
+	require.Nil(t, err)
+
+	_, err = decoder.Decode([]byte{0xde, 0xad, 0xbe, 0xef})
+	require.ErrorIs(t, err, ErrUnknownMethod)
+	require.ErrorContains(t, err, "0xdeadbeef")
+
+	_, err = decoder.Decode([]byte{0xde})
+	require.ErrorContains(t, err, "expected a 4-byte selector")
+}
+
+func TestAddABIFile(t *testing.T) {
+	const abiJSON = `[{"type":"function","name":"mint","stateMutability":"nonpayable",
+		"inputs":[{"name":"to","type":"address"},{"name":"ids","type":"uint256[]"},
+		{"name":"tag","type":"bytes32"}],"outputs":[]}]`
+	dir := t.TempDir()
+	artifact := filepath.Join(dir, "Token.json")
+	require.Nil(t, os.WriteFile(artifact, []byte(`{"abi":`+abiJSON+`}`), 0600))
+
+	decoder, err := NewDecoder()
+	require.Nil(t, err)
+	require.Nil(t, decoder.AddABIFile(artifact))
+
+	parsed, err := abi.JSON(strings.NewReader(abiJSON))
+	require.Nil(t, err)
+	payload, err := EncodeCall(&parsed, "mint",
+		testTo, []*big.Int{big.NewInt(1), big.NewInt(2)}, common.HexToHash("0xff"))
+	require.Nil(t, err)
+
+	call, err := decoder.Decode(payload)
+	require.Nil(t, err)
+	require.Equal(t, []string{"Token"}, call.Contracts)
+
+	data, err := json.Marshal(call.Args)
+	require.Nil(t, err)
+	require.JSONEq(t, `[
+		{"name": "to", "type": "address", "value": "`+testTo.Hex()+`"},
+		{"name": "ids", "type": "uint256[]", "value": ["1", "2"]},
+		{"name": "tag", "type": "bytes32", "value": "`+common.HexToHash("0xff").Hex()+`"}
+	]`, string(data))
+
+	raw := filepath.Join(dir, "Other.abi.json")
+	require.Nil(t, os.WriteFile(raw, []byte(abiJSON), 0600))
+	require.Nil(t, decoder.AddABIFile(raw))
+	call, err = decoder.Decode(payload)
+	require.Nil(t, err)
+	require.Equal(t, []string{"Token", "Other"}, call.Contracts)
+
+	require.Nil(t, os.WriteFile(raw, []byte("{}"), 0600))
+	require.ErrorContains(t, decoder.AddABIFile(raw), "failed to parse ABI")
+}
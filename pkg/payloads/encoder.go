@@ -0,0 +1,98 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package payloads
+
+import (
+	"fmt"
+	"math/big"
+	"strings"
+
+	"github.com/cartesi/rollups-node/pkg/contracts"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// Encode a call to the method of the contract, which may be used as a voucher payload.
+func EncodeCall(parsed *abi.ABI, method string, args ...any) ([]byte, error) {
+	payload, err := parsed.Pack(method, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode call to %v: %v", method, err)
+	}
+	return payload, nil
+}
+
+// Encode the voucher payload that transfers ERC-20 tokens from the application.
+func ERC20Transfer(to common.Address, amount *big.Int) []byte {
+	return mustEncodeCall(contracts.IERC20MetaData, "transfer", to, amount)
+}
+
+// Encode the voucher payload that transfers an ERC-721 token from the application with
+// safeTransferFrom(address,address,uint256).
+func ERC721SafeTransfer(from common.Address, to common.Address, tokenId *big.Int) []byte {
+	return mustEncodeCall(contracts.IERC721MetaData, "safeTransferFrom", from, to, tokenId)
+}
+
+// Encode the voucher payload that transfers ERC-1155 tokens from the application.
+func ERC1155SafeTransfer(
+	from common.Address,
+	to common.Address,
+	tokenId *big.Int,
+	value *big.Int,
+	data []byte,
+) []byte {
+	return mustEncodeCall(contracts.IERC1155MetaData, "safeTransferFrom",
+		from, to, tokenId, value, data)
+}
+
+// Encode the voucher payload that withdraws ether from the application; the voucher
+// destination must be the application itself.
+func WithdrawEther(receiver common.Address, value *big.Int) []byte {
+	return mustEncodeCall(contracts.CartesiDAppMetaData, "withdrawEther", receiver, value)
+}
+
+// Encode a call to a built-in method, panicking if the arguments are invalid, such as a nil
+// amount, because they are given by the caller.
+func mustEncodeCall(metadata *bind.MetaData, method string, args ...any) []byte {
+	parsed, err := metadata.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	payload, err := EncodeCall(parsed, method, args...)
+	if err != nil {
+		panic(err)
+	}
+	return payload
+}
+
+// Parse a comma-separated list of ABI types, such as "address,uint256".
+func ParseTypes(types string) (abi.Arguments, error) {
+	var arguments abi.Arguments
+	for _, name := range strings.Split(types, ",") {
+		typ, err := abi.NewType(strings.TrimSpace(name), "", nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ABI type %q: %v", name, err)
+		}
+		arguments = append(arguments, abi.Argument{Type: typ})
+	}
+	return arguments, nil
+}
+
+// Encode the values with the ABI types as a notice payload, as abi.encode does.
+func EncodeNotice(types abi.Arguments, values ...any) ([]byte, error) {
+	payload, err := types.Pack(values...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode notice: %v", err)
+	}
+	return payload, nil
+}
+
+// Decode a notice payload encoded with the ABI types, as abi.decode does.
+func DecodeNotice(types abi.Arguments, payload []byte) ([]Arg, error) {
+	values, err := types.Unpack(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode notice: %v", err)
+	}
+	return newArgs(types, values), nil
+}
@@ -0,0 +1,42 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package payloads
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNoticeRoundTrip(t *testing.T) {
+	types, err := ParseTypes("address, uint256,string")
+	require.Nil(t, err)
+
+	payload, err := EncodeNotice(types, testTo, big.NewInt(7), "hello")
+	require.Nil(t, err)
+	require.Equal(t, common.LeftPadBytes(testTo.Bytes(), 32), payload[:32])
+
+	args, err := DecodeNotice(types, payload)
+	require.Nil(t, err)
+	require.Equal(t, []Arg{
+		{Type: "address", Value: testTo},
+		{Type: "uint256", Value: big.NewInt(7)},
+		{Type: "string", Value: "hello"},
+	}, args)
+
+	_, err = DecodeNotice(types, payload[:40])
+	require.ErrorContains(t, err, "failed to decode notice")
+
+	_, err = EncodeNotice(types, testTo)
+	require.ErrorContains(t, err, "failed to encode notice")
+
+	_, err = ParseTypes("address,token")
+	require.ErrorContains(t, err, `failed to parse ABI type "token"`)
+}
+
+func TestBuiltinPayloadsPanicOnInvalidArguments(t *testing.T) {
+	require.Panics(t, func() { ERC20Transfer(testTo, nil) })
+}
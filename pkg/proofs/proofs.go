@@ -0,0 +1,223 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+// This package verifies the validity proofs of notices and vouchers offline, following the
+// checks of CartesiDApp.validateNotice and CartesiDApp.executeVoucher, so they may be checked
+// in bulk without calling the blockchain node.
+package proofs
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cartesi/rollups-node/pkg/claims"
+	"github.com/cartesi/rollups-node/pkg/readerclient"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Sizes of the Merkle trees of the outputs in the machine, as in the CanonicalMachine library.
+const (
+	// Log2 of the size of a Keccak-256 hash, which is a leaf of the output trees.
+	KeccakLog2Size = 5
+
+	// Log2 of the size of the tree with the output hashes of an input.
+	OutputMetadataLog2Size = 21
+
+	// Log2 of the size of the tree with the output hashes roots of the inputs of an epoch.
+	EpochOutputLog2Size = 37
+
+	// Log2 of the size of a machine word, which is a leaf of the tree of the output hash.
+	wordLog2Size = 3
+)
+
+// Errors of the verification, named after the errors of the LibOutputValidation library.
+var (
+	ErrIncorrectOutputHashesRootHash = errors.New("incorrect output hashes root hash")
+	ErrIncorrectOutputsEpochRootHash = errors.New("incorrect outputs epoch root hash")
+	ErrIncorrectEpochHash            = errors.New("incorrect epoch hash")
+	ErrInputIndexOutOfClaimBounds    = errors.New("input index out of claim bounds")
+
+	errNoProof = errors.New("invalid proof: the output has no proof yet")
+)
+
+var (
+	bytesType, _   = abi.NewType("bytes", "", nil)
+	addressType, _ = abi.NewType("address", "", nil)
+)
+
+// Hash of a notice as it is stored in the machine, which is the keccak256 of the ABI-encoded
+// notice payload.
+func NoticeHash(payload []byte) common.Hash {
+	args := abi.Arguments{{Type: bytesType}}
+	encoded, err := args.Pack(payload)
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode notice: %v", err))
+	}
+	return crypto.Keccak256Hash(encoded)
+}
+
+// Hash of a voucher as it is stored in the machine, which is the keccak256 of the ABI-encoded
+// voucher destination and payload.
+func VoucherHash(destination common.Address, payload []byte) common.Hash {
+	args := abi.Arguments{{Type: addressType}, {Type: bytesType}}
+	encoded, err := args.Pack(destination, payload)
+	if err != nil {
+		panic(fmt.Sprintf("failed to encode voucher: %v", err))
+	}
+	return crypto.Keccak256Hash(encoded)
+}
+
+// Verify the proof of a notice given its payload.
+// It returns the epoch hash the proof commits to, which must match the claim of the epoch
+// for the notice to be valid on-chain; see VerifyClaim.
+func VerifyNotice(payload []byte, proof *readerclient.Proof) (common.Hash, error) {
+	if proof == nil {
+		return common.Hash{}, errNoProof
+	}
+	return verifyOutput(NoticeHash(payload), proof, proof.NoticesEpochRootHash)
+}
+
+// Verify the proof of a voucher given its destination and payload.
+// It returns the epoch hash the proof commits to, which must match the claim of the epoch
+// for the voucher to be executable on-chain; see VerifyClaim.
+func VerifyVoucher(
+	destination common.Address,
+	payload []byte,
+	proof *readerclient.Proof,
+) (common.Hash, error) {
+	if proof == nil {
+		return common.Hash{}, errNoProof
+	}
+	return verifyOutput(VoucherHash(destination, payload), proof, proof.VouchersEpochRootHash)
+}
+
+// Verify that the claim, obtained with claims.Reader.GetClaim given the proof context,
+// commits to the epoch hash of the proof and covers the input that produced the output.
+func VerifyClaim(proof *readerclient.Proof, inputIndex int, claim *claims.ClaimData) error {
+	if proof == nil {
+		return errNoProof
+	}
+	epochHash := claims.EpochHash(proof)
+	if epochHash != claim.EpochHash {
+		return fmt.Errorf("%w: the proof commits to %v, but the claim is %v",
+			ErrIncorrectEpochHash, epochHash, claim.EpochHash)
+	}
+	index := claim.FirstIndex + uint64(proof.InputIndexWithinEpoch)
+	if index > claim.LastIndex {
+		return fmt.Errorf("%w: input %v is not in the claimed inputs [%v, %v]",
+			ErrInputIndexOutOfClaimBounds, index, claim.FirstIndex, claim.LastIndex)
+	}
+	if index != uint64(inputIndex) {
+		return fmt.Errorf("%w: the proof is for input %v, but the output is from input %v",
+			ErrInputIndexOutOfClaimBounds, index, inputIndex)
+	}
+	return nil
+}
+
+// Walk the siblings from the output hash to the outputs epoch root hash, and combine the
+// roots of the epoch into its hash.
+func verifyOutput(
+	outputHash common.Hash,
+	proof *readerclient.Proof,
+	outputsEpochRootHash []byte,
+) (common.Hash, error) {
+	if err := checkProof(proof); err != nil {
+		return common.Hash{}, err
+	}
+
+	root := rootAfterReplacement(proof.OutputIndexWithinInput, wordsRoot(outputHash),
+		proof.OutputHashInOutputHashesSiblings)
+	if root != common.BytesToHash(proof.OutputHashesRootHash) {
+		return common.Hash{}, fmt.Errorf("%w: computed %v, but the proof has %v",
+			ErrIncorrectOutputHashesRootHash, root, proof.OutputHashesRootHash)
+	}
+
+	root = rootAfterReplacement(proof.InputIndexWithinEpoch,
+		common.BytesToHash(proof.OutputHashesRootHash), proof.OutputHashesInEpochSiblings)
+	if root != common.BytesToHash(outputsEpochRootHash) {
+		return common.Hash{}, fmt.Errorf("%w: computed %v, but the proof has %v",
+			ErrIncorrectOutputsEpochRootHash, root, outputsEpochRootHash)
+	}
+
+	return claims.EpochHash(proof), nil
+}
+
+// Check the sizes of the hashes and the number of siblings of the proof.
+func checkProof(proof *readerclient.Proof) error {
+	hashes := []struct {
+		name  string
+		value []byte
+	}{
+		{"output hashes root hash", proof.OutputHashesRootHash},
+		{"vouchers epoch root hash", proof.VouchersEpochRootHash},
+		{"notices epoch root hash", proof.NoticesEpochRootHash},
+		{"machine state hash", proof.MachineStateHash},
+	}
+	for _, hash := range hashes {
+		if len(hash.value) != common.HashLength {
+			return fmt.Errorf("invalid proof: expected %v bytes in the %v, but got %v",
+				common.HashLength, hash.name, len(hash.value))
+		}
+	}
+	siblings := []struct {
+		name   string
+		value  []hexutil.Bytes
+		length int
+	}{
+		{"output hash", proof.OutputHashInOutputHashesSiblings,
+			OutputMetadataLog2Size - KeccakLog2Size},
+		{"output hashes", proof.OutputHashesInEpochSiblings,
+			EpochOutputLog2Size - KeccakLog2Size},
+	}
+	for _, s := range siblings {
+		if len(s.value) != s.length {
+			return fmt.Errorf("invalid proof: expected %v siblings of the %v, but got %v",
+				s.length, s.name, len(s.value))
+		}
+		for i, sibling := range s.value {
+			if len(sibling) != common.HashLength {
+				return fmt.Errorf("invalid proof: expected %v bytes in sibling %v of the %v, "+
+					"but got %v", common.HashLength, i, s.name, len(sibling))
+			}
+		}
+	}
+	if proof.InputIndexWithinEpoch < 0 || proof.OutputIndexWithinInput < 0 ||
+		proof.OutputIndexWithinInput >= 1<<(OutputMetadataLog2Size-KeccakLog2Size) {
+		return errors.New("invalid proof: index out of range")
+	}
+	return nil
+}
+
+// Root of the tree after replacing the leaf at the index, given the siblings from the leaf
+// to the root, as in MerkleV2.getRootAfterReplacementInDrive.
+func rootAfterReplacement(index int, leaf common.Hash, siblings []hexutil.Bytes) common.Hash {
+	node := leaf
+	for i, sibling := range siblings {
+		if (index>>i)&1 == 0 {
+			node = crypto.Keccak256Hash(node[:], sibling)
+		} else {
+			node = crypto.Keccak256Hash(sibling, node[:])
+		}
+	}
+	return node
+}
+
+// Root of the tree of the machine words of the hash, as in MerkleV2.getMerkleRootFromBytes,
+// because the leaves of the machine memory are words rather than hashes.
+func wordsRoot(hash common.Hash) common.Hash {
+	const wordSize = 1 << wordLog2Size
+	nodes := make([]common.Hash, common.HashLength/wordSize)
+	for i := range nodes {
+		nodes[i] = crypto.Keccak256Hash(hash[i*wordSize : (i+1)*wordSize])
+	}
+	for len(nodes) > 1 {
+		for i := range len(nodes) / 2 {
+			nodes[i] = crypto.Keccak256Hash(nodes[2*i][:], nodes[2*i+1][:])
+		}
+		nodes = nodes[:len(nodes)/2]
+	}
+	return nodes[0]
+}
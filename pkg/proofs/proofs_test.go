@@ -0,0 +1,83 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package proofs
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/cartesi/rollups-node/pkg/claims"
+	"github.com/cartesi/rollups-node/pkg/readerclient"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+// Input of the echo application with the proofs reported by the node.
+func readEchoInput(t *testing.T) *readerclient.Input {
+	data, err := os.ReadFile("../../test/data/echo_input/expected_input_with_proofs.json")
+	require.Nil(t, err)
+	var input readerclient.Input
+	require.Nil(t, json.Unmarshal(data, &input))
+	return &input
+}
+
+func TestVerifyNotice(t *testing.T) {
+	notice := readEchoInput(t).Notices[0]
+
+	epochHash, err := VerifyNotice(notice.Payload, notice.Proof)
+	require.Nil(t, err)
+	require.Equal(t, claims.EpochHash(notice.Proof), epochHash)
+
+	_, err = VerifyNotice([]byte("other"), notice.Proof)
+	require.ErrorIs(t, err, ErrIncorrectOutputHashesRootHash)
+
+	notice.Proof.OutputHashesInEpochSiblings[3] = common.HexToHash("0x01").Bytes()
+	_, err = VerifyNotice(notice.Payload, notice.Proof)
+	require.ErrorIs(t, err, ErrIncorrectOutputsEpochRootHash)
+
+	notice.Proof.OutputHashesInEpochSiblings = notice.Proof.OutputHashesInEpochSiblings[1:]
+	_, err = VerifyNotice(notice.Payload, notice.Proof)
+	require.ErrorContains(t, err, "expected 32 siblings of the output hashes, but got 31")
+
+	_, err = VerifyNotice(notice.Payload, nil)
+	require.ErrorContains(t, err, "no proof")
+}
+
+func TestVerifyVoucher(t *testing.T) {
+	voucher := readEchoInput(t).Vouchers[0]
+
+	epochHash, err := VerifyVoucher(voucher.Destination, voucher.Payload, voucher.Proof)
+	require.Nil(t, err)
+	require.Equal(t, claims.EpochHash(voucher.Proof), epochHash)
+
+	_, err = VerifyVoucher(common.Address{}, voucher.Payload, voucher.Proof)
+	require.ErrorIs(t, err, ErrIncorrectOutputHashesRootHash)
+
+	// the voucher isn't in the notices of the epoch
+	_, err = VerifyNotice(voucher.Payload, voucher.Proof)
+	require.ErrorIs(t, err, ErrIncorrectOutputHashesRootHash)
+}
+
+func TestVerifyClaim(t *testing.T) {
+	notice := readEchoInput(t).Notices[0]
+	claim := &claims.ClaimData{
+		EpochHash:  claims.EpochHash(notice.Proof),
+		FirstIndex: 0,
+		LastIndex:  2,
+	}
+	require.Nil(t, VerifyClaim(notice.Proof, 0, claim))
+
+	err := VerifyClaim(notice.Proof, 1, claim)
+	require.ErrorIs(t, err, ErrInputIndexOutOfClaimBounds)
+
+	claim.EpochHash = common.HexToHash("0x01")
+	err = VerifyClaim(notice.Proof, 0, claim)
+	require.ErrorIs(t, err, ErrIncorrectEpochHash)
+
+	claim = &claims.ClaimData{EpochHash: claims.EpochHash(notice.Proof), FirstIndex: 3}
+	notice.Proof.InputIndexWithinEpoch = 1
+	err = VerifyClaim(notice.Proof, 4, claim)
+	require.ErrorContains(t, err, "input 4 is not in the claimed inputs [3, 0]")
+}
@@ -31,28 +31,64 @@ func (v *__getInputInput) GetIndex() int { return v.Index }
 
 // __getInputNoticesInput is used internally by genqlient
 type __getInputNoticesInput struct {
-	InputIndex int `json:"inputIndex"`
+	InputIndex int    `json:"inputIndex"`
+	First      int    `json:"first,omitempty"`
+	After      string `json:"after,omitempty"`
 }
 
 // GetInputIndex returns __getInputNoticesInput.InputIndex, and is useful for accessing the field via an interface.
 func (v *__getInputNoticesInput) GetInputIndex() int { return v.InputIndex }
 
+// GetFirst returns __getInputNoticesInput.First, and is useful for accessing the field via an interface.
+func (v *__getInputNoticesInput) GetFirst() int { return v.First }
+
+// GetAfter returns __getInputNoticesInput.After, and is useful for accessing the field via an interface.
+func (v *__getInputNoticesInput) GetAfter() string { return v.After }
+
 // __getInputReportsInput is used internally by genqlient
 type __getInputReportsInput struct {
-	InputIndex int `json:"inputIndex"`
+	InputIndex int    `json:"inputIndex"`
+	First      int    `json:"first,omitempty"`
+	After      string `json:"after,omitempty"`
 }
 
 // GetInputIndex returns __getInputReportsInput.InputIndex, and is useful for accessing the field via an interface.
 func (v *__getInputReportsInput) GetInputIndex() int { return v.InputIndex }
 
+// GetFirst returns __getInputReportsInput.First, and is useful for accessing the field via an interface.
+func (v *__getInputReportsInput) GetFirst() int { return v.First }
+
+// GetAfter returns __getInputReportsInput.After, and is useful for accessing the field via an interface.
+func (v *__getInputReportsInput) GetAfter() string { return v.After }
+
 // __getInputVouchersInput is used internally by genqlient
 type __getInputVouchersInput struct {
-	InputIndex int `json:"inputIndex"`
+	InputIndex int    `json:"inputIndex"`
+	First      int    `json:"first,omitempty"`
+	After      string `json:"after,omitempty"`
 }
 
 // GetInputIndex returns __getInputVouchersInput.InputIndex, and is useful for accessing the field via an interface.
 func (v *__getInputVouchersInput) GetInputIndex() int { return v.InputIndex }
 
+// GetFirst returns __getInputVouchersInput.First, and is useful for accessing the field via an interface.
+func (v *__getInputVouchersInput) GetFirst() int { return v.First }
+
+// GetAfter returns __getInputVouchersInput.After, and is useful for accessing the field via an interface.
+func (v *__getInputVouchersInput) GetAfter() string { return v.After }
+
+// __getInputsInput is used internally by genqlient
+type __getInputsInput struct {
+	First int    `json:"first,omitempty"`
+	After string `json:"after,omitempty"`
+}
+
+// GetFirst returns __getInputsInput.First, and is useful for accessing the field via an interface.
+func (v *__getInputsInput) GetFirst() int { return v.First }
+
+// GetAfter returns __getInputsInput.After, and is useful for accessing the field via an interface.
+func (v *__getInputsInput) GetAfter() string { return v.After }
+
 // __getNoticeInput is used internally by genqlient
 type __getNoticeInput struct {
 	NoticeIndex int `json:"noticeIndex"`
@@ -65,6 +101,18 @@ func (v *__getNoticeInput) GetNoticeIndex() int { return v.NoticeIndex }
 // GetInputIndex returns __getNoticeInput.InputIndex, and is useful for accessing the field via an interface.
 func (v *__getNoticeInput) GetInputIndex() int { return v.InputIndex }
 
+// __getNoticesInput is used internally by genqlient
+type __getNoticesInput struct {
+	First int    `json:"first,omitempty"`
+	After string `json:"after,omitempty"`
+}
+
+// GetFirst returns __getNoticesInput.First, and is useful for accessing the field via an interface.
+func (v *__getNoticesInput) GetFirst() int { return v.First }
+
+// GetAfter returns __getNoticesInput.After, and is useful for accessing the field via an interface.
+func (v *__getNoticesInput) GetAfter() string { return v.After }
+
 // __getReportInput is used internally by genqlient
 type __getReportInput struct {
 	ReportIndex int `json:"reportIndex"`
@@ -77,6 +125,18 @@ func (v *__getReportInput) GetReportIndex() int { return v.ReportIndex }
 // GetInputIndex returns __getReportInput.InputIndex, and is useful for accessing the field via an interface.
 func (v *__getReportInput) GetInputIndex() int { return v.InputIndex }
 
+// __getReportsInput is used internally by genqlient
+type __getReportsInput struct {
+	First int    `json:"first,omitempty"`
+	After string `json:"after,omitempty"`
+}
+
+// GetFirst returns __getReportsInput.First, and is useful for accessing the field via an interface.
+func (v *__getReportsInput) GetFirst() int { return v.First }
+
+// GetAfter returns __getReportsInput.After, and is useful for accessing the field via an interface.
+func (v *__getReportsInput) GetAfter() string { return v.After }
+
 // __getVoucherInput is used internally by genqlient
 type __getVoucherInput struct {
 	VoucherIndex int `json:"voucherIndex"`
@@ -89,6 +149,18 @@ func (v *__getVoucherInput) GetVoucherIndex() int { return v.VoucherIndex }
 // GetInputIndex returns __getVoucherInput.InputIndex, and is useful for accessing the field via an interface.
 func (v *__getVoucherInput) GetInputIndex() int { return v.InputIndex }
 
+// __getVouchersInput is used internally by genqlient
+type __getVouchersInput struct {
+	First int    `json:"first,omitempty"`
+	After string `json:"after,omitempty"`
+}
+
+// GetFirst returns __getVouchersInput.First, and is useful for accessing the field via an interface.
+func (v *__getVouchersInput) GetFirst() int { return v.First }
+
+// GetAfter returns __getVouchersInput.After, and is useful for accessing the field via an interface.
+func (v *__getVouchersInput) GetAfter() string { return v.After }
+
 // getInputInput includes the requested fields of the GraphQL type Input.
 // The GraphQL type's documentation follows.
 //
@@ -146,10 +218,17 @@ func (v *getInputInput) GetReports() getInputInputReportsReportConnection { retu
 //
 // Pagination result
 type getInputInputNoticesNoticeConnection struct {
+	// Pagination metadata
+	PageInfo getInputInputNoticesNoticeConnectionPageInfo `json:"pageInfo"`
 	// Pagination entries returned for the current page
 	Edges []getInputInputNoticesNoticeConnectionEdgesNoticeEdge `json:"edges"`
 }
 
+// GetPageInfo returns getInputInputNoticesNoticeConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *getInputInputNoticesNoticeConnection) GetPageInfo() getInputInputNoticesNoticeConnectionPageInfo {
+	return v.PageInfo
+}
+
 // GetEdges returns getInputInputNoticesNoticeConnection.Edges, and is useful for accessing the field via an interface.
 func (v *getInputInputNoticesNoticeConnection) GetEdges() []getInputInputNoticesNoticeConnectionEdgesNoticeEdge {
 	return v.Edges
@@ -281,15 +360,39 @@ func (v *getInputInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNoticeProofValid
 	return v.OutputHashesInEpochSiblings
 }
 
+// getInputInputNoticesNoticeConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+// The GraphQL type's documentation follows.
+//
+// Page metadata for the cursor-based Connection pagination pattern
+type getInputInputNoticesNoticeConnectionPageInfo struct {
+	// Cursor pointing to the last entry of the page
+	EndCursor string `json:"endCursor"`
+	// Indicates if there are additional entries after the end curs
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+// GetEndCursor returns getInputInputNoticesNoticeConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *getInputInputNoticesNoticeConnectionPageInfo) GetEndCursor() string { return v.EndCursor }
+
+// GetHasNextPage returns getInputInputNoticesNoticeConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *getInputInputNoticesNoticeConnectionPageInfo) GetHasNextPage() bool { return v.HasNextPage }
+
 // getInputInputReportsReportConnection includes the requested fields of the GraphQL type ReportConnection.
 // The GraphQL type's documentation follows.
 //
 // Pagination result
 type getInputInputReportsReportConnection struct {
+	// Pagination metadata
+	PageInfo getInputInputReportsReportConnectionPageInfo `json:"pageInfo"`
 	// Pagination entries returned for the current page
 	Edges []getInputInputReportsReportConnectionEdgesReportEdge `json:"edges"`
 }
 
+// GetPageInfo returns getInputInputReportsReportConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *getInputInputReportsReportConnection) GetPageInfo() getInputInputReportsReportConnectionPageInfo {
+	return v.PageInfo
+}
+
 // GetEdges returns getInputInputReportsReportConnection.Edges, and is useful for accessing the field via an interface.
 func (v *getInputInputReportsReportConnection) GetEdges() []getInputInputReportsReportConnectionEdgesReportEdge {
 	return v.Edges
@@ -330,15 +433,39 @@ func (v *getInputInputReportsReportConnectionEdgesReportEdgeNodeReport) GetPaylo
 	return v.Payload
 }
 
+// getInputInputReportsReportConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+// The GraphQL type's documentation follows.
+//
+// Page metadata for the cursor-based Connection pagination pattern
+type getInputInputReportsReportConnectionPageInfo struct {
+	// Cursor pointing to the last entry of the page
+	EndCursor string `json:"endCursor"`
+	// Indicates if there are additional entries after the end curs
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+// GetEndCursor returns getInputInputReportsReportConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *getInputInputReportsReportConnectionPageInfo) GetEndCursor() string { return v.EndCursor }
+
+// GetHasNextPage returns getInputInputReportsReportConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *getInputInputReportsReportConnectionPageInfo) GetHasNextPage() bool { return v.HasNextPage }
+
 // getInputInputVouchersVoucherConnection includes the requested fields of the GraphQL type VoucherConnection.
 // The GraphQL type's documentation follows.
 //
 // Pagination result
 type getInputInputVouchersVoucherConnection struct {
+	// Pagination metadata
+	PageInfo getInputInputVouchersVoucherConnectionPageInfo `json:"pageInfo"`
 	// Pagination entries returned for the current page
 	Edges []getInputInputVouchersVoucherConnectionEdgesVoucherEdge `json:"edges"`
 }
 
+// GetPageInfo returns getInputInputVouchersVoucherConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *getInputInputVouchersVoucherConnection) GetPageInfo() getInputInputVouchersVoucherConnectionPageInfo {
+	return v.PageInfo
+}
+
 // GetEdges returns getInputInputVouchersVoucherConnection.Edges, and is useful for accessing the field via an interface.
 func (v *getInputInputVouchersVoucherConnection) GetEdges() []getInputInputVouchersVoucherConnectionEdgesVoucherEdge {
 	return v.Edges
@@ -477,6 +604,23 @@ func (v *getInputInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucherProofV
 	return v.OutputHashesInEpochSiblings
 }
 
+// getInputInputVouchersVoucherConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+// The GraphQL type's documentation follows.
+//
+// Page metadata for the cursor-based Connection pagination pattern
+type getInputInputVouchersVoucherConnectionPageInfo struct {
+	// Cursor pointing to the last entry of the page
+	EndCursor string `json:"endCursor"`
+	// Indicates if there are additional entries after the end curs
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+// GetEndCursor returns getInputInputVouchersVoucherConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *getInputInputVouchersVoucherConnectionPageInfo) GetEndCursor() string { return v.EndCursor }
+
+// GetHasNextPage returns getInputInputVouchersVoucherConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *getInputInputVouchersVoucherConnectionPageInfo) GetHasNextPage() bool { return v.HasNextPage }
+
 // getInputNoticesInput includes the requested fields of the GraphQL type Input.
 // The GraphQL type's documentation follows.
 //
@@ -501,10 +645,17 @@ func (v *getInputNoticesInput) GetNotices() getInputNoticesInputNoticesNoticeCon
 //
 // Pagination result
 type getInputNoticesInputNoticesNoticeConnection struct {
+	// Pagination metadata
+	PageInfo getInputNoticesInputNoticesNoticeConnectionPageInfo `json:"pageInfo"`
 	// Pagination entries returned for the current page
 	Edges []getInputNoticesInputNoticesNoticeConnectionEdgesNoticeEdge `json:"edges"`
 }
 
+// GetPageInfo returns getInputNoticesInputNoticesNoticeConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *getInputNoticesInputNoticesNoticeConnection) GetPageInfo() getInputNoticesInputNoticesNoticeConnectionPageInfo {
+	return v.PageInfo
+}
+
 // GetEdges returns getInputNoticesInputNoticesNoticeConnection.Edges, and is useful for accessing the field via an interface.
 func (v *getInputNoticesInputNoticesNoticeConnection) GetEdges() []getInputNoticesInputNoticesNoticeConnectionEdgesNoticeEdge {
 	return v.Edges
@@ -636,6 +787,27 @@ func (v *getInputNoticesInputNoticesNoticeConnectionEdgesNoticeEdgeNodeNoticePro
 	return v.OutputHashesInEpochSiblings
 }
 
+// getInputNoticesInputNoticesNoticeConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+// The GraphQL type's documentation follows.
+//
+// Page metadata for the cursor-based Connection pagination pattern
+type getInputNoticesInputNoticesNoticeConnectionPageInfo struct {
+	// Cursor pointing to the last entry of the page
+	EndCursor string `json:"endCursor"`
+	// Indicates if there are additional entries after the end curs
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+// GetEndCursor returns getInputNoticesInputNoticesNoticeConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *getInputNoticesInputNoticesNoticeConnectionPageInfo) GetEndCursor() string {
+	return v.EndCursor
+}
+
+// GetHasNextPage returns getInputNoticesInputNoticesNoticeConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *getInputNoticesInputNoticesNoticeConnectionPageInfo) GetHasNextPage() bool {
+	return v.HasNextPage
+}
+
 // getInputNoticesResponse is returned by getInputNotices on success.
 type getInputNoticesResponse struct {
 	// Get input based on its identifier
@@ -669,10 +841,17 @@ func (v *getInputReportsInput) GetReports() getInputReportsInputReportsReportCon
 //
 // Pagination result
 type getInputReportsInputReportsReportConnection struct {
+	// Pagination metadata
+	PageInfo getInputReportsInputReportsReportConnectionPageInfo `json:"pageInfo"`
 	// Pagination entries returned for the current page
 	Edges []getInputReportsInputReportsReportConnectionEdgesReportEdge `json:"edges"`
 }
 
+// GetPageInfo returns getInputReportsInputReportsReportConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *getInputReportsInputReportsReportConnection) GetPageInfo() getInputReportsInputReportsReportConnectionPageInfo {
+	return v.PageInfo
+}
+
 // GetEdges returns getInputReportsInputReportsReportConnection.Edges, and is useful for accessing the field via an interface.
 func (v *getInputReportsInputReportsReportConnection) GetEdges() []getInputReportsInputReportsReportConnectionEdgesReportEdge {
 	return v.Edges
@@ -713,6 +892,27 @@ func (v *getInputReportsInputReportsReportConnectionEdgesReportEdgeNodeReport) G
 	return v.Payload
 }
 
+// getInputReportsInputReportsReportConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+// The GraphQL type's documentation follows.
+//
+// Page metadata for the cursor-based Connection pagination pattern
+type getInputReportsInputReportsReportConnectionPageInfo struct {
+	// Cursor pointing to the last entry of the page
+	EndCursor string `json:"endCursor"`
+	// Indicates if there are additional entries after the end curs
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+// GetEndCursor returns getInputReportsInputReportsReportConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *getInputReportsInputReportsReportConnectionPageInfo) GetEndCursor() string {
+	return v.EndCursor
+}
+
+// GetHasNextPage returns getInputReportsInputReportsReportConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *getInputReportsInputReportsReportConnectionPageInfo) GetHasNextPage() bool {
+	return v.HasNextPage
+}
+
 // getInputReportsResponse is returned by getInputReports on success.
 type getInputReportsResponse struct {
 	// Get input based on its identifier
@@ -755,10 +955,17 @@ func (v *getInputVouchersInput) GetVouchers() getInputVouchersInputVouchersVouch
 //
 // Pagination result
 type getInputVouchersInputVouchersVoucherConnection struct {
+	// Pagination metadata
+	PageInfo getInputVouchersInputVouchersVoucherConnectionPageInfo `json:"pageInfo"`
 	// Pagination entries returned for the current page
 	Edges []getInputVouchersInputVouchersVoucherConnectionEdgesVoucherEdge `json:"edges"`
 }
 
+// GetPageInfo returns getInputVouchersInputVouchersVoucherConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *getInputVouchersInputVouchersVoucherConnection) GetPageInfo() getInputVouchersInputVouchersVoucherConnectionPageInfo {
+	return v.PageInfo
+}
+
 // GetEdges returns getInputVouchersInputVouchersVoucherConnection.Edges, and is useful for accessing the field via an interface.
 func (v *getInputVouchersInputVouchersVoucherConnection) GetEdges() []getInputVouchersInputVouchersVoucherConnectionEdgesVoucherEdge {
 	return v.Edges
@@ -897,6 +1104,27 @@ func (v *getInputVouchersInputVouchersVoucherConnectionEdgesVoucherEdgeNodeVouch
 	return v.OutputHashesInEpochSiblings
 }
 
+// getInputVouchersInputVouchersVoucherConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+// The GraphQL type's documentation follows.
+//
+// Page metadata for the cursor-based Connection pagination pattern
+type getInputVouchersInputVouchersVoucherConnectionPageInfo struct {
+	// Cursor pointing to the last entry of the page
+	EndCursor string `json:"endCursor"`
+	// Indicates if there are additional entries after the end curs
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+// GetEndCursor returns getInputVouchersInputVouchersVoucherConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *getInputVouchersInputVouchersVoucherConnectionPageInfo) GetEndCursor() string {
+	return v.EndCursor
+}
+
+// GetHasNextPage returns getInputVouchersInputVouchersVoucherConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *getInputVouchersInputVouchersVoucherConnectionPageInfo) GetHasNextPage() bool {
+	return v.HasNextPage
+}
+
 // getInputVouchersResponse is returned by getInputVouchers on success.
 type getInputVouchersResponse struct {
 	// Get input based on its identifier
@@ -911,10 +1139,17 @@ func (v *getInputVouchersResponse) GetInput() getInputVouchersInput { return v.I
 //
 // Pagination result
 type getInputsInputsInputConnection struct {
+	// Pagination metadata
+	PageInfo getInputsInputsInputConnectionPageInfo `json:"pageInfo"`
 	// Pagination entries returned for the current page
 	Edges []getInputsInputsInputConnectionEdgesInputEdge `json:"edges"`
 }
 
+// GetPageInfo returns getInputsInputsInputConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *getInputsInputsInputConnection) GetPageInfo() getInputsInputsInputConnectionPageInfo {
+	return v.PageInfo
+}
+
 // GetEdges returns getInputsInputsInputConnection.Edges, and is useful for accessing the field via an interface.
 func (v *getInputsInputsInputConnection) GetEdges() []getInputsInputsInputConnectionEdgesInputEdge {
 	return v.Edges
@@ -1005,10 +1240,17 @@ func (v *getInputsInputsInputConnectionEdgesInputEdgeNodeInput) GetReports() get
 //
 // Pagination result
 type getInputsInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnection struct {
+	// Pagination metadata
+	PageInfo getInputsInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionPageInfo `json:"pageInfo"`
 	// Pagination entries returned for the current page
 	Edges []getInputsInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdge `json:"edges"`
 }
 
+// GetPageInfo returns getInputsInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *getInputsInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnection) GetPageInfo() getInputsInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionPageInfo {
+	return v.PageInfo
+}
+
 // GetEdges returns getInputsInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnection.Edges, and is useful for accessing the field via an interface.
 func (v *getInputsInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnection) GetEdges() []getInputsInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionEdgesNoticeEdge {
 	return v.Edges
@@ -1140,15 +1382,43 @@ func (v *getInputsInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConne
 	return v.OutputHashesInEpochSiblings
 }
 
+// getInputsInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+// The GraphQL type's documentation follows.
+//
+// Page metadata for the cursor-based Connection pagination pattern
+type getInputsInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionPageInfo struct {
+	// Cursor pointing to the last entry of the page
+	EndCursor string `json:"endCursor"`
+	// Indicates if there are additional entries after the end curs
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+// GetEndCursor returns getInputsInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *getInputsInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionPageInfo) GetEndCursor() string {
+	return v.EndCursor
+}
+
+// GetHasNextPage returns getInputsInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *getInputsInputsInputConnectionEdgesInputEdgeNodeInputNoticesNoticeConnectionPageInfo) GetHasNextPage() bool {
+	return v.HasNextPage
+}
+
 // getInputsInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnection includes the requested fields of the GraphQL type ReportConnection.
 // The GraphQL type's documentation follows.
 //
 // Pagination result
 type getInputsInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnection struct {
+	// Pagination metadata
+	PageInfo getInputsInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionPageInfo `json:"pageInfo"`
 	// Pagination entries returned for the current page
 	Edges []getInputsInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdge `json:"edges"`
 }
 
+// GetPageInfo returns getInputsInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *getInputsInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnection) GetPageInfo() getInputsInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionPageInfo {
+	return v.PageInfo
+}
+
 // GetEdges returns getInputsInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnection.Edges, and is useful for accessing the field via an interface.
 func (v *getInputsInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnection) GetEdges() []getInputsInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionEdgesReportEdge {
 	return v.Edges
@@ -1189,15 +1459,43 @@ func (v *getInputsInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConne
 	return v.Payload
 }
 
+// getInputsInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+// The GraphQL type's documentation follows.
+//
+// Page metadata for the cursor-based Connection pagination pattern
+type getInputsInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionPageInfo struct {
+	// Cursor pointing to the last entry of the page
+	EndCursor string `json:"endCursor"`
+	// Indicates if there are additional entries after the end curs
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+// GetEndCursor returns getInputsInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *getInputsInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionPageInfo) GetEndCursor() string {
+	return v.EndCursor
+}
+
+// GetHasNextPage returns getInputsInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *getInputsInputsInputConnectionEdgesInputEdgeNodeInputReportsReportConnectionPageInfo) GetHasNextPage() bool {
+	return v.HasNextPage
+}
+
 // getInputsInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnection includes the requested fields of the GraphQL type VoucherConnection.
 // The GraphQL type's documentation follows.
 //
 // Pagination result
 type getInputsInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnection struct {
+	// Pagination metadata
+	PageInfo getInputsInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionPageInfo `json:"pageInfo"`
 	// Pagination entries returned for the current page
 	Edges []getInputsInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdge `json:"edges"`
 }
 
+// GetPageInfo returns getInputsInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *getInputsInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnection) GetPageInfo() getInputsInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionPageInfo {
+	return v.PageInfo
+}
+
 // GetEdges returns getInputsInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnection.Edges, and is useful for accessing the field via an interface.
 func (v *getInputsInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnection) GetEdges() []getInputsInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionEdgesVoucherEdge {
 	return v.Edges
@@ -1336,6 +1634,44 @@ func (v *getInputsInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherCon
 	return v.OutputHashesInEpochSiblings
 }
 
+// getInputsInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+// The GraphQL type's documentation follows.
+//
+// Page metadata for the cursor-based Connection pagination pattern
+type getInputsInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionPageInfo struct {
+	// Cursor pointing to the last entry of the page
+	EndCursor string `json:"endCursor"`
+	// Indicates if there are additional entries after the end curs
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+// GetEndCursor returns getInputsInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *getInputsInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionPageInfo) GetEndCursor() string {
+	return v.EndCursor
+}
+
+// GetHasNextPage returns getInputsInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *getInputsInputsInputConnectionEdgesInputEdgeNodeInputVouchersVoucherConnectionPageInfo) GetHasNextPage() bool {
+	return v.HasNextPage
+}
+
+// getInputsInputsInputConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+// The GraphQL type's documentation follows.
+//
+// Page metadata for the cursor-based Connection pagination pattern
+type getInputsInputsInputConnectionPageInfo struct {
+	// Cursor pointing to the last entry of the page
+	EndCursor string `json:"endCursor"`
+	// Indicates if there are additional entries after the end curs
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+// GetEndCursor returns getInputsInputsInputConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *getInputsInputsInputConnectionPageInfo) GetEndCursor() string { return v.EndCursor }
+
+// GetHasNextPage returns getInputsInputsInputConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *getInputsInputsInputConnectionPageInfo) GetHasNextPage() bool { return v.HasNextPage }
+
 // getInputsResponse is returned by getInputs on success.
 type getInputsResponse struct {
 	// Get inputs with support for pagination
@@ -1480,10 +1816,17 @@ func (v *getNoticeResponse) GetNotice() getNoticeNotice { return v.Notice }
 //
 // Pagination result
 type getNoticesNoticesNoticeConnection struct {
+	// Pagination metadata
+	PageInfo getNoticesNoticesNoticeConnectionPageInfo `json:"pageInfo"`
 	// Pagination entries returned for the current page
 	Edges []getNoticesNoticesNoticeConnectionEdgesNoticeEdge `json:"edges"`
 }
 
+// GetPageInfo returns getNoticesNoticesNoticeConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *getNoticesNoticesNoticeConnection) GetPageInfo() getNoticesNoticesNoticeConnectionPageInfo {
+	return v.PageInfo
+}
+
 // GetEdges returns getNoticesNoticesNoticeConnection.Edges, and is useful for accessing the field via an interface.
 func (v *getNoticesNoticesNoticeConnection) GetEdges() []getNoticesNoticesNoticeConnectionEdgesNoticeEdge {
 	return v.Edges
@@ -1634,6 +1977,23 @@ func (v *getNoticesNoticesNoticeConnectionEdgesNoticeEdgeNodeNoticeProofValidity
 	return v.OutputHashesInEpochSiblings
 }
 
+// getNoticesNoticesNoticeConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+// The GraphQL type's documentation follows.
+//
+// Page metadata for the cursor-based Connection pagination pattern
+type getNoticesNoticesNoticeConnectionPageInfo struct {
+	// Cursor pointing to the last entry of the page
+	EndCursor string `json:"endCursor"`
+	// Indicates if there are additional entries after the end curs
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+// GetEndCursor returns getNoticesNoticesNoticeConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *getNoticesNoticesNoticeConnectionPageInfo) GetEndCursor() string { return v.EndCursor }
+
+// GetHasNextPage returns getNoticesNoticesNoticeConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *getNoticesNoticesNoticeConnectionPageInfo) GetHasNextPage() bool { return v.HasNextPage }
+
 // getNoticesResponse is returned by getNotices on success.
 type getNoticesResponse struct {
 	// Get notices with support for pagination
@@ -1691,10 +2051,17 @@ func (v *getReportResponse) GetReport() getReportReport { return v.Report }
 //
 // Pagination result
 type getReportsReportsReportConnection struct {
+	// Pagination metadata
+	PageInfo getReportsReportsReportConnectionPageInfo `json:"pageInfo"`
 	// Pagination entries returned for the current page
 	Edges []getReportsReportsReportConnectionEdgesReportEdge `json:"edges"`
 }
 
+// GetPageInfo returns getReportsReportsReportConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *getReportsReportsReportConnection) GetPageInfo() getReportsReportsReportConnectionPageInfo {
+	return v.PageInfo
+}
+
 // GetEdges returns getReportsReportsReportConnection.Edges, and is useful for accessing the field via an interface.
 func (v *getReportsReportsReportConnection) GetEdges() []getReportsReportsReportConnectionEdgesReportEdge {
 	return v.Edges
@@ -1754,6 +2121,23 @@ func (v *getReportsReportsReportConnectionEdgesReportEdgeNodeReportInput) GetInd
 	return v.Index
 }
 
+// getReportsReportsReportConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+// The GraphQL type's documentation follows.
+//
+// Page metadata for the cursor-based Connection pagination pattern
+type getReportsReportsReportConnectionPageInfo struct {
+	// Cursor pointing to the last entry of the page
+	EndCursor string `json:"endCursor"`
+	// Indicates if there are additional entries after the end curs
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+// GetEndCursor returns getReportsReportsReportConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *getReportsReportsReportConnectionPageInfo) GetEndCursor() string { return v.EndCursor }
+
+// GetHasNextPage returns getReportsReportsReportConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *getReportsReportsReportConnectionPageInfo) GetHasNextPage() bool { return v.HasNextPage }
+
 // getReportsResponse is returned by getReports on success.
 type getReportsResponse struct {
 	// Get reports with support for pagination
@@ -1912,10 +2296,17 @@ func (v *getVouchersResponse) GetVouchers() getVouchersVouchersVoucherConnection
 //
 // Pagination result
 type getVouchersVouchersVoucherConnection struct {
+	// Pagination metadata
+	PageInfo getVouchersVouchersVoucherConnectionPageInfo `json:"pageInfo"`
 	// Pagination entries returned for the current page
 	Edges []getVouchersVouchersVoucherConnectionEdgesVoucherEdge `json:"edges"`
 }
 
+// GetPageInfo returns getVouchersVouchersVoucherConnection.PageInfo, and is useful for accessing the field via an interface.
+func (v *getVouchersVouchersVoucherConnection) GetPageInfo() getVouchersVouchersVoucherConnectionPageInfo {
+	return v.PageInfo
+}
+
 // GetEdges returns getVouchersVouchersVoucherConnection.Edges, and is useful for accessing the field via an interface.
 func (v *getVouchersVouchersVoucherConnection) GetEdges() []getVouchersVouchersVoucherConnectionEdgesVoucherEdge {
 	return v.Edges
@@ -2075,6 +2466,23 @@ func (v *getVouchersVouchersVoucherConnectionEdgesVoucherEdgeNodeVoucherProofVal
 	return v.OutputHashesInEpochSiblings
 }
 
+// getVouchersVouchersVoucherConnectionPageInfo includes the requested fields of the GraphQL type PageInfo.
+// The GraphQL type's documentation follows.
+//
+// Page metadata for the cursor-based Connection pagination pattern
+type getVouchersVouchersVoucherConnectionPageInfo struct {
+	// Cursor pointing to the last entry of the page
+	EndCursor string `json:"endCursor"`
+	// Indicates if there are additional entries after the end curs
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+// GetEndCursor returns getVouchersVouchersVoucherConnectionPageInfo.EndCursor, and is useful for accessing the field via an interface.
+func (v *getVouchersVouchersVoucherConnectionPageInfo) GetEndCursor() string { return v.EndCursor }
+
+// GetHasNextPage returns getVouchersVouchersVoucherConnectionPageInfo.HasNextPage, and is useful for accessing the field via an interface.
+func (v *getVouchersVouchersVoucherConnectionPageInfo) GetHasNextPage() bool { return v.HasNextPage }
+
 // The query or mutation executed by getInput.
 const getInput_Operation = `
 query getInput ($index: Int!) {
@@ -2086,6 +2494,10 @@ query getInput ($index: Int!) {
 		blockNumber
 		payload
 		notices {
+			pageInfo {
+				endCursor
+				hasNextPage
+			}
 			edges {
 				node {
 					index
@@ -2107,6 +2519,10 @@ query getInput ($index: Int!) {
 			}
 		}
 		vouchers {
+			pageInfo {
+				endCursor
+				hasNextPage
+			}
 			edges {
 				node {
 					index
@@ -2129,6 +2545,10 @@ query getInput ($index: Int!) {
 			}
 		}
 		reports {
+			pageInfo {
+				endCursor
+				hasNextPage
+			}
 			edges {
 				node {
 					index
@@ -2168,10 +2588,14 @@ func getInput(
 
 // The query or mutation executed by getInputNotices.
 const getInputNotices_Operation = `
-query getInputNotices ($inputIndex: Int!) {
+query getInputNotices ($inputIndex: Int!, $first: Int, $after: String) {
 	input(index: $inputIndex) {
 		index
-		notices {
+		notices(first: $first, after: $after) {
+			pageInfo {
+				endCursor
+				hasNextPage
+			}
 			edges {
 				node {
 					index
@@ -2200,12 +2624,16 @@ func getInputNotices(
 	ctx_ context.Context,
 	client_ graphql.Client,
 	inputIndex int,
+	first int,
+	after string,
 ) (*getInputNoticesResponse, error) {
 	req_ := &graphql.Request{
 		OpName: "getInputNotices",
 		Query:  getInputNotices_Operation,
 		Variables: &__getInputNoticesInput{
 			InputIndex: inputIndex,
+			First:      first,
+			After:      after,
 		},
 	}
 	var err_ error
@@ -2224,10 +2652,14 @@ func getInputNotices(
 
 // The query or mutation executed by getInputReports.
 const getInputReports_Operation = `
-query getInputReports ($inputIndex: Int!) {
+query getInputReports ($inputIndex: Int!, $first: Int, $after: String) {
 	input(index: $inputIndex) {
 		index
-		reports {
+		reports(first: $first, after: $after) {
+			pageInfo {
+				endCursor
+				hasNextPage
+			}
 			edges {
 				node {
 					index
@@ -2243,12 +2675,16 @@ func getInputReports(
 	ctx_ context.Context,
 	client_ graphql.Client,
 	inputIndex int,
+	first int,
+	after string,
 ) (*getInputReportsResponse, error) {
 	req_ := &graphql.Request{
 		OpName: "getInputReports",
 		Query:  getInputReports_Operation,
 		Variables: &__getInputReportsInput{
 			InputIndex: inputIndex,
+			First:      first,
+			After:      after,
 		},
 	}
 	var err_ error
@@ -2267,10 +2703,14 @@ func getInputReports(
 
 // The query or mutation executed by getInputVouchers.
 const getInputVouchers_Operation = `
-query getInputVouchers ($inputIndex: Int!) {
+query getInputVouchers ($inputIndex: Int!, $first: Int, $after: String) {
 	input(index: $inputIndex) {
 		index
-		vouchers {
+		vouchers(first: $first, after: $after) {
+			pageInfo {
+				endCursor
+				hasNextPage
+			}
 			edges {
 				node {
 					index
@@ -2300,12 +2740,16 @@ func getInputVouchers(
 	ctx_ context.Context,
 	client_ graphql.Client,
 	inputIndex int,
+	first int,
+	after string,
 ) (*getInputVouchersResponse, error) {
 	req_ := &graphql.Request{
 		OpName: "getInputVouchers",
 		Query:  getInputVouchers_Operation,
 		Variables: &__getInputVouchersInput{
 			InputIndex: inputIndex,
+			First:      first,
+			After:      after,
 		},
 	}
 	var err_ error
@@ -2324,8 +2768,12 @@ func getInputVouchers(
 
 // The query or mutation executed by getInputs.
 const getInputs_Operation = `
-query getInputs {
-	inputs {
+query getInputs ($first: Int, $after: String) {
+	inputs(first: $first, after: $after) {
+		pageInfo {
+			endCursor
+			hasNextPage
+		}
 		edges {
 			node {
 				index
@@ -2335,6 +2783,10 @@ query getInputs {
 				blockNumber
 				payload
 				notices {
+					pageInfo {
+						endCursor
+						hasNextPage
+					}
 					edges {
 						node {
 							index
@@ -2356,6 +2808,10 @@ query getInputs {
 					}
 				}
 				vouchers {
+					pageInfo {
+						endCursor
+						hasNextPage
+					}
 					edges {
 						node {
 							index
@@ -2378,6 +2834,10 @@ query getInputs {
 					}
 				}
 				reports {
+					pageInfo {
+						endCursor
+						hasNextPage
+					}
 					edges {
 						node {
 							index
@@ -2394,10 +2854,16 @@ query getInputs {
 func getInputs(
 	ctx_ context.Context,
 	client_ graphql.Client,
+	first int,
+	after string,
 ) (*getInputsResponse, error) {
 	req_ := &graphql.Request{
 		OpName: "getInputs",
 		Query:  getInputs_Operation,
+		Variables: &__getInputsInput{
+			First: first,
+			After: after,
+		},
 	}
 	var err_ error
 
@@ -2469,8 +2935,12 @@ func getNotice(
 
 // The query or mutation executed by getNotices.
 const getNotices_Operation = `
-query getNotices {
-	notices {
+query getNotices ($first: Int, $after: String) {
+	notices(first: $first, after: $after) {
+		pageInfo {
+			endCursor
+			hasNextPage
+		}
 		edges {
 			node {
 				index
@@ -2500,10 +2970,16 @@ query getNotices {
 func getNotices(
 	ctx_ context.Context,
 	client_ graphql.Client,
+	first int,
+	after string,
 ) (*getNoticesResponse, error) {
 	req_ := &graphql.Request{
 		OpName: "getNotices",
 		Query:  getNotices_Operation,
+		Variables: &__getNoticesInput{
+			First: first,
+			After: after,
+		},
 	}
 	var err_ error
 
@@ -2562,8 +3038,12 @@ func getReport(
 
 // The query or mutation executed by getReports.
 const getReports_Operation = `
-query getReports {
-	reports {
+query getReports ($first: Int, $after: String) {
+	reports(first: $first, after: $after) {
+		pageInfo {
+			endCursor
+			hasNextPage
+		}
 		edges {
 			node {
 				index
@@ -2580,10 +3060,16 @@ query getReports {
 func getReports(
 	ctx_ context.Context,
 	client_ graphql.Client,
+	first int,
+	after string,
 ) (*getReportsResponse, error) {
 	req_ := &graphql.Request{
 		OpName: "getReports",
 		Query:  getReports_Operation,
+		Variables: &__getReportsInput{
+			First: first,
+			After: after,
+		},
 	}
 	var err_ error
 
@@ -2656,8 +3142,12 @@ func getVoucher(
 
 // The query or mutation executed by getVouchers.
 const getVouchers_Operation = `
-query getVouchers {
-	vouchers {
+query getVouchers ($first: Int, $after: String) {
+	vouchers(first: $first, after: $after) {
+		pageInfo {
+			endCursor
+			hasNextPage
+		}
 		edges {
 			node {
 				index
@@ -2688,10 +3178,16 @@ query getVouchers {
 func getVouchers(
 	ctx_ context.Context,
 	client_ graphql.Client,
+	first int,
+	after string,
 ) (*getVouchersResponse, error) {
 	req_ := &graphql.Request{
 		OpName: "getVouchers",
 		Query:  getVouchers_Operation,
+		Variables: &__getVouchersInput{
+			First: first,
+			After: after,
+		},
 	}
 	var err_ error
 
@@ -165,6 +165,23 @@ func GetInput(
 		reports = append(reports, *report)
 	}
 
+	// the outputs after the first page of each list are got with the queries of the input
+	notices, err = appendRemaining(ctx, client, notices, GetInputNoticesPage, index,
+		PageInfo(resp.Input.Notices.PageInfo))
+	if err != nil {
+		return nil, err
+	}
+	vouchers, err = appendRemaining(ctx, client, vouchers, GetInputVouchersPage, index,
+		PageInfo(resp.Input.Vouchers.PageInfo))
+	if err != nil {
+		return nil, err
+	}
+	reports, err = appendRemaining(ctx, client, reports, GetInputReportsPage, index,
+		PageInfo(resp.Input.Reports.PageInfo))
+	if err != nil {
+		return nil, err
+	}
+
 	input, err := newInput(
 		resp.Input.Index,
 		resp.Input.Status,
@@ -181,16 +198,29 @@ func GetInput(
 }
 
 // GetInputs returns multiple inputs ordered by index
+// It walks all the pages with DefaultPageSize entries each.
 func GetInputs(
 	ctx context.Context,
 	client graphql.Client,
 ) ([]Input, error) {
+	return NewIterator(client, GetInputsPage, DefaultPageSize, "").All(ctx)
+}
+
+// Get a page of inputs from GraphQL with up to first inputs after the given cursor.
+// If first is zero, the page size is chosen by the server. If after is empty, the page starts at
+// the first input.
+func GetInputsPage(
+	ctx context.Context,
+	client graphql.Client,
+	first int,
+	after string,
+) ([]Input, *PageInfo, error) {
 
 	var inputs []Input
 
-	resp, err := getInputs(ctx, client)
+	resp, err := getInputs(ctx, client, first, after)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	for _, inputEdge := range resp.Inputs.Edges {
@@ -213,7 +243,7 @@ func GetInputs(
 				edge.Node.Proof.Context,
 			)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
 			notice, err := newNotice(
@@ -223,7 +253,7 @@ func GetInputs(
 				proof,
 			)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
 			notices = append(notices, *notice)
@@ -243,7 +273,7 @@ func GetInputs(
 				edge.Node.Proof.Context,
 			)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
 			voucher, err := newVoucher(
@@ -254,7 +284,7 @@ func GetInputs(
 				proof,
 			)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
 			vouchers = append(vouchers, *voucher)
@@ -268,12 +298,30 @@ func GetInputs(
 				edge.Node.Payload,
 			)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 
 			reports = append(reports, *report)
 		}
 
+		// the outputs after the first page of each list are got with the queries of the input
+		index := inputEdge.Node.Index
+		notices, err = appendRemaining(ctx, client, notices, GetInputNoticesPage, index,
+			PageInfo(inputEdge.Node.Notices.PageInfo))
+		if err != nil {
+			return nil, nil, err
+		}
+		vouchers, err = appendRemaining(ctx, client, vouchers, GetInputVouchersPage, index,
+			PageInfo(inputEdge.Node.Vouchers.PageInfo))
+		if err != nil {
+			return nil, nil, err
+		}
+		reports, err = appendRemaining(ctx, client, reports, GetInputReportsPage, index,
+			PageInfo(inputEdge.Node.Reports.PageInfo))
+		if err != nil {
+			return nil, nil, err
+		}
+
 		input, err := newInput(
 			inputEdge.Node.Index,
 			inputEdge.Node.Status,
@@ -286,11 +334,35 @@ func GetInputs(
 			reports,
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		inputs = append(inputs, *input)
 	}
 
-	return inputs, err
+	pageInfo := &PageInfo{
+		EndCursor:   resp.Inputs.PageInfo.EndCursor,
+		HasNextPage: resp.Inputs.PageInfo.HasNextPage,
+	}
+	return inputs, pageInfo, err
+}
+
+// Append the entries of the input after the page described by pageInfo, if there are any.
+func appendRemaining[T any](
+	ctx context.Context,
+	client graphql.Client,
+	entries []T,
+	fetch InputPageFunc[T],
+	inputIndex int,
+	pageInfo PageInfo,
+) ([]T, error) {
+	if !pageInfo.HasNextPage || pageInfo.EndCursor == "" {
+		return entries, nil
+	}
+	it := NewIterator(client, ForInput(fetch, inputIndex), DefaultPageSize, pageInfo.EndCursor)
+	remaining, err := it.All(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return append(entries, remaining...), nil
 }
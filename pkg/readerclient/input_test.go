@@ -0,0 +1,77 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package readerclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/stretchr/testify/require"
+)
+
+// GraphQL client that answers each request with the data returned by the handler.
+type fakeGraphQLClient struct {
+	handler func(req *graphql.Request) string
+}
+
+func (c *fakeGraphQLClient) MakeRequest(
+	_ context.Context,
+	req *graphql.Request,
+	resp *graphql.Response,
+) error {
+	return json.Unmarshal([]byte(c.handler(req)), resp.Data)
+}
+
+// Connection with the reports of the given indices in JSON.
+func reportConnection(endCursor string, hasNextPage bool, indices ...int) string {
+	edges := "["
+	for i, index := range indices {
+		if i > 0 {
+			edges += ","
+		}
+		edges += fmt.Sprintf(`{"node": {"index": %v, "payload": "0x"}}`, index)
+	}
+	edges += "]"
+	return fmt.Sprintf(`{"pageInfo": {"endCursor": %q, "hasNextPage": %v}, "edges": %v}`,
+		endCursor, hasNextPage, edges)
+}
+
+func TestGetInputGetsAllThePagesOfTheOutputs(t *testing.T) {
+	const empty = `{"pageInfo": {"endCursor": "", "hasNextPage": false}, "edges": []}`
+	var requests []string
+	client := &fakeGraphQLClient{handler: func(req *graphql.Request) string {
+		switch variables := req.Variables.(type) {
+		case *__getInputInput:
+			requests = append(requests, req.OpName)
+			return fmt.Sprintf(`{"input": {"index": %v, "status": "ACCEPTED",
+				"msgSender": "0x0000000000000000000000000000000000000001", "timestamp": "0",
+				"blockNumber": "0", "payload": "0x", "notices": %v, "vouchers": %v,
+				"reports": %v}}`, variables.Index, empty, empty, reportConnection("r1", true, 0, 1))
+		case *__getInputReportsInput:
+			requests = append(requests, req.OpName+"/"+variables.After)
+			if variables.After == "r1" {
+				return fmt.Sprintf(`{"input": {"index": %v, "reports": %v}}`,
+					variables.InputIndex, reportConnection("r3", true, 2, 3))
+			}
+			return fmt.Sprintf(`{"input": {"index": %v, "reports": %v}}`,
+				variables.InputIndex, reportConnection("r4", false, 4))
+		}
+		t.Fatalf("unexpected request %v", req.OpName)
+		return ""
+	}}
+
+	input, err := GetInput(context.Background(), client, 7)
+	require.Nil(t, err)
+	require.Equal(t, []string{"getInput", "getInputReports/r1", "getInputReports/r3"}, requests)
+	require.Len(t, input.Reports, 5)
+	for i, report := range input.Reports {
+		require.Equal(t, i, report.Index)
+		require.Equal(t, 7, report.InputIndex)
+	}
+	require.Empty(t, input.Notices)
+	require.Empty(t, input.Vouchers)
+}
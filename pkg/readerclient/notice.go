@@ -44,16 +44,29 @@ func newNotice(
 }
 
 // Get multiple notices from graphql.
+// It walks all the pages with DefaultPageSize entries each.
 func GetNotices(
 	ctx context.Context,
 	client graphql.Client,
 ) ([]Notice, error) {
+	return NewIterator(client, GetNoticesPage, DefaultPageSize, "").All(ctx)
+}
+
+// Get a page of notices from GraphQL with up to first notices after the given cursor.
+// If first is zero, the page size is chosen by the server. If after is empty, the page starts at
+// the first notice.
+func GetNoticesPage(
+	ctx context.Context,
+	client graphql.Client,
+	first int,
+	after string,
+) ([]Notice, *PageInfo, error) {
 
 	var notices []Notice
 
-	resp, err := getNotices(ctx, client)
+	resp, err := getNotices(ctx, client, first, after)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	for _, edge := range resp.Notices.Edges {
@@ -70,7 +83,7 @@ func GetNotices(
 			edge.Node.Proof.Context,
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		notice, err := newNotice(
@@ -80,27 +93,46 @@ func GetNotices(
 			proof,
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		notices = append(notices, *notice)
 	}
 
-	return notices, err
+	pageInfo := &PageInfo{
+		EndCursor:   resp.Notices.PageInfo.EndCursor,
+		HasNextPage: resp.Notices.PageInfo.HasNextPage,
+	}
+	return notices, pageInfo, err
 }
 
 // Get multiple notices from GraphQL for the given input index.
+// It walks all the pages with DefaultPageSize entries each.
 func GetInputNotices(
 	ctx context.Context,
 	client graphql.Client,
 	inputIndex int,
 ) ([]Notice, error) {
+	fetch := ForInput(GetInputNoticesPage, inputIndex)
+	return NewIterator(client, fetch, DefaultPageSize, "").All(ctx)
+}
+
+// Get a page of notices from GraphQL for the given input index with up to first notices after the
+// given cursor. If first is zero, the page size is chosen by the server. If after is empty, the
+// page starts at the first notice of the input.
+func GetInputNoticesPage(
+	ctx context.Context,
+	client graphql.Client,
+	inputIndex int,
+	first int,
+	after string,
+) ([]Notice, *PageInfo, error) {
 
 	var notices []Notice
 
-	resp, err := getInputNotices(ctx, client, inputIndex)
+	resp, err := getInputNotices(ctx, client, inputIndex, first, after)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	for _, edge := range resp.Input.Notices.Edges {
@@ -117,7 +149,7 @@ func GetInputNotices(
 			edge.Node.Proof.Context,
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		notice, err := newNotice(
@@ -127,13 +159,17 @@ func GetInputNotices(
 			proof,
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		notices = append(notices, *notice)
 	}
 
-	return notices, err
+	pageInfo := &PageInfo{
+		EndCursor:   resp.Input.Notices.PageInfo.EndCursor,
+		HasNextPage: resp.Input.Notices.PageInfo.HasNextPage,
+	}
+	return notices, pageInfo, err
 }
 
 // Get notice from GraphQL given the input and notice indices.
@@ -0,0 +1,130 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package readerclient
+
+import (
+	"context"
+
+	"github.com/Khan/genqlient/graphql"
+)
+
+// Position of a page in a list paginated by the GraphQL API.
+type PageInfo struct {
+	// Cursor of the last entry of the page, used to get the next page
+	EndCursor string `json:"endCursor"`
+	// Whether there are entries after the page
+	HasNextPage bool `json:"hasNextPage"`
+}
+
+// Default number of entries per page of the functions that get all the entries of a list.
+const DefaultPageSize = 100
+
+// Function that gets a page with up to first entries after the cursor, such as GetVouchersPage.
+type PageFunc[T any] func(
+	ctx context.Context,
+	client graphql.Client,
+	first int,
+	after string,
+) ([]T, *PageInfo, error)
+
+// Iterator over the pages of a list paginated by the GraphQL API.
+//
+//	it := readerclient.NewIterator(client, readerclient.GetVouchersPage, 500, "")
+//	for it.Next(ctx) {
+//		process(it.Page())
+//	}
+//	if it.Err() != nil {
+//		...
+//	}
+type Iterator[T any] struct {
+	client   graphql.Client
+	fetch    PageFunc[T]
+	pageSize int
+	cursor   string
+	done     bool
+	page     []T
+	err      error
+}
+
+// Create an iterator over the pages of the list with up to pageSize entries each, starting
+// after the cursor. If pageSize is zero, it uses DefaultPageSize. If after is empty, it starts
+// at the first entry.
+func NewIterator[T any](
+	client graphql.Client,
+	fetch PageFunc[T],
+	pageSize int,
+	after string,
+) *Iterator[T] {
+	if pageSize <= 0 {
+		pageSize = DefaultPageSize
+	}
+	return &Iterator[T]{client: client, fetch: fetch, pageSize: pageSize, cursor: after}
+}
+
+// Get the next page, which is returned by Page.
+// It returns false when there are no more pages or the request fails, which is reported by Err.
+func (it *Iterator[T]) Next(ctx context.Context) bool {
+	if it.done {
+		return false
+	}
+	page, pageInfo, err := it.fetch(ctx, it.client, it.pageSize, it.cursor)
+	if err != nil {
+		it.err = err
+		it.done = true
+		return false
+	}
+	it.page = page
+	if pageInfo.EndCursor != "" {
+		it.cursor = pageInfo.EndCursor
+	}
+	it.done = !pageInfo.HasNextPage || pageInfo.EndCursor == "" || len(page) == 0
+	return true
+}
+
+// Page got by the last call to Next.
+func (it *Iterator[T]) Page() []T {
+	return it.page
+}
+
+// Cursor of the last entry got by the iterator, which may be used to resume it later.
+func (it *Iterator[T]) Cursor() string {
+	return it.cursor
+}
+
+// Error that stopped the iterator, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// Get the remaining entries of the iterator, walking all the pages.
+// If a request fails, it returns the entries got so far with the error.
+func (it *Iterator[T]) All(ctx context.Context) ([]T, error) {
+	var entries []T
+	for it.Next(ctx) {
+		entries = append(entries, it.Page()...)
+	}
+	return entries, it.Err()
+}
+
+// Function that gets a page with up to first entries of the input after the cursor, such as
+// GetInputVouchersPage.
+type InputPageFunc[T any] func(
+	ctx context.Context,
+	client graphql.Client,
+	inputIndex int,
+	first int,
+	after string,
+) ([]T, *PageInfo, error)
+
+// Get the PageFunc that gets the pages of the entries of the input.
+func ForInput[T any](fetch InputPageFunc[T], inputIndex int) PageFunc[T] {
+	return func(
+		ctx context.Context,
+		client graphql.Client,
+		first int,
+		after string,
+	) ([]T, *PageInfo, error) {
+		return fetch(ctx, client, inputIndex, first, after)
+	}
+}
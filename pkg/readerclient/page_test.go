@@ -0,0 +1,88 @@
+// (c) Cartesi and individual authors (see AUTHORS)
+// SPDX-License-Identifier: Apache-2.0 (see LICENSE)
+
+package readerclient
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/Khan/genqlient/graphql"
+	"github.com/stretchr/testify/require"
+)
+
+// Page function over the integers [0, n), whose cursors are the entries themselves.
+type fakePages struct {
+	n     int
+	calls []string
+	err   error
+}
+
+func (f *fakePages) fetch(
+	_ context.Context,
+	_ graphql.Client,
+	first int,
+	after string,
+) ([]int, *PageInfo, error) {
+	f.calls = append(f.calls, strconv.Itoa(first)+"/"+after)
+	if f.err != nil && len(f.calls) > 1 {
+		return nil, nil, f.err
+	}
+	start := 0
+	if after != "" {
+		cursor, _ := strconv.Atoi(after)
+		start = cursor + 1
+	}
+	var page []int
+	for i := start; i < f.n && len(page) < first; i++ {
+		page = append(page, i)
+	}
+	pageInfo := &PageInfo{HasNextPage: start+len(page) < f.n}
+	if len(page) > 0 {
+		pageInfo.EndCursor = strconv.Itoa(page[len(page)-1])
+	}
+	return page, pageInfo, nil
+}
+
+func TestIteratorWalksAllPages(t *testing.T) {
+	pages := &fakePages{n: 7}
+	it := NewIterator(nil, pages.fetch, 3, "")
+
+	var sizes []int
+	for it.Next(context.Background()) {
+		sizes = append(sizes, len(it.Page()))
+	}
+	require.Nil(t, it.Err())
+	require.Equal(t, []int{3, 3, 1}, sizes)
+	require.Equal(t, []string{"3/", "3/2", "3/5"}, pages.calls)
+	require.Equal(t, "6", it.Cursor())
+	require.False(t, it.Next(context.Background()))
+}
+
+func TestIteratorAll(t *testing.T) {
+	pages := &fakePages{n: 5}
+	entries, err := NewIterator(nil, pages.fetch, 0, "1").All(context.Background())
+	require.Nil(t, err)
+	require.Equal(t, []int{2, 3, 4}, entries)
+	require.Equal(t, []string{"100/1"}, pages.calls)
+
+	pages = &fakePages{n: 0}
+	entries, err = NewIterator(nil, pages.fetch, 2, "").All(context.Background())
+	require.Nil(t, err)
+	require.Empty(t, entries)
+	require.Len(t, pages.calls, 1)
+}
+
+func TestIteratorStopsOnError(t *testing.T) {
+	pages := &fakePages{n: 5, err: errors.New("connection refused")}
+	it := NewIterator(nil, pages.fetch, 2, "")
+
+	entries, err := it.All(context.Background())
+	require.ErrorContains(t, err, "connection refused")
+	require.Equal(t, []int{0, 1}, entries)
+	require.Equal(t, "1", it.Cursor())
+	require.False(t, it.Next(context.Background()))
+	require.Len(t, pages.calls, 2)
+}
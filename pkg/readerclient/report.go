@@ -40,16 +40,29 @@ func newReport(
 }
 
 // Get multiple reports from graphql.
+// It walks all the pages with DefaultPageSize entries each.
 func GetReports(
 	ctx context.Context,
 	client graphql.Client,
 ) ([]Report, error) {
+	return NewIterator(client, GetReportsPage, DefaultPageSize, "").All(ctx)
+}
+
+// Get a page of reports from GraphQL with up to first reports after the given cursor.
+// If first is zero, the page size is chosen by the server. If after is empty, the page starts at
+// the first report.
+func GetReportsPage(
+	ctx context.Context,
+	client graphql.Client,
+	first int,
+	after string,
+) ([]Report, *PageInfo, error) {
 
 	var reports []Report
 
-	resp, err := getReports(ctx, client)
+	resp, err := getReports(ctx, client, first, after)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	for _, edge := range resp.Reports.Edges {
@@ -60,27 +73,46 @@ func GetReports(
 			edge.Node.Payload,
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		reports = append(reports, *report)
 	}
 
-	return reports, err
+	pageInfo := &PageInfo{
+		EndCursor:   resp.Reports.PageInfo.EndCursor,
+		HasNextPage: resp.Reports.PageInfo.HasNextPage,
+	}
+	return reports, pageInfo, err
 }
 
 // Get multiple reports from GraphQL for the given input index.
+// It walks all the pages with DefaultPageSize entries each.
 func GetInputReports(
 	ctx context.Context,
 	client graphql.Client,
 	inputIndex int,
 ) ([]Report, error) {
+	fetch := ForInput(GetInputReportsPage, inputIndex)
+	return NewIterator(client, fetch, DefaultPageSize, "").All(ctx)
+}
+
+// Get a page of reports from GraphQL for the given input index with up to first reports after the
+// given cursor. If first is zero, the page size is chosen by the server. If after is empty, the
+// page starts at the first report of the input.
+func GetInputReportsPage(
+	ctx context.Context,
+	client graphql.Client,
+	inputIndex int,
+	first int,
+	after string,
+) ([]Report, *PageInfo, error) {
 
 	var reports []Report
 
-	resp, err := getInputReports(ctx, client, inputIndex)
+	resp, err := getInputReports(ctx, client, inputIndex, first, after)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	for _, edge := range resp.Input.Reports.Edges {
@@ -91,13 +123,17 @@ func GetInputReports(
 			edge.Node.Payload,
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		reports = append(reports, *report)
 	}
 
-	return reports, err
+	pageInfo := &PageInfo{
+		EndCursor:   resp.Input.Reports.PageInfo.EndCursor,
+		HasNextPage: resp.Input.Reports.PageInfo.HasNextPage,
+	}
+	return reports, pageInfo, err
 }
 
 // Get report from GraphQL given the input and report indices.
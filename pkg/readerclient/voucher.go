@@ -49,16 +49,29 @@ func newVoucher(
 }
 
 // Get multiple vouchers from graphql.
+// It walks all the pages with DefaultPageSize entries each.
 func GetVouchers(
 	ctx context.Context,
 	client graphql.Client,
 ) ([]Voucher, error) {
+	return NewIterator(client, GetVouchersPage, DefaultPageSize, "").All(ctx)
+}
+
+// Get a page of vouchers from GraphQL with up to first vouchers after the given cursor.
+// If first is zero, the page size is chosen by the server. If after is empty, the page starts at
+// the first voucher.
+func GetVouchersPage(
+	ctx context.Context,
+	client graphql.Client,
+	first int,
+	after string,
+) ([]Voucher, *PageInfo, error) {
 
 	var vouchers []Voucher
 
-	resp, err := getVouchers(ctx, client)
+	resp, err := getVouchers(ctx, client, first, after)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	for _, edge := range resp.Vouchers.Edges {
@@ -75,7 +88,7 @@ func GetVouchers(
 			edge.Node.Proof.Context,
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		voucher, err := newVoucher(
@@ -86,27 +99,46 @@ func GetVouchers(
 			proof,
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		vouchers = append(vouchers, *voucher)
 	}
 
-	return vouchers, err
+	pageInfo := &PageInfo{
+		EndCursor:   resp.Vouchers.PageInfo.EndCursor,
+		HasNextPage: resp.Vouchers.PageInfo.HasNextPage,
+	}
+	return vouchers, pageInfo, err
 }
 
 // Get multiple vouchers from GraphQL for the given input index.
+// It walks all the pages with DefaultPageSize entries each.
 func GetInputVouchers(
 	ctx context.Context,
 	client graphql.Client,
 	inputIndex int,
 ) ([]Voucher, error) {
+	fetch := ForInput(GetInputVouchersPage, inputIndex)
+	return NewIterator(client, fetch, DefaultPageSize, "").All(ctx)
+}
+
+// Get a page of vouchers from GraphQL for the given input index with up to first vouchers after the
+// given cursor. If first is zero, the page size is chosen by the server. If after is empty, the
+// page starts at the first voucher of the input.
+func GetInputVouchersPage(
+	ctx context.Context,
+	client graphql.Client,
+	inputIndex int,
+	first int,
+	after string,
+) ([]Voucher, *PageInfo, error) {
 
 	var vouchers []Voucher
 
-	resp, err := getInputVouchers(ctx, client, inputIndex)
+	resp, err := getInputVouchers(ctx, client, inputIndex, first, after)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	for _, edge := range resp.Input.Vouchers.Edges {
@@ -123,7 +155,7 @@ func GetInputVouchers(
 			edge.Node.Proof.Context,
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		voucher, err := newVoucher(
@@ -134,13 +166,17 @@ func GetInputVouchers(
 			proof,
 		)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		vouchers = append(vouchers, *voucher)
 	}
 
-	return vouchers, err
+	pageInfo := &PageInfo{
+		EndCursor:   resp.Input.Vouchers.PageInfo.EndCursor,
+		HasNextPage: resp.Input.Vouchers.PageInfo.HasNextPage,
+	}
+	return vouchers, pageInfo, err
 }
 
 // Get voucher from GraphQL given the input and voucher indices.